@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/crypto/shamir"
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+	utils "github.com/luizgbraga/crypto-go/utils"
+)
+
+const (
+	defaultShares    = 5
+	defaultThreshold = 3
+)
+
+// exportKeyShares splits one of the user's private keys into Shamir shares
+// and prints each as a text block to hand to a different person.
+func exportKeyShares(keyStore keystore.KeyStore, registry *crypto.Registry, signers *crypto.SignerRegistry) {
+	algorithms := privateKeyAlgorithms(keyStore, registry, signers)
+	if len(algorithms) == 0 {
+		fmt.Println("You have no private keys to back up")
+		return
+	}
+
+	fmt.Println("Private keys:")
+	for i, algorithm := range algorithms {
+		fmt.Printf("%d. %s\n", i+1, algorithm)
+	}
+
+	choice := utils.Read("Enter key: ")
+	index, err := strconv.Atoi(choice)
+	if err != nil || index < 1 || index > len(algorithms) {
+		fmt.Printf("Unknown key: %s\n", choice)
+		return
+	}
+	algorithm := algorithms[index-1]
+
+	if crypto.Stateful(algorithm) {
+		fmt.Printf("%s keys cannot be backed up: a restored copy would reuse one-time keys\n", algorithm)
+		return
+	}
+
+	n, err := readCount(fmt.Sprintf("Enter number of shares [%d]: ", defaultShares), defaultShares)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	threshold, err := readCount(fmt.Sprintf("Enter shares needed to recover [%d]: ", defaultThreshold), defaultThreshold)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	privateKey, err := keyStore.GetPrivateKey(algorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	shares, err := shamir.Split(privateKey, n, threshold)
+	if err != nil {
+		fmt.Printf("Error splitting key: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\nAny %d of these %d shares recover your %s key. Give each to a different person.\n", threshold, n, algorithm)
+	for i, share := range shares {
+		fmt.Printf("\nShare %d of %d:\n", i+1, n)
+		fmt.Print(shamir.EncodeShare(string(algorithm), share))
+	}
+}
+
+// recoverKey reads pasted share blocks until there are enough, rebuilds the
+// private key and publishes its public key again.
+func recoverKey(client pb.CryptoServiceClient, keyStore keystore.KeyStore, registry *crypto.Registry, signers *crypto.SignerRegistry, userID string) {
+	var text strings.Builder
+	var label string
+	var shares []shamir.Share
+
+	for len(shares) == 0 || len(shares) < shares[0].Threshold {
+		if len(shares) > 0 {
+			fmt.Printf("Have %d of %d shares.\n", len(shares), shares[0].Threshold)
+		}
+
+		pasted := utils.ReadLines("Paste share blocks, then an empty line (or type cancel):")
+		if pasted == "" || strings.EqualFold(pasted, "cancel") {
+			fmt.Println("Recovery cancelled")
+			return
+		}
+		text.WriteString(pasted + "\n")
+
+		var err error
+		label, shares, err = shamir.DecodeShares(text.String())
+		if err != nil {
+			fmt.Printf("Error reading shares: %v\n", err)
+			return
+		}
+	}
+
+	algorithm := crypto.Algorithm(label)
+	holder, err := keyHolder(registry, signers, algorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if crypto.Stateful(algorithm) {
+		fmt.Printf("%s keys cannot be restored from a backup\n", algorithm)
+		return
+	}
+	if hasPrivateKey(keyStore, algorithm) {
+		fmt.Printf("You already have a %s key; recovering would replace it\n", algorithm)
+		return
+	}
+
+	privateKey, err := shamir.Combine(shares)
+	if err != nil {
+		fmt.Printf("Error combining shares: %v\n", err)
+		return
+	}
+
+	err = keyStore.StorePrivateKey(algorithm, privateKey)
+	if err != nil {
+		fmt.Printf("Error storing key: %v\n", err)
+		return
+	}
+
+	err = registerPublicKey(client, holder, userID, algorithm)
+	if err != nil {
+		keyStore.StorePrivateKey(algorithm, nil)
+		fmt.Printf("Recovered key is not usable: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%s key recovered successfully!\n", algorithm)
+}
+
+// privateKeyAlgorithms lists the key algorithms the user has a private key
+// for, in registry order.
+func privateKeyAlgorithms(keyStore keystore.KeyStore, registry *crypto.Registry, signers *crypto.SignerRegistry) []crypto.Algorithm {
+	var algorithms []crypto.Algorithm
+	for _, algorithm := range append(registry.Algorithms(), signers.Algorithms()...) {
+		algorithm = crypto.KeyAlgorithm(algorithm)
+		if !slices.Contains(algorithms, algorithm) && hasPrivateKey(keyStore, algorithm) {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+	return algorithms
+}
+
+func hasPrivateKey(keyStore keystore.KeyStore, algorithm crypto.Algorithm) bool {
+	privateKey, err := keyStore.GetPrivateKey(algorithm)
+	return err == nil && len(privateKey) > 0
+}
+
+// keyHolder returns the provider or signer that owns keys of the algorithm.
+func keyHolder(registry *crypto.Registry, signers *crypto.SignerRegistry, algorithm crypto.Algorithm) (crypto.KeyHolder, error) {
+	if provider, err := registry.Get(algorithm); err == nil {
+		return provider, nil
+	}
+	return signers.Get(algorithm)
+}
+
+func readCount(message string, defaultValue int) (int, error) {
+	input := utils.Read(message)
+	if input == "" {
+		return defaultValue, nil
+	}
+
+	count, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %s", input)
+	}
+	return count, nil
+}
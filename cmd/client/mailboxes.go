@@ -0,0 +1,714 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/crypto/elgamal"
+	"github.com/luizgbraga/crypto-go/internal/crypto/threshold"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+	utils "github.com/luizgbraga/crypto-go/utils"
+)
+
+// shareAlgorithm is the algorithm dealers encrypt key shares with.
+const shareAlgorithm = crypto.ECIESX25519
+
+const (
+	CmdCreateMailbox      = "1"
+	CmdListMailboxes      = "2"
+	CmdDealMailboxKey     = "3"
+	CmdCheckMailboxShares = "4"
+	CmdSendMailboxMessage = "5"
+	CmdReadMailbox        = "6"
+	CmdMailboxesBack      = "7"
+)
+
+func mailboxesMenu(client pb.CryptoServiceClient, registry *crypto.Registry, userID string) {
+	for {
+		fmt.Println("\nShared Mailbox Commands:")
+		fmt.Printf("%s. Create mailbox\n", CmdCreateMailbox)
+		fmt.Printf("%s. List mailboxes\n", CmdListMailboxes)
+		fmt.Printf("%s. Contribute key share\n", CmdDealMailboxKey)
+		fmt.Printf("%s. Check key shares\n", CmdCheckMailboxShares)
+		fmt.Printf("%s. Send to mailbox\n", CmdSendMailboxMessage)
+		fmt.Printf("%s. Read mailbox\n", CmdReadMailbox)
+		fmt.Printf("%s. Back\n", CmdMailboxesBack)
+
+		cmd := utils.Read("Enter command: ")
+
+		switch cmd {
+		case CmdCreateMailbox:
+			createMailbox(client, registry, userID)
+		case CmdListMailboxes:
+			listMailboxes(client)
+		case CmdDealMailboxKey:
+			mailbox, err := findMailbox(client, utils.Read("Enter mailbox ID: "))
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			dealMailboxKey(client, registry, mailbox, userID)
+		case CmdCheckMailboxShares:
+			checkMailboxShares(client, registry, userID)
+		case CmdSendMailboxMessage:
+			sendMailboxMessage(client, userID)
+		case CmdReadMailbox:
+			readMailbox(client, registry, userID)
+		case CmdMailboxesBack:
+			fmt.Println("Returning to main menu")
+			return
+		default:
+			fmt.Println("Unknown command")
+		}
+	}
+}
+
+// createMailbox creates a mailbox with the user as a member and deals the
+// user's share of its key right away.
+func createMailbox(client pb.CryptoServiceClient, registry *crypto.Registry, userID string) {
+	var members []string
+	for _, member := range strings.Split(utils.Read("Enter the other members' IDs (comma-separated): "), ",") {
+		if member = strings.TrimSpace(member); member != "" {
+			members = append(members, member)
+		}
+	}
+
+	t, err := readCount("Enter how many members must cooperate to decrypt [2]: ", 2)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Groups: %s\n", strings.Join(elgamal.GroupNames(), ", "))
+	groupName := utils.Read("Enter group [modp2048]: ")
+	if groupName == "" {
+		groupName = "modp2048"
+	}
+
+	resp, err := client.CreateMailbox(context.Background(), &pb.CreateMailboxRequest{
+		CreatorId: userID,
+		MemberIds: members,
+		Threshold: int32(t),
+		Group:     groupName,
+	})
+	if err != nil {
+		fmt.Printf("Error creating mailbox: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to create mailbox: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Printf("Mailbox %s created! Every member must now contribute a key share, then check theirs.\n", resp.MailboxId)
+
+	mailbox, err := findMailbox(client, resp.MailboxId)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	dealMailboxKey(client, registry, mailbox, userID)
+}
+
+func listMailboxes(client pb.CryptoServiceClient) {
+	mailboxes, err := client.GetMailboxes(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		fmt.Printf("Error listing mailboxes: %v\n", err)
+		return
+	}
+
+	fmt.Println("\nMailboxes:")
+	if len(mailboxes.Mailboxes) == 0 {
+		fmt.Println("No mailboxes yet.")
+	}
+	for _, mailbox := range mailboxes.Mailboxes {
+		status := "ready"
+		if len(mailbox.PublicKey) == 0 {
+			status = fmt.Sprintf("%d of %d key shares, %d of %d checked, %d complaint(s)",
+				len(dealerIDs(mailbox)), len(mailbox.MemberIds), len(mailbox.AcknowledgedIds), len(mailbox.MemberIds), len(mailbox.Complaints))
+		}
+		fmt.Printf("- %s: %s (%d of %d needed to decrypt, %s, %s)\n", mailbox.MailboxId,
+			strings.Join(mailbox.MemberIds, ", "), mailbox.Threshold, len(mailbox.MemberIds), mailbox.Group, status)
+	}
+}
+
+// findMailbox looks a mailbox up by ID in the server's list.
+func findMailbox(client pb.CryptoServiceClient, mailboxID string) (*pb.Mailbox, error) {
+	mailboxes, err := client.GetMailboxes(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mailbox := range mailboxes.Mailboxes {
+		if mailbox.MailboxId == mailboxID {
+			return mailbox, nil
+		}
+	}
+
+	return nil, fmt.Errorf("mailbox %s not found", mailboxID)
+}
+
+// dealerIDs lists the members who have dealt, whether or not their dealing
+// is disputed.
+func dealerIDs(mailbox *pb.Mailbox) []string {
+	dealers := slices.Clone(mailbox.DealerIds)
+	for _, complaint := range mailbox.Complaints {
+		if !slices.Contains(dealers, complaint.DealerId) {
+			dealers = append(dealers, complaint.DealerId)
+		}
+	}
+	return dealers
+}
+
+// memberIndex returns the user's number in the mailbox's key sharing,
+// counting from 1, or 0 if the user is not a member.
+func memberIndex(mailbox *pb.Mailbox, userID string) int {
+	for i, member := range mailbox.MemberIds {
+		if member == userID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// shareProvider returns the provider key shares are encrypted with,
+// creating and registering the user's key first if there is none.
+func shareProvider(client pb.CryptoServiceClient, registry *crypto.Registry, userID string) (crypto.Provider, error) {
+	provider, err := registry.Get(shareAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := provider.PublicKey(); err != nil {
+		fmt.Printf("Generating %s key for key shares...\n", shareAlgorithm)
+		if err := provider.GenerateKey(); err != nil {
+			return nil, fmt.Errorf("creating %s key: %v", shareAlgorithm, err)
+		}
+	}
+
+	err = registerPublicKey(client, provider, userID, shareAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("registering public key: %v", err)
+	}
+
+	return provider, nil
+}
+
+// dealMailboxKey contributes the user's part of the mailbox key: a random
+// polynomial whose commitments are public and whose values are sent to each
+// member encrypted under their ECIES-X25519 key. The user keeps a copy of
+// every share, encrypted to itself and stored with the dealing, to answer
+// complaints with.
+func dealMailboxKey(client pb.CryptoServiceClient, registry *crypto.Registry, mailbox *pb.Mailbox, userID string) {
+	if memberIndex(mailbox, userID) == 0 {
+		fmt.Println("Error: you are not a member of this mailbox")
+		return
+	}
+
+	group, err := threshold.LookupGroup(mailbox.Group)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	provider, err := shareProvider(client, registry, userID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	for _, member := range mailbox.MemberIds {
+		err := fetchPublicKey(client, provider, member, shareAlgorithm)
+		if err != nil {
+			fmt.Printf("Cannot deal yet: %s has no %s key: %v\n", member, shareAlgorithm, err)
+			return
+		}
+	}
+
+	dealing, err := threshold.Deal(group, int(mailbox.Threshold), len(mailbox.MemberIds))
+	if err != nil {
+		fmt.Printf("Error creating key share: %v\n", err)
+		return
+	}
+
+	commitments := make([][]byte, len(dealing.Commitments))
+	for i, c := range dealing.Commitments {
+		commitments[i] = c.Bytes()
+	}
+
+	encryptedShares := make(map[string][]byte, len(mailbox.MemberIds))
+	shares := make([]string, len(mailbox.MemberIds))
+	for i, member := range mailbox.MemberIds {
+		encryptedShares[member], err = provider.Encrypt(dealing.Shares[i+1].Bytes(), member)
+		if err != nil {
+			fmt.Printf("Error encrypting share for %s: %v\n", member, err)
+			return
+		}
+		shares[i] = dealing.Shares[i+1].String()
+	}
+
+	dealerCopy, err := provider.Encrypt([]byte(strings.Join(shares, ",")), userID)
+	if err != nil {
+		fmt.Printf("Error encrypting copy of the shares: %v\n", err)
+		return
+	}
+
+	resp, err := client.SubmitDealing(context.Background(), &pb.SubmitDealingRequest{
+		MailboxId:       mailbox.MailboxId,
+		DealerId:        userID,
+		Commitments:     commitments,
+		EncryptedShares: encryptedShares,
+		DealerCopy:      dealerCopy,
+	})
+	if err != nil {
+		fmt.Printf("Error submitting key share: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to submit key share: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Println("Key share submitted!")
+}
+
+// checkMailboxShares answers complaints against the user's dealing, then
+// checks every share dealt to the user against its dealer's commitments. It
+// complains about the dealers whose shares do not match, or acknowledges the
+// shares if all of them do.
+func checkMailboxShares(client pb.CryptoServiceClient, registry *crypto.Registry, userID string) {
+	mailbox, err := findMailbox(client, utils.Read("Enter mailbox ID: "))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if memberIndex(mailbox, userID) == 0 {
+		fmt.Println("Error: you are not a member of this mailbox")
+		return
+	}
+
+	provider, err := registry.Get(shareAlgorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	group, err := threshold.LookupGroup(mailbox.Group)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	dealings, err := getDealings(client, mailbox, userID)
+	if err != nil {
+		fmt.Printf("Error getting dealings: %v\n", err)
+		return
+	}
+
+	for _, dealing := range dealings {
+		if dealing.DealerId == userID {
+			answerComplaints(client, provider, mailbox, dealing, userID)
+		}
+	}
+
+	var complaints []string
+	for _, dealing := range dealings {
+		// A disputed dealing is checked again if it is reinstated.
+		if dealing.Disputed {
+			continue
+		}
+
+		if _, err := dealtShare(provider, group, dealing, memberIndex(mailbox, userID)); err != nil {
+			fmt.Printf("Share from %s is invalid: %v\n", dealing.DealerId, err)
+			complaints = append(complaints, dealing.DealerId)
+		}
+	}
+
+	resp, err := client.CheckDealings(context.Background(), &pb.CheckDealingsRequest{
+		MailboxId:  mailbox.MailboxId,
+		MemberId:   userID,
+		Complaints: complaints,
+	})
+	if err != nil {
+		fmt.Printf("Error checking key shares: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to check key shares: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Println(resp.Message)
+}
+
+// answerComplaints reveals the share the user dealt to each member that
+// complained about it, taken from the user's copy of the dealing.
+func answerComplaints(client pb.CryptoServiceClient, provider crypto.Provider, mailbox *pb.Mailbox, dealing *pb.Dealing, userID string) {
+	var complainants []string
+	for _, complaint := range mailbox.Complaints {
+		if complaint.DealerId == userID {
+			complainants = append(complainants, complaint.MemberId)
+		}
+	}
+	if len(complainants) == 0 {
+		return
+	}
+
+	copyBytes, err := provider.Decrypt(dealing.DealerCopy)
+	if err != nil {
+		fmt.Printf("Cannot answer complaints: decrypting your copy of the shares: %v\n", err)
+		return
+	}
+	shares := strings.Split(string(copyBytes), ",")
+	if len(shares) != len(mailbox.MemberIds) {
+		fmt.Println("Cannot answer complaints: your copy of the shares is malformed")
+		return
+	}
+
+	for _, member := range complainants {
+		share, ok := new(big.Int).SetString(shares[memberIndex(mailbox, member)-1], 10)
+		if !ok {
+			fmt.Printf("Cannot answer %s: your copy of the share is malformed\n", member)
+			continue
+		}
+
+		resp, err := client.AnswerComplaint(context.Background(), &pb.AnswerComplaintRequest{
+			MailboxId: mailbox.MailboxId,
+			DealerId:  userID,
+			MemberId:  member,
+			Share:     share.Bytes(),
+		})
+		if err != nil {
+			fmt.Printf("Error answering %s: %v\n", member, err)
+			continue
+		}
+		if !resp.Success {
+			fmt.Printf("Failed to answer %s: %s\n", member, resp.Message)
+			continue
+		}
+
+		fmt.Printf("Revealed the share dealt to %s, who complained about it\n", member)
+	}
+}
+
+// dealtShare returns the share a dealing gives member j: the one the dealer
+// revealed after a complaint, if any, or else the encrypted one. Either way
+// it must match the dealer's commitments.
+func dealtShare(provider crypto.Provider, group *threshold.Group, dealing *pb.Dealing, j int) (*big.Int, error) {
+	shareBytes := dealing.RevealedShare
+	if len(shareBytes) == 0 {
+		var err error
+		shareBytes, err = provider.Decrypt(dealing.EncryptedShare)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting share: %v", err)
+		}
+	}
+
+	share := new(big.Int).SetBytes(shareBytes)
+	if err := threshold.VerifyShare(group, dealingCommitments(dealing), j, share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// sendMailboxMessage encrypts the message with ElGamal under the mailbox
+// key. Anyone can send; only members can read. Members check the key
+// against the dealings first, so the server cannot swap in a key of its own.
+func sendMailboxMessage(client pb.CryptoServiceClient, userID string) {
+	mailbox, err := findMailbox(client, utils.Read("Enter mailbox ID: "))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(mailbox.PublicKey) == 0 {
+		fmt.Println("Error: the mailbox key is not ready; waiting for key shares and checks")
+		return
+	}
+
+	keyPair, err := elgamal.DecodePublicKey(string(mailbox.PublicKey))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if memberIndex(mailbox, userID) != 0 {
+		if err := checkMailboxKey(client, mailbox, keyPair, userID); err != nil {
+			fmt.Printf("Error: the server's mailbox key cannot be trusted: %v\n", err)
+			return
+		}
+	}
+
+	message := utils.Read("Enter message: ")
+
+	k, err := elgamal.RandomEphemeral(&keyPair.P)
+	if err != nil {
+		fmt.Printf("Error encrypting message: %v\n", err)
+		return
+	}
+
+	a, b, err := elgamal.Encrypt(keyPair, []byte(message), k)
+	if err != nil {
+		fmt.Printf("Error encrypting message: %v\n", err)
+		return
+	}
+
+	encrypted, err := elgamal.EncodeCiphertext(a, b, &keyPair.P)
+	if err != nil {
+		fmt.Printf("Error encrypting message: %v\n", err)
+		return
+	}
+
+	resp, err := client.SendMailboxMessage(context.Background(), &pb.SendMailboxMessageRequest{
+		MailboxId:        mailbox.MailboxId,
+		SenderId:         userID,
+		EncryptedMessage: encrypted,
+	})
+	if err != nil {
+		fmt.Printf("Error sending message: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to send message: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Println("Message sent successfully!")
+}
+
+// checkMailboxKey recomputes the mailbox key from the commitments of every
+// dealing and compares it with the key the server published.
+func checkMailboxKey(client pb.CryptoServiceClient, mailbox *pb.Mailbox, keyPair *elgamal.ElGamalKeyPair, userID string) error {
+	group, err := threshold.LookupGroup(mailbox.Group)
+	if err != nil {
+		return err
+	}
+
+	dealings, err := getDealings(client, mailbox, userID)
+	if err != nil {
+		return err
+	}
+
+	var commitments [][]*big.Int
+	for _, dealing := range dealings {
+		if !dealing.Disputed {
+			commitments = append(commitments, dealingCommitments(dealing))
+		}
+	}
+	if len(commitments) == 0 {
+		return errors.New("no dealings")
+	}
+
+	y := threshold.PublicKey(group, commitments)
+	if keyPair.P.Cmp(group.P) != 0 || keyPair.G.Cmp(group.G) != 0 || keyPair.Y.Cmp(y) != 0 {
+		return errors.New("published key does not match the dealings")
+	}
+	return nil
+}
+
+// getDealings returns the dealings of the mailbox, each with the share
+// dealt to the user.
+func getDealings(client pb.CryptoServiceClient, mailbox *pb.Mailbox, userID string) ([]*pb.Dealing, error) {
+	resp, err := client.GetDealings(context.Background(), &pb.GetDealingsRequest{
+		MailboxId: mailbox.MailboxId,
+		MemberId:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, errors.New(resp.Message)
+	}
+
+	return resp.Dealings, nil
+}
+
+func dealingCommitments(dealing *pb.Dealing) []*big.Int {
+	commitments := make([]*big.Int, len(dealing.Commitments))
+	for i, c := range dealing.Commitments {
+		commitments[i] = new(big.Int).SetBytes(c)
+	}
+	return commitments
+}
+
+// mailboxKey is a member's view of a mailbox key: the member's own key
+// share and every member's verification key.
+type mailboxKey struct {
+	group            *threshold.Group
+	index            int
+	share            *big.Int
+	verificationKeys map[int]*big.Int
+}
+
+// loadMailboxKey decrypts the shares dealt to the user by the dealings the
+// key was made from, checks each against its dealer's commitments and adds
+// them up into the user's key share.
+func loadMailboxKey(client pb.CryptoServiceClient, provider crypto.Provider, mailbox *pb.Mailbox, userID string) (*mailboxKey, error) {
+	group, err := threshold.LookupGroup(mailbox.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	dealings, err := getDealings(client, mailbox, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &mailboxKey{
+		group:            group,
+		index:            memberIndex(mailbox, userID),
+		verificationKeys: make(map[int]*big.Int),
+	}
+
+	var shares []*big.Int
+	var allCommitments [][]*big.Int
+	for _, dealing := range dealings {
+		if dealing.Disputed {
+			continue
+		}
+
+		share, err := dealtShare(provider, group, dealing, key.index)
+		if err != nil {
+			return nil, fmt.Errorf("share from %s: %v", dealing.DealerId, err)
+		}
+
+		shares = append(shares, share)
+		allCommitments = append(allCommitments, dealingCommitments(dealing))
+	}
+
+	key.share = threshold.KeyShare(group, shares)
+	for j := range mailbox.MemberIds {
+		key.verificationKeys[j+1] = threshold.VerificationKey(group, allCommitments, j+1)
+	}
+
+	return key, nil
+}
+
+// readMailbox contributes the user's partial decryption of every message
+// that does not have one yet, and shows the messages for which enough
+// members have contributed.
+func readMailbox(client pb.CryptoServiceClient, registry *crypto.Registry, userID string) {
+	mailbox, err := findMailbox(client, utils.Read("Enter mailbox ID: "))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if memberIndex(mailbox, userID) == 0 {
+		fmt.Println("Error: you are not a member of this mailbox")
+		return
+	}
+	if len(mailbox.PublicKey) == 0 {
+		fmt.Println("Error: the mailbox key is not ready; waiting for key shares and checks")
+		return
+	}
+
+	provider, err := registry.Get(shareAlgorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	key, err := loadMailboxKey(client, provider, mailbox, userID)
+	if err != nil {
+		fmt.Printf("Error loading key share: %v\n", err)
+		return
+	}
+
+	resp, err := client.GetMailboxMessages(context.Background(), &pb.GetMailboxMessagesRequest{
+		MailboxId: mailbox.MailboxId,
+		MemberId:  userID,
+	})
+	if err != nil {
+		fmt.Printf("Error getting messages: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to get messages: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Printf("\nMailbox %s:\n", mailbox.MailboxId)
+	if len(resp.Messages) == 0 {
+		fmt.Println("No messages yet.")
+	}
+	for _, msg := range resp.Messages {
+		fmt.Printf("\nMessage %d from %s:\n", msg.MessageId, msg.SenderId)
+
+		plaintext, err := decryptMailboxMessage(client, key, mailbox, msg, userID)
+		if err != nil {
+			fmt.Printf("Not decrypted: %v\n", err)
+			continue
+		}
+		fmt.Printf("Message: %s\n", plaintext)
+	}
+}
+
+// decryptMailboxMessage submits the user's partial decryption if it is
+// missing, then combines the first Threshold partials whose proofs check
+// out.
+func decryptMailboxMessage(client pb.CryptoServiceClient, key *mailboxKey, mailbox *pb.Mailbox, msg *pb.MailboxMessage, userID string) ([]byte, error) {
+	a, b, err := elgamal.DecodeCiphertext(msg.EncryptedMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	partials := msg.Partials
+	if !hasPartial(partials, userID) {
+		d, proof, err := threshold.PartialDecrypt(key.group, key.share, a)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.SubmitPartialDecryption(context.Background(), &pb.SubmitPartialDecryptionRequest{
+			MailboxId: mailbox.MailboxId,
+			MessageId: msg.MessageId,
+			MemberId:  userID,
+			Partial:   d.Bytes(),
+			Proof:     proof,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, errors.New(resp.Message)
+		}
+
+		partials = append(partials, &pb.PartialDecryption{MemberId: userID, Partial: d.Bytes(), Proof: proof})
+	}
+
+	// The server checks the proofs too, but members do not have to trust
+	// it to.
+	verified := make(map[int]*big.Int)
+	for _, partial := range partials {
+		j := memberIndex(mailbox, partial.MemberId)
+		if j == 0 || verified[j] != nil {
+			continue
+		}
+
+		d := new(big.Int).SetBytes(partial.Partial)
+		if err := threshold.VerifyPartial(key.group, key.verificationKeys[j], a, d, partial.Proof); err != nil {
+			fmt.Printf("Ignoring partial decryption from %s: %v\n", partial.MemberId, err)
+			continue
+		}
+
+		verified[j] = d
+		if len(verified) == int(mailbox.Threshold) {
+			return threshold.Combine(key.group, b, verified)
+		}
+	}
+
+	return nil, fmt.Errorf("waiting for %d more member(s) to read it", int(mailbox.Threshold)-len(verified))
+}
+
+func hasPartial(partials []*pb.PartialDecryption, memberID string) bool {
+	for _, partial := range partials {
+		if partial.MemberId == memberID {
+			return true
+		}
+	}
+	return false
+}
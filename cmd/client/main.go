@@ -2,14 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"math/big"
 	"time"
 
 	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/crypto/ecc"
 	"github.com/luizgbraga/crypto-go/internal/crypto/elgamal"
+	"github.com/luizgbraga/crypto-go/internal/crypto/hashsig"
+	"github.com/luizgbraga/crypto-go/internal/crypto/hybrid"
+	"github.com/luizgbraga/crypto-go/internal/crypto/lattice"
+	"github.com/luizgbraga/crypto-go/internal/crypto/paillier"
+	"github.com/luizgbraga/crypto-go/internal/crypto/quadratic"
 	"github.com/luizgbraga/crypto-go/internal/crypto/rsa"
+	"github.com/luizgbraga/crypto-go/internal/crypto/weierstrass"
 	"github.com/luizgbraga/crypto-go/internal/keystore"
 	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
 	reader "github.com/luizgbraga/crypto-go/utils"
@@ -31,6 +38,54 @@ func main() {
 
 	rsaProvider := rsa.NewRSAProvider(keyStore, userID)
 	elgamalProvider := elgamal.NewElGamalProvider(keyStore, userID)
+	ecElGamalProvider := weierstrass.NewECElGamalProvider(keyStore, userID)
+	regevProvider := lattice.NewRegevProvider(keyStore, userID)
+
+	registry := crypto.NewRegistry()
+	registry.Register(crypto.RSA, rsaProvider)
+	registry.Register(crypto.ElGamal, elgamalProvider)
+	registry.Register(crypto.Rabin, quadratic.NewRabinProvider(keyStore, userID))
+	registry.Register(crypto.GoldwasserMicali, quadratic.NewGMProvider(keyStore, userID))
+	for _, algorithm := range ecc.Algorithms {
+		eccProvider, err := ecc.NewECIESProvider(keyStore, userID, algorithm)
+		if err != nil {
+			log.Fatalf("Failed to create %s provider: %v", algorithm, err)
+		}
+		registry.Register(algorithm, eccProvider)
+	}
+	registry.Register(crypto.ECElGamal, ecElGamalProvider)
+	for _, algorithm := range lattice.Algorithms {
+		latticeProvider, err := lattice.NewMLKEMProvider(keyStore, userID, algorithm)
+		if err != nil {
+			log.Fatalf("Failed to create %s provider: %v", algorithm, err)
+		}
+		registry.Register(algorithm, latticeProvider)
+	}
+	registry.Register(crypto.RegevLWE, regevProvider)
+	registry.Register(crypto.XWing, hybrid.NewXWingProvider(keyStore, userID))
+	paillierProvider := paillier.NewPaillierProvider(keyStore, userID)
+	registry.Register(crypto.Paillier, paillierProvider)
+
+	// Outgoing messages are signed with the first of these the user has a
+	// key for.
+	signers := crypto.NewSignerRegistry()
+	signers.Register(crypto.Ed25519, ecc.NewEd25519Provider(keyStore, userID))
+	signers.Register(crypto.ECDSAP256, ecc.NewECDSAProvider(keyStore, userID))
+	signers.Register(crypto.RSAPSS, rsa.NewPSSSigner(rsaProvider))
+	for _, scheme := range elgamal.SignatureAlgorithms {
+		elgamalSigner, err := elgamal.NewElGamalSigner(elgamalProvider, scheme)
+		if err != nil {
+			log.Fatalf("Failed to create %s signer: %v", scheme, err)
+		}
+		signers.Register(scheme, elgamalSigner)
+	}
+	signers.Register(crypto.XMSS, hashsig.NewXMSSProvider(keyStore, userID))
+	signers.Register(crypto.Lamport, hashsig.NewLamportProvider(keyStore, userID))
+	signers.Register(crypto.WOTS, hashsig.NewWOTSProvider(keyStore, userID))
+
+	voters := crypto.NewAlgorithmRegistry[voteProvider]("poll algorithm")
+	voters.Register(crypto.Paillier, paillierProvider)
+	voters.Register(crypto.ExponentialElGamal, elgamal.NewExponentialProvider(elgamalProvider))
 
 	resp, err := client.RegisterUser(context.Background(), &pb.RegisterUserRequest{
 		UserId: userID,
@@ -44,9 +99,9 @@ func main() {
 	}
 	fmt.Println("User registered!")
 
-	go pollForMessages(client, userID, rsaProvider)
+	go pollForMessages(client, userID, registry, signers)
 
-	mainMenu(client, keyStore, rsaProvider, elgamalProvider, userID)
+	mainMenu(client, keyStore, registry, signers, voters, rsaProvider, elgamalProvider, ecElGamalProvider, regevProvider, userID)
 }
 
 func getUser() (string, string) {
@@ -56,7 +111,7 @@ func getUser() (string, string) {
 	return userID, name
 }
 
-func pollForMessages(client pb.CryptoServiceClient, userID string, rsaProvider *rsa.RSAProvider) {
+func pollForMessages(client pb.CryptoServiceClient, userID string, registry *crypto.Registry, signers *crypto.SignerRegistry) {
 	for {
 		resp, err := client.GetMessages(context.Background(), &pb.GetMessagesRequest{
 			UserId: userID,
@@ -64,81 +119,174 @@ func pollForMessages(client pb.CryptoServiceClient, userID string, rsaProvider *
 		if err == nil && len(resp.Messages) > 0 {
 			fmt.Printf("\nYou have %d new message(s)!\n", len(resp.Messages))
 			for _, msg := range resp.Messages {
-				handleIncomingMessage(msg, rsaProvider)
+				handleIncomingMessage(client, msg, registry, signers)
 			}
 		}
 		time.Sleep(5 * time.Second)
 	}
 }
 
-func handleIncomingMessage(msg *pb.Message, rsaProvider *rsa.RSAProvider) {
-	fmt.Printf("\nNew message from %s:\n", msg.SenderId)
+func handleIncomingMessage(client pb.CryptoServiceClient, msg *pb.Message, registry *crypto.Registry, signers *crypto.SignerRegistry) {
+	fmt.Printf("\nNew %s message from %s:\n", msg.Algorithm, msg.SenderId)
 
-	decrypted, err := rsaProvider.Decrypt(msg.EncryptedMessage)
+	provider, err := registry.Get(crypto.Algorithm(msg.Algorithm))
+	if err != nil {
+		fmt.Printf("Failed to decrypt message: %v\n", err)
+		return
+	}
+
+	decrypted, err := provider.Decrypt(msg.EncryptedMessage)
 	if err != nil {
 		fmt.Printf("Failed to decrypt message: %v\n", err)
 		return
 	}
 
 	fmt.Printf("Message: %s\n", decrypted)
+	fmt.Printf("Signature: %s\n", verifyMessage(client, msg, signers))
 }
 
-func listUsers(client pb.CryptoServiceClient) {
-	users, err := client.GetUsers(context.Background(), &pb.EmptyRequest{})
+// fetchPublicKey downloads the user's public key for the algorithm from the
+// server and hands it to the provider to check and store.
+func fetchPublicKey(client pb.CryptoServiceClient, provider crypto.KeyHolder, userID string, algorithm crypto.Algorithm) error {
+	resp, err := client.GetPublicKey(context.Background(), &pb.GetPublicKeyRequest{
+		UserId:    userID,
+		Algorithm: string(algorithm),
+	})
 	if err != nil {
-		fmt.Printf("Error listing users: %v\n", err)
-		return
+		return err
 	}
-
-	fmt.Println("\nUsers:")
-	for _, user := range users.Users {
-		status := "offline"
-		if user.Online {
-			status = "online"
-		}
-		fmt.Printf("- %s (%s): %s\n", user.UserId, user.Name, status)
+	if !resp.Success {
+		return errors.New(resp.Message)
 	}
+
+	return provider.ParsePublicKey(userID, resp.KeyData)
 }
 
-func sendRSAEncryptedMessage(client pb.CryptoServiceClient, rsaProvider *rsa.RSAProvider, userID, recipientID, message string) {
-	encrypted, err := rsaProvider.Encrypt([]byte(message), recipientID)
+// registerPublicKey publishes the user's public key for the algorithm,
+// with a proof of possession if the provider makes one.
+func registerPublicKey(client pb.CryptoServiceClient, provider crypto.KeyHolder, userID string, algorithm crypto.Algorithm) error {
+	publicKeyBytes, err := provider.PublicKey()
 	if err != nil {
-		fmt.Printf("Error encrypting message: %v\n", err)
-		return
+		return fmt.Errorf("getting public key: %v", err)
 	}
 
-	resp, err := client.SendMessage(context.Background(), &pb.SendMessageRequest{
-		SenderId:         userID,
-		RecipientId:      recipientID,
-		EncryptedMessage: encrypted,
-		Algorithm:        string(crypto.RSA),
-	})
+	var proof []byte
+	switch prover := provider.(type) {
+	case crypto.ChallengeResponder:
+		proof, err = answerKeyChallenge(client, prover, userID, algorithm)
+	case crypto.PossessionProver:
+		proof, err = prover.ProvePossession(userID)
+	}
+	if err != nil {
+		return fmt.Errorf("proving key possession: %v", err)
+	}
 
+	resp, err := client.RegisterPublicKey(context.Background(), &pb.RegisterPublicKeyRequest{
+		UserId:    userID,
+		Algorithm: string(algorithm),
+		KeyData:   publicKeyBytes,
+		Proof:     proof,
+	})
 	if err != nil {
-		fmt.Printf("Error sending message: %v\n", err)
-		return
+		return err
 	}
+	if !resp.Success {
+		return errors.New(resp.Message)
+	}
+
+	return nil
+}
 
+// answerKeyChallenge asks the server for a registration challenge and
+// answers it with the user's private key.
+func answerKeyChallenge(client pb.CryptoServiceClient, prover crypto.ChallengeResponder, userID string, algorithm crypto.Algorithm) ([]byte, error) {
+	resp, err := client.GetKeyChallenge(context.Background(), &pb.GetKeyChallengeRequest{
+		UserId:    userID,
+		Algorithm: string(algorithm),
+	})
+	if err != nil {
+		return nil, err
+	}
 	if !resp.Success {
-		fmt.Printf("Failed to send message: %s\n", resp.Message)
-		return
+		return nil, errors.New(resp.Message)
 	}
 
-	fmt.Println("Message sent successfully!")
+	return prover.AnswerChallenge(userID, resp.Nonce)
 }
 
-func sendElGamalEncryptedMessage(client pb.CryptoServiceClient, elgamalProvider *elgamal.ElGamalProvider, userID, recipientID, message string, k big.Int) {
-	encrypted, err := elgamalProvider.Encrypt([]byte(message), recipientID, k)
+// signMessage signs the encrypted message with the first signer the user has
+// a key for. Users without any signing key send their messages unsigned.
+func signMessage(signers *crypto.SignerRegistry, encrypted []byte) ([]byte, crypto.Algorithm) {
+	for _, algorithm := range signers.Algorithms() {
+		signer, err := signers.Get(algorithm)
+		if err != nil {
+			continue
+		}
+
+		signature, err := signer.Sign(encrypted)
+		if err == nil {
+			return signature, algorithm
+		}
+	}
+
+	fmt.Println("Sending message unsigned: no signing key available")
+	return nil, ""
+}
+
+// verifyMessage checks the message signature against the sender's key for
+// the message's signature algorithm as currently registered on the server.
+func verifyMessage(client pb.CryptoServiceClient, msg *pb.Message, signers *crypto.SignerRegistry) string {
+	if len(msg.Signature) == 0 {
+		return "unverified (unsigned)"
+	}
+
+	algorithm := crypto.Algorithm(msg.SignatureAlgorithm)
+	signer, err := signers.Get(algorithm)
 	if err != nil {
-		fmt.Printf("Error encrypting message: %v\n", err)
+		return "unverified (" + err.Error() + ")"
+	}
+
+	err = fetchPublicKey(client, signer, msg.SenderId, crypto.KeyAlgorithm(algorithm))
+	if err != nil {
+		return fmt.Sprintf("unverified (sender has no %s key)", algorithm)
+	}
+
+	err = signer.Verify(msg.EncryptedMessage, msg.Signature, msg.SenderId)
+	if err != nil {
+		return "unverified (" + err.Error() + ")"
+	}
+
+	return fmt.Sprintf("verified (%s)", algorithm)
+}
+
+func listUsers(client pb.CryptoServiceClient) {
+	users, err := client.GetUsers(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		fmt.Printf("Error listing users: %v\n", err)
 		return
 	}
 
+	fmt.Println("\nUsers:")
+	for _, user := range users.Users {
+		status := "offline"
+		if user.Online {
+			status = "online"
+		}
+		fmt.Printf("- %s (%s): %s\n", user.UserId, user.Name, status)
+	}
+}
+
+// sendMessage signs the encrypted message and delivers it to the recipient.
+func sendMessage(client pb.CryptoServiceClient, signers *crypto.SignerRegistry, userID, recipientID string, algorithm crypto.Algorithm, encrypted []byte) {
+	signature, signatureAlgorithm := signMessage(signers, encrypted)
+
 	resp, err := client.SendMessage(context.Background(), &pb.SendMessageRequest{
-		SenderId:         userID,
-		RecipientId:      recipientID,
-		EncryptedMessage: encrypted,
-		Algorithm:        string(crypto.ElGamal),
+		SenderId:           userID,
+		RecipientId:        recipientID,
+		EncryptedMessage:   encrypted,
+		Algorithm:          string(algorithm),
+		Signature:          signature,
+		SignatureAlgorithm: string(signatureAlgorithm),
 	})
 
 	if err != nil {
@@ -1,13 +1,18 @@
 package main
 
 import (
-	"context"
 	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/crypto/ecc"
 	"github.com/luizgbraga/crypto-go/internal/crypto/elgamal"
+	"github.com/luizgbraga/crypto-go/internal/crypto/lattice"
+	"github.com/luizgbraga/crypto-go/internal/crypto/quadratic"
 	"github.com/luizgbraga/crypto-go/internal/crypto/rsa"
+	"github.com/luizgbraga/crypto-go/internal/crypto/weierstrass"
 	"github.com/luizgbraga/crypto-go/internal/keystore"
 	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
 	utils "github.com/luizgbraga/crypto-go/utils"
@@ -17,14 +22,21 @@ const (
 	CmdListUsers   = "1"
 	CmdManageKeys  = "2"
 	CmdSendMessage = "3"
-	CmdExit        = "4"
+	CmdPolls       = "4"
+	CmdMailboxes   = "5"
+	CmdExit        = "6"
 )
 
 func mainMenu(
 	client pb.CryptoServiceClient,
 	keyStore keystore.KeyStore,
+	registry *crypto.Registry,
+	signers *crypto.SignerRegistry,
+	voters *crypto.AlgorithmRegistry[voteProvider],
 	rsaProvider *rsa.RSAProvider,
 	elgamalProvider *elgamal.ElGamalProvider,
+	ecElGamalProvider *weierstrass.ECElGamalProvider,
+	regevProvider *lattice.RegevProvider,
 	userID string,
 ) {
 	for {
@@ -32,6 +44,8 @@ func mainMenu(
 		fmt.Printf("%s. List users\n", CmdListUsers)
 		fmt.Printf("%s. Manage keys\n", CmdManageKeys)
 		fmt.Printf("%s. Send message\n", CmdSendMessage)
+		fmt.Printf("%s. Polls\n", CmdPolls)
+		fmt.Printf("%s. Shared mailboxes\n", CmdMailboxes)
 		fmt.Printf("%s. Exit\n", CmdExit)
 
 		cmd := utils.Read("Enter command: ")
@@ -40,9 +54,13 @@ func mainMenu(
 		case CmdListUsers:
 			listUsers(client)
 		case CmdManageKeys:
-			manageKeysMenu(client, keyStore, rsaProvider, elgamalProvider, userID)
+			manageKeysMenu(client, keyStore, registry, signers, rsaProvider, elgamalProvider, ecElGamalProvider, regevProvider, userID)
 		case CmdSendMessage:
-			sendMessageMenu(client, rsaProvider, elgamalProvider, userID)
+			sendMessageMenu(client, registry, signers, rsaProvider, elgamalProvider, userID)
+		case CmdPolls:
+			pollsMenu(client, voters, userID)
+		case CmdMailboxes:
+			mailboxesMenu(client, registry, userID)
 		case CmdExit:
 			fmt.Println("Exiting...")
 			return
@@ -53,24 +71,44 @@ func mainMenu(
 }
 
 const (
-	DisplayKeyStore   = "1"
-	CreateRSAKey      = "2"
-	CreateElGamalKey  = "3"
-	CmdManageKeysBack = "4"
+	DisplayKeyStore    = "1"
+	GenerateKey        = "2"
+	CreateRSAKey       = "3"
+	CreateElGamalKey   = "4"
+	CreateECCKey       = "5"
+	CreateECElGamalKey = "6"
+	CreateLWEKey       = "7"
+	CreateSigningKey   = "8"
+	CreateQuadraticKey = "9"
+	ExportKeyShares    = "10"
+	RecoverKey         = "11"
+	CmdManageKeysBack  = "12"
 )
 
 func manageKeysMenu(
 	client pb.CryptoServiceClient,
 	keyStore keystore.KeyStore,
+	registry *crypto.Registry,
+	signers *crypto.SignerRegistry,
 	rsaProvider *rsa.RSAProvider,
 	elgamalProvider *elgamal.ElGamalProvider,
+	ecElGamalProvider *weierstrass.ECElGamalProvider,
+	regevProvider *lattice.RegevProvider,
 	userID string,
 ) {
 	for {
 		fmt.Println("\nKey Management Commands:")
 		fmt.Printf("%s. Display key store\n", DisplayKeyStore)
+		fmt.Printf("%s. Generate key with default parameters\n", GenerateKey)
 		fmt.Printf("%s. Create RSA key\n", CreateRSAKey)
 		fmt.Printf("%s. Create ElGamal key\n", CreateElGamalKey)
+		fmt.Printf("%s. Create ECC key\n", CreateECCKey)
+		fmt.Printf("%s. Create EC-ElGamal key\n", CreateECElGamalKey)
+		fmt.Printf("%s. Create LWE (Regev) key or run noise demo (teaching)\n", CreateLWEKey)
+		fmt.Printf("%s. Create signing key\n", CreateSigningKey)
+		fmt.Printf("%s. Create Rabin or Goldwasser-Micali key\n", CreateQuadraticKey)
+		fmt.Printf("%s. Back up a private key as Shamir shares\n", ExportKeyShares)
+		fmt.Printf("%s. Recover a private key from shares\n", RecoverKey)
 		fmt.Printf("%s. Back\n", CmdManageKeysBack)
 
 		cmd := utils.Read("Enter command: ")
@@ -79,115 +117,253 @@ func manageKeysMenu(
 		case DisplayKeyStore:
 			keyStore.Display()
 
-		case CreateRSAKey:
-			fmt.Println("Create RSA key")
-
-			primeP, err := utils.ReadPrime("Enter prime P: ")
+		case GenerateKey:
+			algorithm, provider, err := chooseAlgorithm(registry)
 			if err != nil {
-				fmt.Println("Error: ", err)
+				fmt.Printf("Error: %v\n", err)
 				continue
 			}
 
-			primeQ, err := utils.ReadPrime("Enter prime Q: ")
+			fmt.Printf("Generating %s key...\n", algorithm)
+			err = provider.GenerateKey()
 			if err != nil {
-				fmt.Println("Error: ", err)
+				fmt.Printf("Error creating %s key: %v\n", algorithm, err)
 				continue
 			}
 
-			dOptions, err := rsaProvider.GetPossibleDValues(primeP, primeQ, 10)
+			err = registerPublicKey(client, provider, userID, algorithm)
 			if err != nil {
-				fmt.Printf("Error getting D values: %v\n", err)
+				fmt.Printf("Failed to register public key: %v\n", err)
 				continue
 			}
 
-			fmt.Println("Suggested D values:")
-			fmt.Println(strings.Join(dOptions, ", "))
+			fmt.Printf("%s key created successfully!\n", algorithm)
+		case CreateRSAKey:
+			fmt.Println("Create RSA key")
+			fmt.Printf("%s. Generate random key\n", RSAKeyModeGenerate)
+			fmt.Printf("%s. Enter primes manually (teaching)\n", RSAKeyModeManual)
+
+			var err error
+			switch utils.Read("Enter mode: ") {
+			case RSAKeyModeGenerate:
+				err = generateRSAKey(rsaProvider)
+			case RSAKeyModeManual:
+				err = enterRSAKey(rsaProvider)
+			default:
+				fmt.Println("Unknown mode")
+				continue
+			}
+			if err != nil {
+				fmt.Printf("Error creating RSA key: %v\n", err)
+				continue
+			}
 
-			selectedD, err := utils.ReadBigInt("\nEnter D: ")
+			err = registerPublicKey(client, rsaProvider, userID, crypto.RSA)
 			if err != nil {
-				fmt.Printf("Error: %v\n", err)
+				fmt.Printf("Failed to register public key: %v\n", err)
 				continue
 			}
 
-			err = rsaProvider.StoreKeyPair(primeP, primeQ, selectedD)
+			fmt.Println("RSA key created successfully!")
+		case CreateElGamalKey:
+			fmt.Println("Create ElGamal key")
+			fmt.Printf("%s. Generate safe prime and generator\n", ElGamalKeyModeGenerate)
+			fmt.Printf("%s. Use a built-in group (RFC 3526 / RFC 7919)\n", ElGamalKeyModeGroup)
+			fmt.Printf("%s. Enter P, G and X manually (teaching)\n", ElGamalKeyModeManual)
+
+			var err error
+			switch utils.Read("Enter mode: ") {
+			case ElGamalKeyModeGenerate:
+				err = generateElGamalKey(elgamalProvider)
+			case ElGamalKeyModeGroup:
+				err = useElGamalGroup(elgamalProvider)
+			case ElGamalKeyModeManual:
+				err = enterElGamalKey(elgamalProvider)
+			default:
+				fmt.Println("Unknown mode")
+				continue
+			}
 			if err != nil {
-				fmt.Printf("Error creating key from primes: %v\n", err)
+				fmt.Printf("Error creating ElGamal key: %v\n", err)
 				continue
 			}
 
-			publicKeyBytes, err := rsaProvider.GetPublicKey()
+			err = registerPublicKey(client, elgamalProvider, userID, crypto.ElGamal)
 			if err != nil {
-				fmt.Printf("Error getting public key: %v\n", err)
+				fmt.Printf("Failed to register public key: %v\n", err)
 				continue
 			}
 
-			resp, err := client.RegisterPublicKey(context.Background(), &pb.RegisterPublicKeyRequest{
-				UserId:    userID,
-				Algorithm: string(crypto.RSA),
-				KeyData:   publicKeyBytes,
-			})
+			fmt.Println("ElGamal key created successfully!")
+		case CreateECCKey:
+			algorithm, provider, err := chooseCurve(registry)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
 
+			fmt.Println("Create ECC key")
+			fmt.Printf("%s. Generate random key\n", ECCKeyModeGenerate)
+			fmt.Printf("%s. Import base64 private key\n", ECCKeyModeImport)
+
+			switch utils.Read("Enter mode: ") {
+			case ECCKeyModeGenerate:
+				err = provider.GenerateKey()
+			case ECCKeyModeImport:
+				err = provider.StorePrivateKey(utils.Read("Enter private key: "))
+			default:
+				fmt.Println("Unknown mode")
+				continue
+			}
 			if err != nil {
-				fmt.Printf("Error registering public key: %v\n", err)
+				fmt.Printf("Error creating %s key: %v\n", algorithm, err)
 				continue
 			}
 
-			if !resp.Success {
-				fmt.Printf("Failed to register public key: %s\n", resp.Message)
+			err = registerPublicKey(client, provider, userID, algorithm)
+			if err != nil {
+				fmt.Printf("Failed to register public key: %v\n", err)
 				continue
 			}
 
-			fmt.Println("RSA key created successfully!")
-		case CreateElGamalKey:
-			primeP, err := utils.ReadPrime("Enter prime P: ")
+			fmt.Printf("%s key created successfully!\n", algorithm)
+		case CreateECElGamalKey:
+			fmt.Println("Create EC-ElGamal key")
+			fmt.Printf("%s. Generate random key over P-256\n", ECElGamalKeyModeGenerate)
+			fmt.Printf("%s. Enter curve, base point and D manually (teaching)\n", ECElGamalKeyModeManual)
+
+			var err error
+			switch utils.Read("Enter mode: ") {
+			case ECElGamalKeyModeGenerate:
+				err = ecElGamalProvider.GenerateKey()
+			case ECElGamalKeyModeManual:
+				err = enterECElGamalKey(ecElGamalProvider)
+			default:
+				fmt.Println("Unknown mode")
+				continue
+			}
 			if err != nil {
-				fmt.Println("Error: ", err)
+				fmt.Printf("Error creating EC-ElGamal key: %v\n", err)
 				continue
 			}
 
-			generatorG, err := utils.ReadBigInt("Enter generator G: ")
+			err = registerPublicKey(client, ecElGamalProvider, userID, crypto.ECElGamal)
 			if err != nil {
-				fmt.Println("Error: ", err)
+				fmt.Printf("Failed to register public key: %v\n", err)
 				continue
 			}
 
-			secretX, err := utils.ReadBigInt("Enter secret X: ")
+			fmt.Println("EC-ElGamal key created successfully!")
+		case CreateLWEKey:
+			fmt.Println("LWE (Regev) key")
+			fmt.Printf("%s. Create key\n", LWEModeCreate)
+			fmt.Printf("%s. Show parameters of my key\n", LWEModeInspect)
+			fmt.Printf("%s. Noise demo: count decryption failures\n", LWEModeDemo)
+
+			switch utils.Read("Enter mode: ") {
+			case LWEModeCreate:
+				params, err := readLWEParams()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+
+				fmt.Println("Generating LWE key...")
+				err = regevProvider.GenerateKeyWithParams(params)
+				if err != nil {
+					fmt.Printf("Error creating LWE key: %v\n", err)
+					continue
+				}
+
+				err = registerPublicKey(client, regevProvider, userID, crypto.RegevLWE)
+				if err != nil {
+					fmt.Printf("Failed to register public key: %v\n", err)
+					continue
+				}
+
+				fmt.Println("LWE key created successfully!")
+			case LWEModeInspect:
+				params, err := regevProvider.Params()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				describeLWEParams(params)
+			case LWEModeDemo:
+				params, err := readLWEParams()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+
+				failures, err := lattice.NoiseDemo(params, lweDemoTrials)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+
+				fmt.Printf("%d of %d random bits decrypted wrongly (%.2f%%).\n",
+					failures, lweDemoTrials, 100*float64(failures)/lweDemoTrials)
+			default:
+				fmt.Println("Unknown mode")
+			}
+		case CreateSigningKey:
+			algorithm, signer, err := chooseAlgorithm(signers)
 			if err != nil {
-				fmt.Println("Error: ", err)
+				fmt.Printf("Error: %v\n", err)
 				continue
 			}
-			if secretX.Cmp(&primeP) >= 0 {
-				fmt.Println("Error: X must be less than P")
+
+			fmt.Printf("Generating %s key...\n", algorithm)
+			err = signer.GenerateKey()
+			if err != nil {
+				fmt.Printf("Error creating %s key: %v\n", algorithm, err)
 				continue
 			}
 
-			err = elgamalProvider.StoreKeyPair(primeP, generatorG, secretX)
+			err = registerPublicKey(client, signer, userID, crypto.KeyAlgorithm(algorithm))
 			if err != nil {
-				fmt.Printf("Error creating key from primes: %v\n", err)
+				fmt.Printf("Failed to register public key: %v\n", err)
 				continue
 			}
 
-			publicKeyBytes, err := elgamalProvider.GetPublicKey()
+			fmt.Printf("%s key created successfully!\n", algorithm)
+		case CreateQuadraticKey:
+			algorithm, provider, err := chooseQuadratic(registry)
 			if err != nil {
-				fmt.Printf("Error getting public key: %v\n", err)
+				fmt.Printf("Error: %v\n", err)
 				continue
 			}
 
-			resp, err := client.RegisterPublicKey(context.Background(), &pb.RegisterPublicKeyRequest{
-				UserId:    userID,
-				Algorithm: string(crypto.ElGamal),
-				KeyData:   publicKeyBytes,
-			})
+			fmt.Printf("Create %s key\n", algorithm)
+			fmt.Printf("%s. Generate random key\n", QuadraticKeyModeGenerate)
+			fmt.Printf("%s. Enter primes manually (teaching)\n", QuadraticKeyModeManual)
+
+			switch utils.Read("Enter mode: ") {
+			case QuadraticKeyModeGenerate:
+				err = generateQuadraticKey(provider)
+			case QuadraticKeyModeManual:
+				err = enterQuadraticKey(provider, algorithm)
+			default:
+				fmt.Println("Unknown mode")
+				continue
+			}
 			if err != nil {
-				fmt.Printf("Error registering public key: %v\n", err)
+				fmt.Printf("Error creating %s key: %v\n", algorithm, err)
 				continue
 			}
-			if !resp.Success {
-				fmt.Printf("Failed to register public key: %s\n", resp.Message)
+
+			err = registerPublicKey(client, provider, userID, algorithm)
+			if err != nil {
+				fmt.Printf("Failed to register public key: %v\n", err)
 				continue
 			}
 
-			fmt.Println("ElGamal key created successfully!")
+			fmt.Printf("%s key created successfully!\n", algorithm)
+		case ExportKeyShares:
+			exportKeyShares(keyStore, registry, signers)
+		case RecoverKey:
+			recoverKey(client, keyStore, registry, signers, userID)
 		case CmdManageKeysBack:
 			fmt.Println("Returning to main menu")
 			return
@@ -197,84 +373,535 @@ func manageKeysMenu(
 	}
 }
 
+// chooseAlgorithm lists the registered algorithms and returns the one the
+// user picks, by number or by name.
+func chooseAlgorithm[T any](registry *crypto.AlgorithmRegistry[T]) (crypto.Algorithm, T, error) {
+	algorithms := registry.Algorithms()
+
+	fmt.Println("Algorithms:")
+	for i, algorithm := range algorithms {
+		fmt.Printf("%d. %s\n", i+1, algorithm)
+	}
+
+	choice := utils.Read("Enter algorithm: ")
+	algorithm := crypto.Algorithm(choice)
+	if index, err := strconv.Atoi(choice); err == nil && index >= 1 && index <= len(algorithms) {
+		algorithm = algorithms[index-1]
+	}
+
+	provider, err := registry.Get(algorithm)
+	if err != nil {
+		return "", provider, err
+	}
+
+	return algorithm, provider, nil
+}
+
+const (
+	ECCKeyModeGenerate = "1"
+	ECCKeyModeImport   = "2"
+)
+
+// chooseCurve lets the user pick one of the ECIES curves and returns its
+// registered provider.
+func chooseCurve(registry *crypto.Registry) (crypto.Algorithm, *ecc.ECIESProvider, error) {
+	algorithm, provider, err := chooseVariant(registry, ecc.Algorithms, "curve")
+	if err != nil {
+		return "", nil, err
+	}
+
+	eccProvider, ok := provider.(*ecc.ECIESProvider)
+	if !ok {
+		return "", nil, fmt.Errorf("%s provider is not an ECIES provider", algorithm)
+	}
+
+	return algorithm, eccProvider, nil
+}
+
+// chooseVariant lists the given algorithms by number and returns the one the
+// user picks together with its registered provider.
+func chooseVariant(registry *crypto.Registry, algorithms []crypto.Algorithm, kind string) (crypto.Algorithm, crypto.Provider, error) {
+	fmt.Printf("%ss:\n", strings.ToUpper(kind[:1])+kind[1:])
+	for i, algorithm := range algorithms {
+		fmt.Printf("%d. %s\n", i+1, algorithm)
+	}
+
+	choice := utils.Read(fmt.Sprintf("Enter %s: ", kind))
+	index, err := strconv.Atoi(choice)
+	if err != nil || index < 1 || index > len(algorithms) {
+		return "", nil, fmt.Errorf("unknown %s: %s", kind, choice)
+	}
+	algorithm := algorithms[index-1]
+
+	provider, err := registry.Get(algorithm)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return algorithm, provider, nil
+}
+
+const (
+	LWEModeCreate  = "1"
+	LWEModeInspect = "2"
+	LWEModeDemo    = "3"
+)
+
+const lweDemoTrials = 1000
+
+// readLWEParams lets the user pick a preset or enter the LWE parameters, and
+// shows how likely decryption is to fail with them.
+func readLWEParams() (lattice.Params, error) {
+	fmt.Println("Parameter sets:")
+	for i, preset := range lattice.Presets {
+		fmt.Printf("%d. %s: %s (%s)\n", i+1, preset.Name, preset.Description, preset.Params)
+	}
+	fmt.Printf("%d. Enter parameters manually\n", len(lattice.Presets)+1)
+
+	choice := utils.Read("Enter parameter set: ")
+	index, err := strconv.Atoi(choice)
+	if err != nil || index < 1 || index > len(lattice.Presets)+1 {
+		return lattice.Params{}, fmt.Errorf("unknown parameter set: %s", choice)
+	}
+
+	var params lattice.Params
+	if index <= len(lattice.Presets) {
+		params = lattice.Presets[index-1].Params
+	} else {
+		params, err = enterLWEParams()
+		if err != nil {
+			return lattice.Params{}, err
+		}
+	}
+
+	if err := params.Validate(); err != nil {
+		return lattice.Params{}, err
+	}
+
+	describeLWEParams(params)
+	return params, nil
+}
+
+func enterLWEParams() (lattice.Params, error) {
+	var params lattice.Params
+	var err error
+
+	if params.N, err = strconv.Atoi(utils.Read("Enter dimension n: ")); err != nil {
+		return params, fmt.Errorf("invalid n: %v", err)
+	}
+	if params.M, err = strconv.Atoi(utils.Read("Enter number of samples m: ")); err != nil {
+		return params, fmt.Errorf("invalid m: %v", err)
+	}
+	if params.Q, err = strconv.ParseInt(utils.Read("Enter modulus q: "), 10, 64); err != nil {
+		return params, fmt.Errorf("invalid q: %v", err)
+	}
+
+	params.Noise = lattice.Noise(utils.Read(fmt.Sprintf("Enter noise distribution (%s/%s): ", lattice.NoiseUniform, lattice.NoiseGaussian)))
+	if params.Width, err = strconv.ParseFloat(utils.Read("Enter noise width (bound or standard deviation): "), 64); err != nil {
+		return params, fmt.Errorf("invalid width: %v", err)
+	}
+
+	return params, nil
+}
+
+func describeLWEParams(params lattice.Params) {
+	fmt.Printf("Parameters: %s\n", params)
+	fmt.Printf("Decryption rounds correctly while the error stays below q/4 = %d.\n", params.Q/4)
+	fmt.Printf("Estimated error standard deviation: %.1f\n", params.NoiseStdDev())
+	fmt.Printf("Estimated chance of a wrong bit: %.4g\n", params.FailureProbability())
+}
+
+const (
+	ECElGamalKeyModeGenerate = "1"
+	ECElGamalKeyModeManual   = "2"
+)
+
+// enterECElGamalKey walks through a curve y² = x³ + ax + b mod P by hand.
+// On small curves the point count and the order of G are computed and shown.
+func enterECElGamalKey(ecElGamalProvider *weierstrass.ECElGamalProvider) error {
+	primeP, err := utils.ReadPrime("Enter prime P: ")
+	if err != nil {
+		return err
+	}
+
+	coefficientA, err := utils.ReadBigInt("Enter coefficient A: ")
+	if err != nil {
+		return err
+	}
+
+	coefficientB, err := utils.ReadBigInt("Enter coefficient B: ")
+	if err != nil {
+		return err
+	}
+
+	curve, err := weierstrass.NewCurve(&primeP, &coefficientA, &coefficientB)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Curve: %s\n", curve)
+
+	small := curve.P.BitLen() <= weierstrass.MaxCountBits
+	if small {
+		count, err := curve.CountPoints()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Number of points: %s\n", count)
+	}
+
+	baseX, err := utils.ReadBigInt("Enter base point Gx: ")
+	if err != nil {
+		return err
+	}
+
+	baseY, err := utils.ReadBigInt("Enter base point Gy: ")
+	if err != nil {
+		return err
+	}
+
+	g := weierstrass.NewPoint(&baseX, &baseY)
+	if !curve.IsOnCurve(g) {
+		return fmt.Errorf("G = %s is not on the curve", g)
+	}
+
+	var order *big.Int
+	if small {
+		order, err = curve.PointOrder(g)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Order of G: %s\n", order)
+	} else {
+		n, err := utils.ReadBigInt("Enter order N of G: ")
+		if err != nil {
+			return err
+		}
+		order = &n
+	}
+
+	var secret *big.Int
+	if input := utils.Read("Enter secret D (empty for random): "); input != "" {
+		d, ok := new(big.Int).SetString(input, 10)
+		if !ok {
+			return fmt.Errorf("invalid number format")
+		}
+		secret = d
+	}
+
+	return ecElGamalProvider.StoreKeyPair(curve, g, order, secret)
+}
+
+const (
+	QuadraticKeyModeGenerate = "1"
+	QuadraticKeyModeManual   = "2"
+)
+
+// quadraticProvider is implemented by the Rabin and Goldwasser-Micali
+// providers, whose keys are both built from two primes.
+type quadraticProvider interface {
+	crypto.Provider
+	GenerateKeyPair(bits int) error
+	StoreKeyPair(primeP, primeQ big.Int) error
+}
+
+// chooseQuadratic lets the user pick Rabin or Goldwasser-Micali and returns
+// its registered provider.
+func chooseQuadratic(registry *crypto.Registry) (crypto.Algorithm, quadraticProvider, error) {
+	algorithm, provider, err := chooseVariant(registry, quadratic.Algorithms, "scheme")
+	if err != nil {
+		return "", nil, err
+	}
+
+	schemeProvider, ok := provider.(quadraticProvider)
+	if !ok {
+		return "", nil, fmt.Errorf("%s provider does not take primes", algorithm)
+	}
+
+	return algorithm, schemeProvider, nil
+}
+
+func generateQuadraticKey(provider quadraticProvider) error {
+	input := utils.Read(fmt.Sprintf("Enter key size in bits [%d]: ", quadratic.DefaultKeySize))
+	bits := quadratic.DefaultKeySize
+	if input != "" {
+		var err error
+		bits, err = strconv.Atoi(input)
+		if err != nil {
+			return fmt.Errorf("invalid key size: %s", input)
+		}
+	}
+
+	fmt.Printf("Generating %d-bit key...\n", bits)
+	return provider.GenerateKeyPair(bits)
+}
+
+func enterQuadraticKey(provider quadraticProvider, algorithm crypto.Algorithm) error {
+	if algorithm == crypto.Rabin {
+		fmt.Println("Both primes must be 3 mod 4.")
+	}
+
+	primeP, err := utils.ReadPrime("Enter prime P: ")
+	if err != nil {
+		return err
+	}
+
+	primeQ, err := utils.ReadPrime("Enter prime Q: ")
+	if err != nil {
+		return err
+	}
+
+	return provider.StoreKeyPair(primeP, primeQ)
+}
+
+const (
+	RSAKeyModeGenerate = "1"
+	RSAKeyModeManual   = "2"
+)
+
+func generateRSAKey(rsaProvider *rsa.RSAProvider) error {
+	sizes := make([]string, len(rsa.SupportedKeySizes))
+	for i, size := range rsa.SupportedKeySizes {
+		sizes[i] = strconv.Itoa(size)
+	}
+
+	input := utils.Read(fmt.Sprintf("Enter key size in bits (%s) [2048]: ", strings.Join(sizes, "/")))
+	bits := 2048
+	if input != "" {
+		var err error
+		bits, err = strconv.Atoi(input)
+		if err != nil {
+			return fmt.Errorf("invalid key size: %s", input)
+		}
+	}
+
+	fmt.Printf("Generating %d-bit RSA key...\n", bits)
+	return rsaProvider.GenerateKeyPair(bits)
+}
+
+func enterRSAKey(rsaProvider *rsa.RSAProvider) error {
+	primeP, err := utils.ReadPrime("Enter prime P: ")
+	if err != nil {
+		return err
+	}
+
+	primeQ, err := utils.ReadPrime("Enter prime Q: ")
+	if err != nil {
+		return err
+	}
+
+	dOptions, err := rsaProvider.GetPossibleDValues(primeP, primeQ, 10)
+	if err != nil {
+		return fmt.Errorf("getting D values: %v", err)
+	}
+
+	fmt.Println("Suggested D values:")
+	fmt.Println(strings.Join(dOptions, ", "))
+
+	selectedD, err := utils.ReadBigInt("\nEnter D: ")
+	if err != nil {
+		return err
+	}
+
+	return rsaProvider.StoreKeyPair(primeP, primeQ, selectedD)
+}
+
 const (
-	CmdSendRSAEncryptedMessage     = "1"
-	CmdSendElGamalEncryptedMessage = "2"
-	CmdSendMessageBack             = "3"
+	ElGamalKeyModeGenerate = "1"
+	ElGamalKeyModeGroup    = "2"
+	ElGamalKeyModeManual   = "3"
+)
+
+func generateElGamalKey(elgamalProvider *elgamal.ElGamalProvider) error {
+	input := utils.Read("Enter prime size in bits [1024]: ")
+	bits := 1024
+	if input != "" {
+		var err error
+		bits, err = strconv.Atoi(input)
+		if err != nil {
+			return fmt.Errorf("invalid prime size: %s", input)
+		}
+	}
+
+	fmt.Printf("Generating %d-bit safe prime, this may take a while...\n", bits)
+	return elgamalProvider.GenerateKeyPair(bits)
+}
+
+func useElGamalGroup(elgamalProvider *elgamal.ElGamalProvider) error {
+	fmt.Printf("Groups: %s\n", strings.Join(elgamal.GroupNames(), ", "))
+
+	groupName := utils.Read("Enter group [modp2048]: ")
+	if groupName == "" {
+		groupName = "modp2048"
+	}
+
+	return elgamalProvider.GenerateKeyPairForGroup(groupName)
+}
+
+func enterElGamalKey(elgamalProvider *elgamal.ElGamalProvider) error {
+	primeP, err := utils.ReadPrime("Enter prime P: ")
+	if err != nil {
+		return err
+	}
+
+	generatorG, err := utils.ReadBigInt("Enter generator G: ")
+	if err != nil {
+		return err
+	}
+
+	secretX, err := utils.ReadBigInt("Enter secret X: ")
+	if err != nil {
+		return err
+	}
+
+	return elgamalProvider.StoreKeyPair(primeP, generatorG, secretX)
+}
+
+const (
+	RSAPaddingHybrid   = "1"
+	RSAPaddingOAEP     = "2"
+	RSAPaddingTextbook = "3"
+)
+
+const (
+	CmdSendEncryptedMessage        = "1"
+	CmdSendRSAEncryptedMessage     = "2"
+	CmdSendElGamalEncryptedMessage = "3"
+	CmdSendECCEncryptedMessage     = "4"
+	CmdSendMessageBack             = "5"
 )
 
 func sendMessageMenu(
 	client pb.CryptoServiceClient,
+	registry *crypto.Registry,
+	signers *crypto.SignerRegistry,
 	rsaProvider *rsa.RSAProvider,
 	elgamalProvider *elgamal.ElGamalProvider,
 	userID string,
 ) {
 	for {
-		fmt.Println("\nSend MessageCommands:")
-		fmt.Printf("%s. Send RSA Encrypted message\n", CmdSendRSAEncryptedMessage)
-		fmt.Printf("%s. Create ElGamal Encrypted message\n", CmdSendElGamalEncryptedMessage)
+		fmt.Println("\nSend Message Commands:")
+		fmt.Printf("%s. Send encrypted message\n", CmdSendEncryptedMessage)
+		fmt.Printf("%s. Send RSA message with chosen padding\n", CmdSendRSAEncryptedMessage)
+		fmt.Printf("%s. Send ElGamal message (optional manual k)\n", CmdSendElGamalEncryptedMessage)
+		fmt.Printf("%s. Send ECC message\n", CmdSendECCEncryptedMessage)
 		fmt.Printf("%s. Back\n", CmdSendMessageBack)
 
 		cmd := utils.Read("Enter command: ")
 
 		switch cmd {
-		case CmdSendRSAEncryptedMessage:
+		case CmdSendEncryptedMessage:
+			algorithm, provider, err := chooseAlgorithm(registry)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+
 			recipient := utils.Read("Enter recipient ID: ")
+			err = fetchPublicKey(client, provider, recipient, algorithm)
+			if err != nil {
+				fmt.Printf("Cannot send message: Unable to get recipient's public key: %v\n", err)
+				continue
+			}
 
-			_, err := rsaProvider.Encrypt([]byte("TEST"), recipient)
+			message := utils.Read("Enter message: ")
+			encrypted, err := provider.Encrypt([]byte(message), recipient)
 			if err != nil {
-				resp, err := client.GetPublicKey(context.Background(), &pb.GetPublicKeyRequest{
-					UserId:    recipient,
-					Algorithm: string(crypto.RSA),
-				})
+				fmt.Printf("Error encrypting message: %v\n", err)
+				continue
+			}
 
-				if err != nil || !resp.Success {
-					fmt.Printf("Cannot send message: Unable to get recipient's public key\n")
-					continue
-				}
+			sendMessage(client, signers, userID, recipient, algorithm, encrypted)
+		case CmdSendRSAEncryptedMessage:
+			recipient := utils.Read("Enter recipient ID: ")
+			err := fetchPublicKey(client, rsaProvider, recipient, crypto.RSA)
+			if err != nil {
+				fmt.Printf("Cannot send message: Unable to get recipient's public key: %v\n", err)
+				continue
+			}
 
-				err = rsaProvider.StorePublicKey(recipient, resp.KeyData)
-				if err != nil {
-					fmt.Printf("Error storing recipient's public key: %v\n", err)
-					continue
-				}
+			fmt.Printf("%s. Hybrid RSA-KEM + AES-GCM (any length)\n", RSAPaddingHybrid)
+			fmt.Printf("%s. OAEP (SHA-256)\n", RSAPaddingOAEP)
+			fmt.Printf("%s. Textbook (teaching)\n", RSAPaddingTextbook)
+
+			var mode rsa.EncryptionMode
+			switch utils.Read("Enter padding: ") {
+			case RSAPaddingHybrid:
+				mode = rsa.ModeHybrid
+			case RSAPaddingOAEP:
+				mode = rsa.ModeOAEP
+			case RSAPaddingTextbook:
+				mode = rsa.ModeTextbook
+			default:
+				fmt.Println("Unknown padding")
+				continue
 			}
 
 			message := utils.Read("Enter message: ")
-			sendRSAEncryptedMessage(client, rsaProvider, userID, recipient, message)
+			encrypted, err := rsaProvider.EncryptWithMode([]byte(message), recipient, mode)
+			if err != nil {
+				fmt.Printf("Error encrypting message: %v\n", err)
+				continue
+			}
+
+			sendMessage(client, signers, userID, recipient, crypto.RSA, encrypted)
 		case CmdSendElGamalEncryptedMessage:
 			recipient := utils.Read("Enter recipient ID: ")
-
-			_, err := rsaProvider.Encrypt([]byte("TEST"), recipient)
+			err := fetchPublicKey(client, elgamalProvider, recipient, crypto.ElGamal)
 			if err != nil {
-				resp, err := client.GetPublicKey(context.Background(), &pb.GetPublicKeyRequest{
-					UserId:    recipient,
-					Algorithm: string(crypto.RSA),
-				})
-
-				if err != nil || !resp.Success {
-					fmt.Printf("Cannot send message: Unable to get recipient's public key\n")
-					continue
-				}
+				fmt.Printf("Cannot send message: Unable to get recipient's public key: %v\n", err)
+				continue
+			}
 
-				err = rsaProvider.StorePublicKey(recipient, resp.KeyData)
+			var k *big.Int
+			if utils.Read("Choose k manually? Teaching mode, reusing k is insecure [y/N]: ") == "y" {
+				chosenK, err := utils.ReadBigInt("Enter k: ")
 				if err != nil {
-					fmt.Printf("Error storing recipient's public key: %v\n", err)
+					fmt.Printf("Error: %v\n", err)
 					continue
 				}
+				k = &chosenK
+			}
+
+			message := utils.Read("Enter message: ")
+			var encrypted []byte
+			if k != nil {
+				encrypted, err = elgamalProvider.EncryptDeterministic([]byte(message), recipient, *k)
+			} else {
+				encrypted, err = elgamalProvider.Encrypt([]byte(message), recipient)
+			}
+			if err != nil {
+				fmt.Printf("Error encrypting message: %v\n", err)
+				continue
 			}
 
-			k, err := utils.ReadBigInt("Enter k: ")
+			sendMessage(client, signers, userID, recipient, crypto.ElGamal, encrypted)
+		case CmdSendECCEncryptedMessage:
+			algorithm, provider, err := chooseCurve(registry)
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				continue
 			}
 
+			recipient := utils.Read("Enter recipient ID: ")
+			err = fetchPublicKey(client, provider, recipient, algorithm)
+			if err != nil {
+				fmt.Printf("Cannot send message: Unable to get recipient's public key: %v\n", err)
+				continue
+			}
+
 			message := utils.Read("Enter message: ")
-			sendElGamalEncryptedMessage(client, elgamalProvider, userID, recipient, message, k)
+			encrypted, err := provider.Encrypt([]byte(message), recipient)
+			if err != nil {
+				fmt.Printf("Error encrypting message: %v\n", err)
+				continue
+			}
+
+			sendMessage(client, signers, userID, recipient, algorithm, encrypted)
 		case CmdSendMessageBack:
 			fmt.Println("Returning to main menu")
 			return
+		default:
+			fmt.Println("Unknown command")
 		}
 	}
 }
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+	utils "github.com/luizgbraga/crypto-go/utils"
+)
+
+// voteProvider is implemented by the additively homomorphic algorithms a
+// poll can be tallied with.
+type voteProvider interface {
+	crypto.KeyHolder
+	EncryptValue(value *big.Int, recipientID string) ([]byte, error)
+	DecryptValue(ciphertext []byte) (*big.Int, error)
+}
+
+const (
+	CmdCreatePoll = "1"
+	CmdListPolls  = "2"
+	CmdVote       = "3"
+	CmdClosePoll  = "4"
+	CmdTallyPoll  = "5"
+	CmdPollsBack  = "6"
+)
+
+func pollsMenu(client pb.CryptoServiceClient, voters *crypto.AlgorithmRegistry[voteProvider], userID string) {
+	for {
+		fmt.Println("\nPoll Commands:")
+		fmt.Printf("%s. Create poll\n", CmdCreatePoll)
+		fmt.Printf("%s. List polls\n", CmdListPolls)
+		fmt.Printf("%s. Vote\n", CmdVote)
+		fmt.Printf("%s. Close my poll\n", CmdClosePoll)
+		fmt.Printf("%s. Tally my poll\n", CmdTallyPoll)
+		fmt.Printf("%s. Back\n", CmdPollsBack)
+
+		cmd := utils.Read("Enter command: ")
+
+		switch cmd {
+		case CmdCreatePoll:
+			createPoll(client, voters, userID)
+		case CmdListPolls:
+			listPolls(client)
+		case CmdVote:
+			vote(client, voters, userID)
+		case CmdClosePoll:
+			closePoll(client, userID)
+		case CmdTallyPoll:
+			tallyPoll(client, voters, userID)
+		case CmdPollsBack:
+			fmt.Println("Returning to main menu")
+			return
+		default:
+			fmt.Println("Unknown command")
+		}
+	}
+}
+
+// createPoll opens a poll whose votes are encrypted under the user's key,
+// creating and registering a key first if the user has none.
+func createPoll(client pb.CryptoServiceClient, voters *crypto.AlgorithmRegistry[voteProvider], userID string) {
+	algorithm, provider, err := chooseAlgorithm(voters)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if _, err := provider.PublicKey(); err != nil {
+		fmt.Printf("Generating %s key for the poll...\n", crypto.KeyAlgorithm(algorithm))
+		if err := provider.GenerateKey(); err != nil {
+			fmt.Printf("Error creating %s key: %v\n", crypto.KeyAlgorithm(algorithm), err)
+			return
+		}
+	}
+
+	err = registerPublicKey(client, provider, userID, crypto.KeyAlgorithm(algorithm))
+	if err != nil {
+		fmt.Printf("Failed to register public key: %v\n", err)
+		return
+	}
+
+	question := utils.Read("Enter question (answered yes or no): ")
+
+	minVotes, err := readCount("Enter the fewest votes to tally over [3]: ", 3)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	resp, err := client.CreatePoll(context.Background(), &pb.CreatePollRequest{
+		OwnerId:   userID,
+		Question:  question,
+		Algorithm: string(algorithm),
+		MinVotes:  int32(minVotes),
+	})
+	if err != nil {
+		fmt.Printf("Error creating poll: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to create poll: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Printf("Poll %s created!\n", resp.PollId)
+}
+
+func listPolls(client pb.CryptoServiceClient) {
+	polls, err := client.GetPolls(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		fmt.Printf("Error listing polls: %v\n", err)
+		return
+	}
+
+	fmt.Println("\nPolls:")
+	if len(polls.Polls) == 0 {
+		fmt.Println("No polls yet.")
+	}
+	for _, poll := range polls.Polls {
+		status := "open"
+		if poll.Closed {
+			status = "closed"
+		}
+		fmt.Printf("- %s by %s: %s (%s, %d of at least %d votes, %s)\n",
+			poll.PollId, poll.OwnerId, poll.Question, poll.Algorithm, poll.Votes, poll.MinVotes, status)
+	}
+}
+
+// findPoll looks a poll up by ID in the server's list.
+func findPoll(client pb.CryptoServiceClient, pollID string) (*pb.Poll, error) {
+	polls, err := client.GetPolls(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, poll := range polls.Polls {
+		if poll.PollId == pollID {
+			return poll, nil
+		}
+	}
+
+	return nil, fmt.Errorf("poll %s not found", pollID)
+}
+
+// vote encrypts 1 for yes or 0 for no under the key the poll was created
+// with.
+func vote(client pb.CryptoServiceClient, voters *crypto.AlgorithmRegistry[voteProvider], userID string) {
+	poll, err := findPoll(client, utils.Read("Enter poll ID: "))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	algorithm := crypto.Algorithm(poll.Algorithm)
+	provider, err := voters.Get(algorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if poll.Closed {
+		fmt.Println("Poll is closed")
+		return
+	}
+
+	err = provider.ParsePublicKey(poll.OwnerId, poll.PublicKey)
+	if err != nil {
+		fmt.Printf("Cannot vote: Invalid poll key: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%s\n", poll.Question)
+	var value int64
+	switch utils.Read("Your vote [y/n]: ") {
+	case "y":
+		value = 1
+	case "n":
+		value = 0
+	default:
+		fmt.Println("Vote must be y or n")
+		return
+	}
+
+	encrypted, err := provider.EncryptValue(big.NewInt(value), poll.OwnerId)
+	if err != nil {
+		fmt.Printf("Error encrypting vote: %v\n", err)
+		return
+	}
+
+	resp, err := client.SubmitVote(context.Background(), &pb.SubmitVoteRequest{
+		PollId:        poll.PollId,
+		VoterId:       userID,
+		EncryptedVote: encrypted,
+	})
+	if err != nil {
+		fmt.Printf("Error submitting vote: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to submit vote: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Println("Vote submitted!")
+}
+
+func closePoll(client pb.CryptoServiceClient, userID string) {
+	resp, err := client.ClosePoll(context.Background(), &pb.ClosePollRequest{
+		PollId:  utils.Read("Enter poll ID: "),
+		OwnerId: userID,
+	})
+	if err != nil {
+		fmt.Printf("Error closing poll: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to close poll: %s\n", resp.Message)
+		return
+	}
+
+	fmt.Println("Poll closed!")
+}
+
+// tallyPoll asks the server for the encrypted sum of the votes and decrypts
+// it with the owner's private key.
+func tallyPoll(client pb.CryptoServiceClient, voters *crypto.AlgorithmRegistry[voteProvider], userID string) {
+	poll, err := findPoll(client, utils.Read("Enter poll ID: "))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	provider, err := voters.Get(crypto.Algorithm(poll.Algorithm))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	resp, err := client.TallyPoll(context.Background(), &pb.TallyPollRequest{
+		PollId:  poll.PollId,
+		OwnerId: userID,
+	})
+	if err != nil {
+		fmt.Printf("Error tallying poll: %v\n", err)
+		return
+	}
+	if !resp.Success {
+		fmt.Printf("Failed to tally poll: %s\n", resp.Message)
+		return
+	}
+
+	total, err := provider.DecryptValue(resp.EncryptedTotal)
+	if err != nil {
+		fmt.Printf("Error decrypting total: %v\n", err)
+		return
+	}
+
+	yes := total.Int64()
+	fmt.Printf("%s\nYes: %d, No: %d (%d votes)\n", poll.Question, yes, int64(resp.Votes)-yes, resp.Votes)
+}
@@ -7,6 +7,82 @@ const (
 	ElGamal Algorithm = "ElGamal"
 )
 
+// Rabin and Goldwasser–Micali, built on square roots and quadratic
+// residues modulo N = P·Q.
+const (
+	Rabin            Algorithm = "Rabin"
+	GoldwasserMicali Algorithm = "Goldwasser-Micali"
+)
+
+// ECIES algorithms, one per curve.
+const (
+	ECIESP256   Algorithm = "ECIES-P256"
+	ECIESP384   Algorithm = "ECIES-P384"
+	ECIESX25519 Algorithm = "ECIES-X25519"
+)
+
+// ECElGamal is EC-ElGamal over a short Weierstrass curve.
+const ECElGamal Algorithm = "EC-ElGamal"
+
+// ML-KEM parameter sets, used as a KEM with AES-GCM.
+const (
+	MLKEM768  Algorithm = "ML-KEM-768"
+	MLKEM1024 Algorithm = "ML-KEM-1024"
+)
+
+// RegevLWE is the toy learning-with-errors scheme used for teaching.
+const RegevLWE Algorithm = "LWE-Regev"
+
+// XWing is the X-Wing style hybrid of X25519 and ML-KEM-768.
+const XWing Algorithm = "X25519-MLKEM768"
+
+// Additively homomorphic algorithms, used to tally polls.
+const (
+	Paillier           Algorithm = "Paillier"
+	ExponentialElGamal Algorithm = "ElGamal-Exponential"
+)
+
+// Signature algorithms, recorded alongside a message's signature.
+const (
+	RSAPSS      Algorithm = "RSA-PSS"
+	RSAPKCS1v15 Algorithm = "RSA-PKCS1v15"
+	ECDSAP256   Algorithm = "ECDSA-P256"
+	Ed25519     Algorithm = "Ed25519"
+
+	ElGamalSignature Algorithm = "ElGamal-Signature"
+	ElGamalSchnorr   Algorithm = "ElGamal-Schnorr"
+)
+
+// Hash-based signatures over SHA-256. Their keys are stateful: each
+// one-time key may sign only once.
+const (
+	Lamport Algorithm = "Lamport-SHA256"
+	WOTS    Algorithm = "WOTS+-SHA256"
+	XMSS    Algorithm = "XMSS-SHA256"
+)
+
+// KeyAlgorithm returns the algorithm whose public keys another algorithm
+// uses. RSA signatures use the RSA encryption key, and ElGamal signatures and
+// exponential ElGamal use the ElGamal key; every other algorithm has keys of
+// its own.
+func KeyAlgorithm(algorithm Algorithm) Algorithm {
+	switch algorithm {
+	case RSAPSS, RSAPKCS1v15:
+		return RSA
+	case ElGamalSignature, ElGamalSchnorr, ExponentialElGamal:
+		return ElGamal
+	default:
+		return algorithm
+	}
+}
+
+// Stateful reports whether an algorithm's private key changes every time it
+// is used. An old copy of such a key, such as a restored backup, would sign
+// again with one-time keys that were already used.
+func Stateful(algorithm Algorithm) bool {
+	return algorithm == Lamport || algorithm == WOTS || algorithm == XMSS
+}
+
 type KeyStore interface {
 	StorePublicKey(userID string, algorithm Algorithm, publicKey []byte) error
 	GetPublicKey(userID string, algorithm Algorithm) ([]byte, error)
@@ -0,0 +1,66 @@
+package ecc
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// Algorithms lists the ECIES variants in the order the client offers them.
+var Algorithms = []crypto.Algorithm{
+	crypto.ECIESP256,
+	crypto.ECIESP384,
+	crypto.ECIESX25519,
+}
+
+// Curve returns the ECDH curve behind an ECIES algorithm.
+func Curve(algorithm crypto.Algorithm) (ecdh.Curve, error) {
+	switch algorithm {
+	case crypto.ECIESP256:
+		return ecdh.P256(), nil
+	case crypto.ECIESP384:
+		return ecdh.P384(), nil
+	case crypto.ECIESX25519:
+		return ecdh.X25519(), nil
+	default:
+		return nil, fmt.Errorf("unsupported ECC algorithm: %s", algorithm)
+	}
+}
+
+func GenerateKey(curve ecdh.Curve) (*ecdh.PrivateKey, error) {
+	return curve.GenerateKey(rand.Reader)
+}
+
+// EncodePrivateKey returns the base64 encoding of the private scalar.
+func EncodePrivateKey(privateKey *ecdh.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(privateKey.Bytes())
+}
+
+// EncodePublicKey returns the base64 encoding of the public key: an
+// uncompressed point for the NIST curves and the u-coordinate for X25519.
+func EncodePublicKey(publicKey *ecdh.PublicKey) string {
+	return base64.StdEncoding.EncodeToString(publicKey.Bytes())
+}
+
+func DecodePrivateKey(curve ecdh.Curve, data string) (*ecdh.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key encoding: %v", err)
+	}
+
+	return curve.NewPrivateKey(raw)
+}
+
+// DecodePublicKey parses a key written by EncodePublicKey. For the NIST
+// curves this rejects points that are not on the curve.
+func DecodePublicKey(curve ecdh.Curve, data string) (*ecdh.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+
+	return curve.NewPublicKey(raw)
+}
@@ -0,0 +1,166 @@
+package ecc
+
+import (
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// ECDSAProvider signs with ECDSA over P-256 and SHA-256. Signatures are
+// deterministic (RFC 6979) and ASN.1 encoded.
+type ECDSAProvider struct {
+	keyStore   crypto.KeyStore
+	userID     string
+	privateKey *ecdsa.PrivateKey
+}
+
+func NewECDSAProvider(keyStore crypto.KeyStore, userID string) *ECDSAProvider {
+	return &ECDSAProvider{
+		keyStore:   keyStore,
+		userID:     userID,
+		privateKey: nil,
+	}
+}
+
+var _ crypto.Signer = (*ECDSAProvider)(nil)
+
+func (p *ECDSAProvider) GenerateKey() error {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	publicKeyStr, err := encodeECDSAPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	p.privateKey = privateKey
+
+	err = p.keyStore.StorePrivateKey(crypto.ECDSAP256, []byte(base64.StdEncoding.EncodeToString(privateKeyDER)))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.ECDSAP256, []byte(publicKeyStr))
+}
+
+func (p *ECDSAProvider) PublicKey() ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	publicKeyStr, err := encodeECDSAPublicKey(&p.privateKey.PublicKey)
+	return []byte(publicKeyStr), err
+}
+
+func (p *ECDSAProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := decodeECDSAPublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.ECDSAP256, publicKeyData)
+}
+
+// Sign passes a nil random source, which makes the signature deterministic.
+// The hash must then be named in the options so the nonce can be derived.
+func (p *ECDSAProvider) Sign(message []byte) ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(message)
+	return p.privateKey.Sign(nil, digest[:], stdcrypto.SHA256)
+}
+
+func (p *ECDSAProvider) Verify(message, signature []byte, signerID string) error {
+	signerKeyBytes, err := p.keyStore.GetPublicKey(signerID, crypto.ECDSAP256)
+	if err != nil {
+		return err
+	}
+
+	signerKey, err := decodeECDSAPublicKey(string(signerKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(message)
+	if !ecdsa.VerifyASN1(signerKey, digest[:], signature) {
+		return errors.New("ecdsa: verification error")
+	}
+
+	return nil
+}
+
+// loadPrivateKey decodes the user's private key from the key store on first
+// use.
+func (p *ECDSAProvider) loadPrivateKey() error {
+	if p.privateKey != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.ECDSAP256)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(string(privateKeyBytes))
+	if err != nil {
+		return fmt.Errorf("invalid private key encoding: %v", err)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return err
+	}
+
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || privateKey.Curve != elliptic.P256() {
+		return errors.New("private key is not a P-256 ECDSA key")
+	}
+
+	p.privateKey = privateKey
+	return nil
+}
+
+// encodeECDSAPublicKey returns the base64 PKIX encoding of the key.
+func encodeECDSAPublicKey(publicKey *ecdsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+func decodeECDSAPublicKey(data string) (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve != elliptic.P256() {
+		return nil, errors.New("public key is not a P-256 ECDSA key")
+	}
+
+	return publicKey, nil
+}
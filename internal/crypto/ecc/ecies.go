@@ -0,0 +1,112 @@
+package ecc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+)
+
+const (
+	eciesInfo      = "crypto-grpc ECIES AES-256-GCM"
+	eciesKeySize   = 32
+	eciesNonceSize = 12
+)
+
+var errDecryption = errors.New("ecies: decryption error")
+
+// Encrypt seals a message for the recipient. A fresh ephemeral key pair is
+// generated on the recipient's curve, the ECDH shared secret is expanded
+// into an AES-256 key with HKDF-SHA-256, and the message is sealed with
+// AES-GCM:
+//
+//	ephemeral public key || nonce (12 bytes) || AES-GCM ciphertext and tag
+//
+// The ephemeral public key is authenticated as additional data.
+func Encrypt(recipient *ecdh.PublicKey, message []byte) ([]byte, error) {
+	ephemeral, err := recipient.Curve().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralBytes := ephemeral.PublicKey().Bytes()
+	aead, err := eciesAEAD(secret, ephemeralBytes, recipient.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	k := len(ephemeralBytes)
+	out := make([]byte, k+eciesNonceSize, k+eciesNonceSize+len(message)+aead.Overhead())
+	copy(out, ephemeralBytes)
+
+	nonce := out[k:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(out, nonce, message, ephemeralBytes), nil
+}
+
+// Decrypt reverses Encrypt.
+func Decrypt(privateKey *ecdh.PrivateKey, ciphertext []byte) ([]byte, error) {
+	k := len(privateKey.PublicKey().Bytes())
+
+	if len(ciphertext) < k+eciesNonceSize {
+		return nil, errDecryption
+	}
+
+	ephemeralBytes := ciphertext[:k]
+	nonce := ciphertext[k : k+eciesNonceSize]
+	sealed := ciphertext[k+eciesNonceSize:]
+
+	ephemeral, err := privateKey.Curve().NewPublicKey(ephemeralBytes)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	secret, err := privateKey.ECDH(ephemeral)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	aead, err := eciesAEAD(secret, ephemeralBytes, privateKey.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := aead.Open(nil, nonce, sealed, ephemeralBytes)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	return message, nil
+}
+
+// eciesAEAD derives the AES-GCM key from the shared secret, binding it to
+// both the ephemeral and the recipient public keys.
+func eciesAEAD(secret, ephemeral, recipient []byte) (cipher.AEAD, error) {
+	salt := make([]byte, 0, len(ephemeral)+len(recipient))
+	salt = append(salt, ephemeral...)
+	salt = append(salt, recipient...)
+
+	key, err := hkdf.Key(sha256.New, secret, salt, eciesInfo, eciesKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
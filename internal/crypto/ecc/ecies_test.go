@@ -0,0 +1,152 @@
+package ecc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	long := make([]byte, 5000)
+	if _, err := rand.Read(long); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, algorithm := range Algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			curve, err := Curve(algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			privateKey, err := GenerateKey(curve)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, message := range [][]byte{nil, []byte("hello"), long} {
+				ciphertext, err := Encrypt(privateKey.PublicKey(), message)
+				if err != nil {
+					t.Fatalf("Encrypt: %v", err)
+				}
+
+				decrypted, err := Decrypt(privateKey, ciphertext)
+				if err != nil {
+					t.Fatalf("Decrypt: %v", err)
+				}
+				if !bytes.Equal(decrypted, message) {
+					t.Fatalf("Decrypt(Encrypt(m)) differs for a %d-byte message", len(message))
+				}
+			}
+		})
+	}
+}
+
+func TestDecryptRejectsTampering(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			curve, err := Curve(algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			privateKey, err := GenerateKey(curve)
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := GenerateKey(curve)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ciphertext, err := Encrypt(privateKey.PublicKey(), []byte("attack at dawn"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			k := len(privateKey.PublicKey().Bytes())
+
+			// Flipping a byte of every part must fail: the ephemeral key,
+			// the nonce, the body and the tag.
+			for _, i := range []int{k - 1, k, k + eciesNonceSize, len(ciphertext) - 1} {
+				tampered := bytes.Clone(ciphertext)
+				tampered[i] ^= 0x01
+				if _, err := Decrypt(privateKey, tampered); err == nil {
+					t.Errorf("Decrypt succeeded with byte %d flipped", i)
+				}
+			}
+
+			if _, err := Decrypt(privateKey, ciphertext[:k+eciesNonceSize-1]); err == nil {
+				t.Error("Decrypt succeeded on a truncated ciphertext")
+			}
+			if _, err := Decrypt(other, ciphertext); err == nil {
+				t.Error("Decrypt succeeded with another key")
+			}
+		})
+	}
+}
+
+func TestProviderRoundTrip(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			alice, err := NewECIESProvider(keystore.NewClientKeyStore("alice"), "alice", algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bob, err := NewECIESProvider(keystore.NewClientKeyStore("bob"), "bob", algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := bob.GenerateKey(); err != nil {
+				t.Fatal(err)
+			}
+
+			publicKey, err := bob.PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := alice.ParsePublicKey("bob", publicKey); err != nil {
+				t.Fatalf("ParsePublicKey: %v", err)
+			}
+
+			ciphertext, err := alice.Encrypt([]byte("hi bob"), "bob")
+			if err != nil {
+				t.Fatalf("Encrypt: %v", err)
+			}
+			decrypted, err := bob.Decrypt(ciphertext)
+			if err != nil {
+				t.Fatalf("Decrypt: %v", err)
+			}
+			if string(decrypted) != "hi bob" {
+				t.Fatalf("Decrypt = %q", decrypted)
+			}
+		})
+	}
+}
+
+func TestDecodePublicKeyRejectsInvalidPoints(t *testing.T) {
+	for _, algorithm := range []crypto.Algorithm{crypto.ECIESP256, crypto.ECIESP384} {
+		curve, err := Curve(algorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privateKey, err := GenerateKey(curve)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		point := privateKey.PublicKey().Bytes()
+		point[len(point)-1] ^= 0x01
+
+		if _, err := DecodePublicKey(curve, EncodePublicKey(privateKey.PublicKey())); err != nil {
+			t.Fatalf("%s: DecodePublicKey of a valid key: %v", algorithm, err)
+		}
+		if _, err := DecodePublicKey(curve, base64.StdEncoding.EncodeToString(point)); err == nil {
+			t.Fatalf("%s: a point off the curve was accepted", algorithm)
+		}
+		if _, err := DecodePublicKey(curve, "not base64!"); err == nil {
+			t.Fatalf("%s: DecodePublicKey accepted bad base64", algorithm)
+		}
+	}
+}
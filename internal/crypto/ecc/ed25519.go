@@ -0,0 +1,124 @@
+package ecc
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// Ed25519Provider signs with Ed25519. The private key is stored as its
+// 32-byte seed and the public key as its 32-byte encoding, both in base64.
+type Ed25519Provider struct {
+	keyStore   crypto.KeyStore
+	userID     string
+	privateKey ed25519.PrivateKey
+}
+
+func NewEd25519Provider(keyStore crypto.KeyStore, userID string) *Ed25519Provider {
+	return &Ed25519Provider{
+		keyStore:   keyStore,
+		userID:     userID,
+		privateKey: nil,
+	}
+}
+
+var _ crypto.Signer = (*Ed25519Provider)(nil)
+
+func (p *Ed25519Provider) GenerateKey() error {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	p.privateKey = privateKey
+
+	err = p.keyStore.StorePrivateKey(crypto.Ed25519, []byte(base64.StdEncoding.EncodeToString(privateKey.Seed())))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.Ed25519, []byte(base64.StdEncoding.EncodeToString(publicKey)))
+}
+
+func (p *Ed25519Provider) PublicKey() ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	publicKey := p.privateKey.Public().(ed25519.PublicKey)
+	return []byte(base64.StdEncoding.EncodeToString(publicKey)), nil
+}
+
+func (p *Ed25519Provider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := decodeEd25519PublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.Ed25519, publicKeyData)
+}
+
+func (p *Ed25519Provider) Sign(message []byte) ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	return ed25519.Sign(p.privateKey, message), nil
+}
+
+func (p *Ed25519Provider) Verify(message, signature []byte, signerID string) error {
+	signerKeyBytes, err := p.keyStore.GetPublicKey(signerID, crypto.Ed25519)
+	if err != nil {
+		return err
+	}
+
+	signerKey, err := decodeEd25519PublicKey(string(signerKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(signerKey, message, signature) {
+		return errors.New("ed25519: verification error")
+	}
+
+	return nil
+}
+
+// loadPrivateKey decodes the user's private key from the key store on first
+// use.
+func (p *Ed25519Provider) loadPrivateKey() error {
+	if p.privateKey != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.Ed25519)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(string(privateKeyBytes))
+	if err != nil {
+		return fmt.Errorf("invalid private key encoding: %v", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return fmt.Errorf("private key must be a %d-byte seed", ed25519.SeedSize)
+	}
+
+	p.privateKey = ed25519.NewKeyFromSeed(seed)
+	return nil
+}
+
+func decodeEd25519PublicKey(data string) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes", ed25519.PublicKeySize)
+	}
+
+	return ed25519.PublicKey(raw), nil
+}
@@ -0,0 +1,128 @@
+package ecc
+
+import (
+	"crypto/ecdh"
+	"fmt"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// ECIESProvider encrypts with ECIES over a single curve. Each curve is its
+// own algorithm, so the client registers one provider per curve.
+type ECIESProvider struct {
+	keyStore   crypto.KeyStore
+	userID     string
+	algorithm  crypto.Algorithm
+	curve      ecdh.Curve
+	privateKey *ecdh.PrivateKey
+}
+
+func NewECIESProvider(keyStore crypto.KeyStore, userID string, algorithm crypto.Algorithm) (*ECIESProvider, error) {
+	curve, err := Curve(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ECIESProvider{
+		keyStore:   keyStore,
+		userID:     userID,
+		algorithm:  algorithm,
+		curve:      curve,
+		privateKey: nil,
+	}, nil
+}
+
+var _ crypto.Provider = (*ECIESProvider)(nil)
+
+func (p *ECIESProvider) Algorithm() crypto.Algorithm {
+	return p.algorithm
+}
+
+func (p *ECIESProvider) GenerateKey() error {
+	privateKey, err := GenerateKey(p.curve)
+	if err != nil {
+		return err
+	}
+
+	return p.storePrivateKey(privateKey)
+}
+
+// StorePrivateKey imports a base64-encoded private key.
+func (p *ECIESProvider) StorePrivateKey(privateKeyData string) error {
+	privateKey, err := DecodePrivateKey(p.curve, privateKeyData)
+	if err != nil {
+		return err
+	}
+
+	return p.storePrivateKey(privateKey)
+}
+
+func (p *ECIESProvider) storePrivateKey(privateKey *ecdh.PrivateKey) error {
+	p.privateKey = privateKey
+
+	err := p.keyStore.StorePrivateKey(p.algorithm, []byte(EncodePrivateKey(privateKey)))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, p.algorithm, []byte(EncodePublicKey(privateKey.PublicKey())))
+}
+
+func (p *ECIESProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodePublicKey(p.curve, string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, p.algorithm, publicKeyData)
+}
+
+func (p *ECIESProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, p.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientKey, err := DecodePublicKey(p.curve, string(recipientKeyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return Encrypt(recipientKey, message)
+}
+
+func (p *ECIESProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	return Decrypt(p.privateKey, ciphertext)
+}
+
+func (p *ECIESProvider) PublicKey() ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	return []byte(EncodePublicKey(p.privateKey.PublicKey())), nil
+}
+
+// loadPrivateKey decodes the user's private key from the key store on first
+// use.
+func (p *ECIESProvider) loadPrivateKey() error {
+	if p.privateKey != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(p.algorithm)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	privateKey, err := DecodePrivateKey(p.curve, string(privateKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	p.privateKey = privateKey
+	return nil
+}
@@ -0,0 +1,84 @@
+package ecc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+func TestSigners(t *testing.T) {
+	signers := []struct {
+		name string
+		new  func(crypto.KeyStore, string) crypto.Signer
+	}{
+		{"ECDSA", func(ks crypto.KeyStore, userID string) crypto.Signer { return NewECDSAProvider(ks, userID) }},
+		{"Ed25519", func(ks crypto.KeyStore, userID string) crypto.Signer { return NewEd25519Provider(ks, userID) }},
+	}
+
+	for _, tt := range signers {
+		t.Run(tt.name, func(t *testing.T) {
+			alice := tt.new(keystore.NewClientKeyStore("alice"), "alice")
+			carol := tt.new(keystore.NewClientKeyStore("carol"), "carol")
+			bob := tt.new(keystore.NewClientKeyStore("bob"), "bob")
+
+			for _, signer := range []struct {
+				id       string
+				provider crypto.Signer
+			}{{"alice", alice}, {"carol", carol}} {
+				if err := signer.provider.GenerateKey(); err != nil {
+					t.Fatal(err)
+				}
+				publicKey, err := signer.provider.PublicKey()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := bob.ParsePublicKey(signer.id, publicKey); err != nil {
+					t.Fatalf("ParsePublicKey: %v", err)
+				}
+			}
+
+			message := []byte("pay bob 10 coins")
+			signature, err := alice.Sign(message)
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			if err := bob.Verify(message, signature, "alice"); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			again, err := alice.Sign(message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(again, signature) {
+				t.Error("signatures are not deterministic")
+			}
+
+			flipped := bytes.Clone(signature)
+			flipped[len(flipped)-1] ^= 0x01
+
+			for _, bad := range []struct {
+				name      string
+				message   []byte
+				signature []byte
+				signerID  string
+			}{
+				{"other message", []byte("pay bob 99 coins"), signature, "alice"},
+				{"flipped bit", message, flipped, "alice"},
+				{"other signer", message, signature, "carol"},
+				{"unknown signer", message, signature, "dave"},
+				{"empty", message, nil, "alice"},
+			} {
+				if err := bob.Verify(bad.message, bad.signature, bad.signerID); err == nil {
+					t.Errorf("%s: Verify succeeded", bad.name)
+				}
+			}
+
+			if err := bob.ParsePublicKey("eve", []byte("not a key")); err == nil {
+				t.Error("ParsePublicKey accepted garbage")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package elgamal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// CiphertextVersion is the current ciphertext format:
+//
+//	version (1 byte) || width (2 bytes, big endian) || a (width bytes) || b (width bytes)
+//
+// where width is the byte length of P and a and b are left-padded with zeros,
+// so the split point never depends on the values themselves.
+const CiphertextVersion = 0x01
+
+const ciphertextHeaderSize = 3
+
+// EncodeCiphertext encodes the pair (a, b) for the group with prime P.
+func EncodeCiphertext(a, b, p *big.Int) ([]byte, error) {
+	if a.Sign() < 0 || b.Sign() < 0 || a.Cmp(p) >= 0 || b.Cmp(p) >= 0 {
+		return nil, errors.New("a and b must be in [0, P)")
+	}
+
+	width := (p.BitLen() + 7) / 8
+	if width > 0xffff {
+		return nil, errors.New("P is too large to encode")
+	}
+
+	out := make([]byte, ciphertextHeaderSize+2*width)
+	out[0] = CiphertextVersion
+	binary.BigEndian.PutUint16(out[1:ciphertextHeaderSize], uint16(width))
+	a.FillBytes(out[ciphertextHeaderSize : ciphertextHeaderSize+width])
+	b.FillBytes(out[ciphertextHeaderSize+width:])
+
+	return out, nil
+}
+
+// DecodeCiphertext splits an encoded ciphertext back into (a, b).
+func DecodeCiphertext(data []byte) (a, b *big.Int, err error) {
+	if len(data) < ciphertextHeaderSize {
+		return nil, nil, errors.New("ciphertext too short")
+	}
+	if data[0] != CiphertextVersion {
+		return nil, nil, fmt.Errorf("unsupported ciphertext version %d", data[0])
+	}
+
+	width := int(binary.BigEndian.Uint16(data[1:ciphertextHeaderSize]))
+	if width == 0 || len(data) != ciphertextHeaderSize+2*width {
+		return nil, nil, errors.New("ciphertext length does not match its header")
+	}
+
+	a = new(big.Int).SetBytes(data[ciphertextHeaderSize : ciphertextHeaderSize+width])
+	b = new(big.Int).SetBytes(data[ciphertextHeaderSize+width:])
+
+	return a, b, nil
+}
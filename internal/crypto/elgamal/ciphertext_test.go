@@ -0,0 +1,194 @@
+package elgamal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	mrand "math/rand/v2"
+	"testing"
+)
+
+// randomMessage returns up to maxLen random bytes. The first byte is never
+// zero, since leading zeros do not survive decryption.
+func randomMessage(t *testing.T, maxLen int) []byte {
+	t.Helper()
+
+	message := make([]byte, mrand.IntN(maxLen+1))
+	if _, err := rand.Read(message); err != nil {
+		t.Fatal(err)
+	}
+	if len(message) > 0 && message[0] == 0 {
+		message[0] = 1
+	}
+	return message
+}
+
+func TestEncryptDecryptRandomMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		bits     int
+		messages int
+	}{
+		{"256-bit", 256, 3000},
+		{"modp2048", 0, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider(t, tt.bits)
+			maxLen := (provider.keyPair.P.BitLen()+7)/8 - 1
+
+			for i := 0; i < tt.messages; i++ {
+				message := randomMessage(t, maxLen)
+
+				ciphertext, err := provider.Encrypt(message, "alice")
+				if err != nil {
+					t.Fatalf("Encrypt(%x): %v", message, err)
+				}
+
+				decrypted, err := provider.Decrypt(ciphertext)
+				if err != nil {
+					t.Fatalf("Decrypt of %x: %v", message, err)
+				}
+				if !bytes.Equal(decrypted, message) {
+					t.Fatalf("Decrypt(Encrypt(%x)) = %x", message, decrypted)
+				}
+			}
+		})
+	}
+}
+
+// randomBelow returns a random value below p with a random byte length, so
+// encodings need padding and a and b rarely have the same length.
+func randomBelow(t *testing.T, p *big.Int) *big.Int {
+	t.Helper()
+
+	switch mrand.IntN(8) {
+	case 0:
+		return big.NewInt(0)
+	case 1:
+		return big.NewInt(mrand.Int64N(256))
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(mrand.IntN(p.BitLen())+1))
+	if limit.Cmp(p) > 0 {
+		limit = p
+	}
+
+	v, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestCiphertextEncodingRoundTrip(t *testing.T) {
+	for i := 0; i < 5000; i++ {
+		p, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(mrand.IntN(2048)+9)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.SetBit(p, 0, 1).Add(p, big.NewInt(256))
+
+		a, b := randomBelow(t, p), randomBelow(t, p)
+
+		encoded, err := EncodeCiphertext(a, b, p)
+		if err != nil {
+			t.Fatalf("EncodeCiphertext(%s, %s, %s): %v", a, b, p, err)
+		}
+
+		width := (p.BitLen() + 7) / 8
+		if len(encoded) != ciphertextHeaderSize+2*width {
+			t.Fatalf("encoded length %d, want %d", len(encoded), ciphertextHeaderSize+2*width)
+		}
+
+		decodedA, decodedB, err := DecodeCiphertext(encoded)
+		if err != nil {
+			t.Fatalf("DecodeCiphertext: %v", err)
+		}
+		if decodedA.Cmp(a) != 0 || decodedB.Cmp(b) != 0 {
+			t.Fatalf("round trip of (%s, %s) gave (%s, %s)", a, b, decodedA, decodedB)
+		}
+	}
+}
+
+func TestCiphertextEncodingLeadingZeros(t *testing.T) {
+	p := new(big.Int).Lsh(big.NewInt(1), 64)
+	p.Add(p, big.NewInt(13))
+
+	// a fills its 9 bytes except for a leading zero; b is a single byte.
+	a := new(big.Int).SetBytes([]byte{0x00, 0xff, 1, 2, 3, 4, 5, 6, 7})
+	b := big.NewInt(1)
+
+	encoded, err := EncodeCiphertext(a, b, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{CiphertextVersion, 0, 9,
+		0x00, 0xff, 1, 2, 3, 4, 5, 6, 7,
+		0, 0, 0, 0, 0, 0, 0, 0, 1}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("EncodeCiphertext = %x, want %x", encoded, want)
+	}
+
+	decodedA, decodedB, err := DecodeCiphertext(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedA.Cmp(a) != 0 || decodedB.Cmp(b) != 0 {
+		t.Fatalf("round trip gave (%s, %s)", decodedA, decodedB)
+	}
+}
+
+func TestDecodeCiphertextRejects(t *testing.T) {
+	p := big.NewInt(1000003)
+	valid, err := EncodeCiphertext(big.NewInt(5), big.NewInt(999999), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modified := func(change func([]byte) []byte) []byte {
+		return change(bytes.Clone(valid))
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"header only", valid[:ciphertextHeaderSize]},
+		{"truncated header", valid[:ciphertextHeaderSize-1]},
+		{"truncated body", valid[:len(valid)-1]},
+		{"trailing byte", append(bytes.Clone(valid), 0)},
+		{"version 0", modified(func(d []byte) []byte { d[0] = 0; return d })},
+		{"version 2", modified(func(d []byte) []byte { d[0] = 2; return d })},
+		{"width 0", modified(func(d []byte) []byte { d[1], d[2] = 0, 0; return d })},
+		{"width too small", modified(func(d []byte) []byte { d[2]--; return d })},
+		{"width too large", modified(func(d []byte) []byte { d[2]++; return d })},
+		{"width in high byte", modified(func(d []byte) []byte { d[1] = 1; return d })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeCiphertext(tt.data); err == nil {
+				t.Fatalf("DecodeCiphertext(%x) succeeded", tt.data)
+			}
+		})
+	}
+}
+
+func TestEncodeCiphertextRejectsOutOfRange(t *testing.T) {
+	p := big.NewInt(1000003)
+
+	for _, values := range [][2]*big.Int{
+		{p, big.NewInt(1)},
+		{big.NewInt(1), p},
+		{big.NewInt(-1), big.NewInt(1)},
+		{big.NewInt(1), big.NewInt(-1)},
+	} {
+		if _, err := EncodeCiphertext(values[0], values[1], p); err == nil {
+			t.Fatalf("EncodeCiphertext(%s, %s) succeeded", values[0], values[1])
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 )
 
 type ElGamalKeyPair struct {
@@ -12,6 +13,9 @@ type ElGamalKeyPair struct {
 	Y big.Int
 
 	X big.Int
+
+	// Group names the built-in parameter set P and G come from, if any.
+	Group string
 }
 
 func CreateElGamalKeyPair(p, g, x *big.Int) (*ElGamalKeyPair, error) {
@@ -19,6 +23,40 @@ func CreateElGamalKeyPair(p, g, x *big.Int) (*ElGamalKeyPair, error) {
 		return nil, errors.New("p, g, and x must not be nil")
 	}
 
+	if group := findGroup(p, g); group != nil {
+		return createKeyPair(group.P, group.G, x, group.Name)
+	}
+
+	if err := ValidateParameters(p, g); err != nil {
+		return nil, err
+	}
+
+	return createKeyPair(p, g, x, "")
+}
+
+// CreateElGamalKeyPairFromGroup creates a key pair over a built-in group.
+// If x is nil a random secret is drawn.
+func CreateElGamalKeyPairFromGroup(groupName string, x *big.Int) (*ElGamalKeyPair, error) {
+	group, err := LookupGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if x == nil {
+		x, err = GenerateSecret(group.P)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return createKeyPair(group.P, group.G, x, group.Name)
+}
+
+func createKeyPair(p, g, x *big.Int, group string) (*ElGamalKeyPair, error) {
+	if x.Sign() <= 0 || x.Cmp(new(big.Int).Sub(p, one)) >= 0 {
+		return nil, errors.New("X must be between 1 and P-2")
+	}
+
 	y := new(big.Int).Exp(g, x, p)
 
 	return &ElGamalKeyPair{
@@ -27,6 +65,8 @@ func CreateElGamalKeyPair(p, g, x *big.Int) (*ElGamalKeyPair, error) {
 		Y: *y,
 
 		X: *x,
+
+		Group: group,
 	}, nil
 }
 
@@ -60,54 +100,94 @@ func Decrypt(keyPair *ElGamalKeyPair, a, b *big.Int) ([]byte, error) {
 	return m.Bytes(), nil
 }
 
+// EncodeToString encodes the public key as "Y,P,G" and the private key as
+// "X,P,G". Keys over a built-in group append the group name to both.
 func (kp *ElGamalKeyPair) EncodeToString() (privateKey, publicKey string, err error) {
 	publicKey = fmt.Sprintf("%s,%s,%s", kp.Y.String(), kp.P.String(), kp.G.String())
-	privateKey = fmt.Sprint(kp.X.String())
+	privateKey = fmt.Sprintf("%s,%s,%s", kp.X.String(), kp.P.String(), kp.G.String())
+
+	if kp.Group != "" {
+		publicKey += "," + kp.Group
+		privateKey += "," + kp.Group
+	}
 
 	return privateKey, publicKey, nil
 }
 
+// DecodePrivateKey accepts the encoding written by EncodeToString and the
+// older bare "X" form, which carries no group.
 func DecodePrivateKey(data string) (*ElGamalKeyPair, error) {
-	var xStr string
-	_, err := fmt.Sscanf(data, "%s", &xStr)
+	keys := strings.Split(data, ",")
+
+	if len(keys) == 1 {
+		x := new(big.Int)
+		if _, ok := x.SetString(keys[0], 10); !ok {
+			return nil, errors.New("invalid X value")
+		}
+
+		return &ElGamalKeyPair{
+			X: *x,
+		}, nil
+	}
+
+	values, group, err := decodeValues(keys, "X", "P", "G")
 	if err != nil {
-		return nil, errors.New("invalid private key format")
+		return nil, errors.New("invalid private key format: " + err.Error())
 	}
+	x, p, g := values[0], values[1], values[2]
 
-	x := new(big.Int)
-	if _, ok := x.SetString(xStr, 10); !ok {
-		return nil, errors.New("invalid X value")
+	if group != "" {
+		if err := checkGroup(group, p, g); err != nil {
+			return nil, err
+		}
 	}
 
-	return &ElGamalKeyPair{
-		X: *x,
-	}, nil
+	return createKeyPair(p, g, x, group)
 }
 
+// DecodePublicKey parses "Y,P,G" or "Y,P,G,group". When a group is named,
+// P and G must be exactly that group's parameters.
 func DecodePublicKey(data string) (*ElGamalKeyPair, error) {
-	var yStr, pStr, gStr string
-	_, err := fmt.Sscanf(data, "%s,%s,%s", &yStr, &pStr, &gStr)
+	values, group, err := decodeValues(strings.Split(data, ","), "Y", "P", "G")
 	if err != nil {
-		return nil, errors.New("invalid public key format")
+		return nil, errors.New("invalid public key format: " + err.Error())
 	}
+	y, p, g := values[0], values[1], values[2]
 
-	y := new(big.Int)
-	p := new(big.Int)
-	g := new(big.Int)
-
-	if _, ok := y.SetString(yStr, 10); !ok {
-		return nil, errors.New("invalid Y value")
-	}
-	if _, ok := p.SetString(pStr, 10); !ok {
-		return nil, errors.New("invalid P value")
-	}
-	if _, ok := g.SetString(gStr, 10); !ok {
-		return nil, errors.New("invalid G value")
+	if group != "" {
+		if err := checkGroup(group, p, g); err != nil {
+			return nil, err
+		}
 	}
 
 	return &ElGamalKeyPair{
 		Y: *y,
 		P: *p,
 		G: *g,
+
+		Group: group,
 	}, nil
 }
+
+// decodeValues parses the named decimal fields, followed by an optional
+// group name.
+func decodeValues(fields []string, names ...string) ([]*big.Int, string, error) {
+	if len(fields) != len(names) && len(fields) != len(names)+1 {
+		return nil, "", fmt.Errorf("expected %d or %d fields", len(names), len(names)+1)
+	}
+
+	values := make([]*big.Int, len(names))
+	for i, name := range names {
+		values[i] = new(big.Int)
+		if _, ok := values[i].SetString(fields[i], 10); !ok {
+			return nil, "", fmt.Errorf("invalid %s value", name)
+		}
+	}
+
+	group := ""
+	if len(fields) > len(names) {
+		group = fields[len(names)]
+	}
+
+	return values, group, nil
+}
@@ -0,0 +1,71 @@
+package elgamal
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+// newTestProvider returns a provider for "alice" with a fresh key over a
+// new safe prime of the given size, or over modp2048 when bits is 0.
+func newTestProvider(t *testing.T, bits int) *ElGamalProvider {
+	t.Helper()
+
+	provider := NewElGamalProvider(keystore.NewClientKeyStore("alice"), "alice")
+	var err error
+	if bits == 0 {
+		err = provider.GenerateKeyPairForGroup("modp2048")
+	} else {
+		err = provider.GenerateKeyPair(bits)
+	}
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	return provider
+}
+
+func TestEncryptDrawsFreshK(t *testing.T) {
+	provider := newTestProvider(t, 256)
+	message := []byte("same message")
+
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		ciphertext, err := provider.Encrypt(message, "alice")
+		if err != nil {
+			t.Fatalf("Encrypt: %v", err)
+		}
+		if seen[string(ciphertext)] {
+			t.Fatal("Encrypt repeated a ciphertext")
+		}
+		seen[string(ciphertext)] = true
+
+		decrypted, err := provider.Decrypt(ciphertext)
+		if err != nil {
+			t.Fatalf("Decrypt: %v", err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("Decrypt = %q, want %q", decrypted, message)
+		}
+	}
+}
+
+func TestRandomEphemeralRange(t *testing.T) {
+	p := big.NewInt(23)
+
+	seen := make(map[int64]bool)
+	for i := 0; i < 2000; i++ {
+		k, err := RandomEphemeral(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k.Int64() < 1 || k.Int64() > 21 {
+			t.Fatalf("RandomEphemeral(23) = %s, outside [1, 21]", k)
+		}
+		seen[k.Int64()] = true
+	}
+	if len(seen) != 21 {
+		t.Fatalf("RandomEphemeral(23) hit %d of 21 values", len(seen))
+	}
+}
@@ -0,0 +1,201 @@
+package elgamal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// DefaultMaxExponent bounds the plaintexts DecryptExponent searches for by
+// default. It is large enough for any vote count.
+const DefaultMaxExponent = 1 << 20
+
+// EncryptExponent encrypts g^m instead of m ("exponential ElGamal"):
+//
+//	a = g^k, b = g^m · y^k
+//
+// Multiplying two such ciphertexts component-wise gives an encryption of the
+// sum of their plaintexts, which is what CombineCiphertexts does.
+func EncryptExponent(keyPair *ElGamalKeyPair, m, k *big.Int) (*big.Int, *big.Int, error) {
+	if m.Sign() < 0 {
+		return nil, nil, errors.New("m must not be negative")
+	}
+
+	a := new(big.Int).Exp(&keyPair.G, k, &keyPair.P)
+
+	b := new(big.Int).Exp(&keyPair.Y, k, &keyPair.P)
+	b.Mul(b, new(big.Int).Exp(&keyPair.G, m, &keyPair.P))
+	b.Mod(b, &keyPair.P)
+
+	return a, b, nil
+}
+
+// DecryptExponent recovers g^m = b / a^x and then m itself with
+// BabyStepGiantStep, searching [0, max].
+func DecryptExponent(keyPair *ElGamalKeyPair, a, b *big.Int, max int64) (*big.Int, error) {
+	gm, err := Decrypt(keyPair, a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return BabyStepGiantStep(&keyPair.G, new(big.Int).SetBytes(gm), &keyPair.P, max)
+}
+
+// BabyStepGiantStep finds m in [0, max] with g^m = h mod p in about
+// 2·sqrt(max) multiplications. With s = ⌈sqrt(max+1)⌉ it writes m = i·s + j,
+// stores the baby steps g^j for j < s and walks the giant steps h·g^(-i·s)
+// until one of them is in the table.
+func BabyStepGiantStep(g, h, p *big.Int, max int64) (*big.Int, error) {
+	if max < 0 {
+		return nil, errors.New("max must not be negative")
+	}
+
+	s := int64(1)
+	for s*s <= max {
+		s++
+	}
+
+	babySteps := make(map[string]int64, s)
+	x := big.NewInt(1)
+	for j := int64(0); j < s; j++ {
+		key := string(x.Bytes())
+		if _, exists := babySteps[key]; !exists {
+			babySteps[key] = j
+		}
+		x.Mul(x, g).Mod(x, p)
+	}
+
+	// x is now g^s; step by its inverse.
+	giantStep := new(big.Int).ModInverse(x, p)
+	if giantStep == nil {
+		return nil, errors.New("g is not invertible mod p")
+	}
+
+	y := new(big.Int).Mod(h, p)
+	for i := int64(0); i*s <= max; i++ {
+		if j, found := babySteps[string(y.Bytes())]; found && i*s+j <= max {
+			return big.NewInt(i*s + j), nil
+		}
+		y.Mul(y, giantStep).Mod(y, p)
+	}
+
+	return nil, fmt.Errorf("no exponent found in [0, %d]", max)
+}
+
+// CombineCiphertexts multiplies ciphertexts component-wise mod P. For
+// exponential ElGamal the result encrypts the sum of the plaintexts; for
+// plain ElGamal, their product.
+func CombineCiphertexts(p *big.Int, as, bs []*big.Int) (*big.Int, *big.Int, error) {
+	if len(as) == 0 || len(as) != len(bs) {
+		return nil, nil, errors.New("need the same, non-zero number of a and b values")
+	}
+
+	a, b := big.NewInt(1), big.NewInt(1)
+	for i := range as {
+		a.Mul(a, as[i]).Mod(a, p)
+		b.Mul(b, bs[i]).Mod(b, p)
+	}
+
+	return a, b, nil
+}
+
+// Sum combines encoded ciphertexts under an encoded public key, returning
+// the encoded combination. It only needs the public key, so a server can
+// tally exponential ElGamal votes it cannot read.
+func Sum(publicKey string, ciphertexts [][]byte) ([]byte, error) {
+	keyPair, err := DecodePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	as := make([]*big.Int, len(ciphertexts))
+	bs := make([]*big.Int, len(ciphertexts))
+	for i, data := range ciphertexts {
+		a, b, err := DecodeCiphertext(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCiphertextPart(a, &keyPair.P); err != nil {
+			return nil, err
+		}
+		if err := checkCiphertextPart(b, &keyPair.P); err != nil {
+			return nil, err
+		}
+		as[i], bs[i] = a, b
+	}
+
+	a, b, err := CombineCiphertexts(&keyPair.P, as, bs)
+	if err != nil {
+		return nil, err
+	}
+
+	return EncodeCiphertext(a, b, &keyPair.P)
+}
+
+// checkCiphertextPart requires 0 < v < P; a zero would wipe out the sum.
+func checkCiphertextPart(v, p *big.Int) error {
+	if v.Sign() <= 0 || v.Cmp(p) >= 0 {
+		return errors.New("ciphertext values must be in (0, P)")
+	}
+	return nil
+}
+
+// ExponentialProvider encrypts small numbers with exponential ElGamal under
+// the user's existing ElGamal key pair.
+type ExponentialProvider struct {
+	provider *ElGamalProvider
+}
+
+func NewExponentialProvider(provider *ElGamalProvider) *ExponentialProvider {
+	return &ExponentialProvider{provider: provider}
+}
+
+func (p *ExponentialProvider) GenerateKey() error {
+	return p.provider.GenerateKey()
+}
+
+func (p *ExponentialProvider) PublicKey() ([]byte, error) {
+	return p.provider.PublicKey()
+}
+
+func (p *ExponentialProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	return p.provider.ParsePublicKey(userID, publicKeyData)
+}
+
+func (p *ExponentialProvider) ProvePossession(userID string) ([]byte, error) {
+	return p.provider.ProvePossession(userID)
+}
+
+// EncryptValue encrypts g^value for the recipient with a fresh random k.
+func (p *ExponentialProvider) EncryptValue(value *big.Int, recipientID string) ([]byte, error) {
+	recipientKey, err := p.provider.recipientKey(recipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := RandomEphemeral(&recipientKey.P)
+	if err != nil {
+		return nil, err
+	}
+
+	a, b, err := EncryptExponent(recipientKey, value, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return EncodeCiphertext(a, b, &recipientKey.P)
+}
+
+// DecryptValue decrypts a value in [0, DefaultMaxExponent].
+func (p *ExponentialProvider) DecryptValue(ciphertext []byte) (*big.Int, error) {
+	if err := p.provider.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	a, b, err := DecodeCiphertext(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecryptExponent(p.provider.keyPair, a, b, DefaultMaxExponent)
+}
@@ -0,0 +1,111 @@
+package elgamal
+
+import (
+	"math/big"
+	mrand "math/rand/v2"
+	"testing"
+)
+
+func TestExponentRoundTrip(t *testing.T) {
+	provider := newTestProvider(t, 256)
+	exponential := NewExponentialProvider(provider)
+
+	for _, value := range []int64{0, 1, 2, 999, DefaultMaxExponent} {
+		ciphertext, err := exponential.EncryptValue(big.NewInt(value), "alice")
+		if err != nil {
+			t.Fatalf("EncryptValue(%d): %v", value, err)
+		}
+
+		decrypted, err := exponential.DecryptValue(ciphertext)
+		if err != nil {
+			t.Fatalf("DecryptValue of %d: %v", value, err)
+		}
+		if decrypted.Int64() != value {
+			t.Fatalf("DecryptValue(EncryptValue(%d)) = %s", value, decrypted)
+		}
+	}
+}
+
+func TestSumAddsPlaintexts(t *testing.T) {
+	provider := newTestProvider(t, 256)
+	exponential := NewExponentialProvider(provider)
+
+	publicKey, err := provider.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ciphertexts [][]byte
+	var want int64
+	for i := 0; i < 50; i++ {
+		value := mrand.Int64N(100)
+		want += value
+
+		ciphertext, err := exponential.EncryptValue(big.NewInt(value), "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ciphertexts = append(ciphertexts, ciphertext)
+	}
+
+	sum, err := Sum(string(publicKey), ciphertexts)
+	if err != nil {
+		t.Fatalf("Sum: %v", err)
+	}
+
+	total, err := exponential.DecryptValue(sum)
+	if err != nil {
+		t.Fatalf("DecryptValue: %v", err)
+	}
+	if total.Int64() != want {
+		t.Fatalf("sum decrypts to %s, want %d", total, want)
+	}
+}
+
+func TestSumRejectsBadCiphertexts(t *testing.T) {
+	provider := newTestProvider(t, 256)
+	publicKey, err := provider.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &provider.keyPair.P
+
+	zero, err := EncodeCiphertext(big.NewInt(0), big.NewInt(5), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ciphertexts := range [][][]byte{
+		nil,
+		{zero},
+		{[]byte("garbage")},
+	} {
+		if _, err := Sum(string(publicKey), ciphertexts); err == nil {
+			t.Fatalf("Sum(%x) succeeded", ciphertexts)
+		}
+	}
+}
+
+func TestBabyStepGiantStep(t *testing.T) {
+	p := big.NewInt(1000003)
+	g := big.NewInt(2)
+
+	for _, max := range []int64{0, 1, 15, 16, 17, 1000} {
+		for m := int64(0); m <= max; m += max/7 + 1 {
+			h := new(big.Int).Exp(g, big.NewInt(m), p)
+
+			found, err := BabyStepGiantStep(g, h, p, max)
+			if err != nil {
+				t.Fatalf("BabyStepGiantStep(m = %d, max = %d): %v", m, max, err)
+			}
+			if found.Int64() != m {
+				t.Fatalf("BabyStepGiantStep(m = %d, max = %d) = %s", m, max, found)
+			}
+		}
+
+		h := new(big.Int).Exp(g, big.NewInt(max+1), p)
+		if _, err := BabyStepGiantStep(g, h, p, max); err == nil {
+			t.Fatalf("BabyStepGiantStep found %d with max = %d", max+1, max)
+		}
+	}
+}
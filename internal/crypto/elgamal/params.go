@@ -0,0 +1,200 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// MinPrimeBits is the smallest prime size GenerateSafePrime accepts.
+const MinPrimeBits = 16
+
+// smallFactorBound limits the trial division used to look for small
+// subgroups in the multiplicative group mod P.
+const smallFactorBound = 1 << 16
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+)
+
+// GenerateSafePrime returns a random prime P of the given size such that
+// Q = (P-1)/2 is also prime.
+func GenerateSafePrime(bits int) (*big.Int, error) {
+	if bits < MinPrimeBits {
+		return nil, fmt.Errorf("prime size must be at least %d bits", MinPrimeBits)
+	}
+
+	for {
+		q, err := rand.Prime(rand.Reader, bits-1)
+		if err != nil {
+			return nil, err
+		}
+
+		// p = 2q + 1
+		p := new(big.Int).Lsh(q, 1)
+		p.Add(p, one)
+
+		if p.BitLen() == bits && p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// FindGenerator returns a generator of the subgroup of prime order
+// Q = (P-1)/2 for a safe prime P. Squaring a random element lands in that
+// subgroup, and every element of it other than 1 generates it.
+func FindGenerator(p *big.Int) (*big.Int, error) {
+	if !isSafePrime(p) {
+		return nil, errors.New("P must be a safe prime")
+	}
+
+	pMinus3 := new(big.Int).Sub(p, big.NewInt(3))
+
+	for {
+		// h in [2, P-2]
+		h, err := rand.Int(rand.Reader, pMinus3)
+		if err != nil {
+			return nil, err
+		}
+		h.Add(h, two)
+
+		g := new(big.Int).Exp(h, two, p)
+		if g.Cmp(one) != 0 {
+			return g, nil
+		}
+	}
+}
+
+// GenerateSecret returns a random secret X in [1, P-2].
+func GenerateSecret(p *big.Int) (*big.Int, error) {
+	return randomExponent(p)
+}
+
+// RandomEphemeral returns a fresh per-message k drawn uniformly from
+// [1, P-2]. Reusing k for two messages reveals the ratio of their
+// plaintexts, so a new one must be drawn for every encryption.
+func RandomEphemeral(p *big.Int) (*big.Int, error) {
+	return randomExponent(p)
+}
+
+// RandomCoprimeEphemeral returns a k in [1, P-2] with gcd(k, P-1) = 1, as
+// required by ElGamal signatures, which invert k modulo P-1.
+func RandomCoprimeEphemeral(p *big.Int) (*big.Int, error) {
+	pMinus1 := new(big.Int).Sub(p, one)
+	gcd := new(big.Int)
+
+	for {
+		k, err := randomExponent(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if gcd.GCD(nil, nil, k, pMinus1).Cmp(one) == 0 {
+			return k, nil
+		}
+	}
+}
+
+// randomExponent returns a uniform value in [1, P-2].
+func randomExponent(p *big.Int) (*big.Int, error) {
+	if p.Cmp(big.NewInt(3)) <= 0 {
+		return nil, errors.New("P is too small")
+	}
+
+	pMinus2 := new(big.Int).Sub(p, two)
+
+	x, err := rand.Int(rand.Reader, pMinus2)
+	if err != nil {
+		return nil, err
+	}
+
+	return x.Add(x, one), nil
+}
+
+// GenerateElGamalKeyPair creates a key pair over a fresh safe prime of the
+// given size, with G generating the prime-order subgroup.
+func GenerateElGamalKeyPair(bits int) (*ElGamalKeyPair, error) {
+	p, err := GenerateSafePrime(bits)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := FindGenerator(p)
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := GenerateSecret(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateElGamalKeyPair(p, g, x)
+}
+
+// ValidateParameters checks a user-supplied group: P must be prime and G
+// must not be 0, 1 or P-1, nor lie in a small subgroup.
+func ValidateParameters(p, g *big.Int) error {
+	if p == nil || g == nil {
+		return errors.New("p and g must not be nil")
+	}
+	if p.Cmp(big.NewInt(3)) <= 0 || !p.ProbablyPrime(20) {
+		return errors.New("P must be a prime greater than 3")
+	}
+
+	pMinus1 := new(big.Int).Sub(p, one)
+	if g.Cmp(one) <= 0 || g.Cmp(pMinus1) >= 0 {
+		return errors.New("G must be between 2 and P-2")
+	}
+
+	if hasSmallOrder(p, g) {
+		return errors.New("G generates a small subgroup")
+	}
+
+	return nil
+}
+
+// hasSmallOrder reports whether the order of G mod P misses the largest
+// prime factor of P-1. P-1 is split by trial division into a smooth part
+// and a remainder. When the remainder is above 1, G has small order if
+// G^smooth = 1. When P-1 factors completely, G must not vanish under
+// (P-1)/L, where L is the largest prime factor.
+func hasSmallOrder(p, g *big.Int) bool {
+	pMinus1 := new(big.Int).Sub(p, one)
+	remainder := new(big.Int).Set(pMinus1)
+	smooth := big.NewInt(1)
+	largest := big.NewInt(1)
+
+	quotient, modulus := new(big.Int), new(big.Int)
+	for d := int64(2); d < smallFactorBound && remainder.Cmp(one) > 0; d++ {
+		divisor := big.NewInt(d)
+		for {
+			quotient.QuoRem(remainder, divisor, modulus)
+			if modulus.Sign() != 0 {
+				break
+			}
+			remainder.Set(quotient)
+			smooth.Mul(smooth, divisor)
+			largest.Set(divisor)
+		}
+	}
+
+	if remainder.Cmp(one) > 0 {
+		return new(big.Int).Exp(g, smooth, p).Cmp(one) == 0
+	}
+
+	exponent := new(big.Int).Div(pMinus1, largest)
+	return new(big.Int).Exp(g, exponent, p).Cmp(one) == 0
+}
+
+// isSafePrime reports whether P and (P-1)/2 are both prime.
+func isSafePrime(p *big.Int) bool {
+	if p.Cmp(big.NewInt(5)) < 0 || !p.ProbablyPrime(20) {
+		return false
+	}
+
+	q := new(big.Int).Rsh(p, 1)
+	return q.ProbablyPrime(20)
+}
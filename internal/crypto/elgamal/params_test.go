@@ -0,0 +1,108 @@
+package elgamal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGenerateSafePrime(t *testing.T) {
+	for _, bits := range []int{MinPrimeBits, 64, 256} {
+		p, err := GenerateSafePrime(bits)
+		if err != nil {
+			t.Fatalf("GenerateSafePrime(%d): %v", bits, err)
+		}
+		if p.BitLen() != bits {
+			t.Fatalf("GenerateSafePrime(%d) has %d bits", bits, p.BitLen())
+		}
+		if !isSafePrime(p) {
+			t.Fatalf("GenerateSafePrime(%d) = %s is not a safe prime", bits, p)
+		}
+	}
+
+	if _, err := GenerateSafePrime(MinPrimeBits - 1); err == nil {
+		t.Fatal("GenerateSafePrime accepted a size below MinPrimeBits")
+	}
+}
+
+func TestFindGenerator(t *testing.T) {
+	p, err := GenerateSafePrime(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := new(big.Int).Rsh(p, 1)
+
+	for i := 0; i < 20; i++ {
+		g, err := FindGenerator(p)
+		if err != nil {
+			t.Fatalf("FindGenerator: %v", err)
+		}
+		if g.Cmp(one) == 0 || new(big.Int).Exp(g, q, p).Cmp(one) != 0 {
+			t.Fatalf("g = %s does not generate the subgroup of order Q", g)
+		}
+		if err := ValidateParameters(p, g); err != nil {
+			t.Fatalf("ValidateParameters rejected a generated group: %v", err)
+		}
+	}
+
+	if _, err := FindGenerator(big.NewInt(13)); err == nil {
+		t.Fatal("FindGenerator accepted 13, which is not a safe prime")
+	}
+}
+
+func TestValidateParametersRejects(t *testing.T) {
+	// 101 is prime and 100 = 2^2 · 5^2. 2 generates the whole group, so
+	// 2^20 has order 5.
+	p := big.NewInt(101)
+	smallOrder := new(big.Int).Exp(two, big.NewInt(20), p)
+
+	tests := []struct {
+		name string
+		p, g *big.Int
+	}{
+		{"nil", nil, nil},
+		{"composite P", big.NewInt(1000001), two},
+		{"P too small", big.NewInt(3), two},
+		{"G = 0", p, big.NewInt(0)},
+		{"G = 1", p, one},
+		{"G = P-1", p, big.NewInt(100)},
+		{"G = P", p, p},
+		{"G of order 5", p, smallOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateParameters(tt.p, tt.g); err == nil {
+				t.Fatal("ValidateParameters succeeded")
+			}
+		})
+	}
+
+	if err := ValidateParameters(p, two); err != nil {
+		t.Fatalf("ValidateParameters(101, 2): %v", err)
+	}
+}
+
+func TestGenerateElGamalKeyPair(t *testing.T) {
+	keyPair, err := GenerateElGamalKeyPair(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	y := new(big.Int).Exp(&keyPair.G, &keyPair.X, &keyPair.P)
+	if y.Cmp(&keyPair.Y) != 0 {
+		t.Fatal("Y != G^X mod P")
+	}
+
+	message := []byte{0x42, 0x13}
+	a, b, err := Encrypt(keyPair, message, big.NewInt(12345))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := Decrypt(keyPair, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if new(big.Int).SetBytes(decrypted).Cmp(new(big.Int).SetBytes(message)) != 0 {
+		t.Fatalf("Decrypt = %x, want %x", decrypted, message)
+	}
+}
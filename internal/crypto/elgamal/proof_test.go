@@ -0,0 +1,129 @@
+package elgamal
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestPossessionProof(t *testing.T) {
+	keyPair, err := CreateElGamalKeyPairFromGroup("modp2048", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := CreateElGamalKeyPairFromGroup("modp2048", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof, err := keyPair.ProvePossession("alice")
+	if err != nil {
+		t.Fatalf("ProvePossession: %v", err)
+	}
+	if err := keyPair.VerifyPossession("alice", proof); err != nil {
+		t.Fatalf("VerifyPossession: %v", err)
+	}
+
+	flipped := bytes.Clone(proof)
+	flipped[len(flipped)-1] ^= 0x01
+
+	schnorr, err := keyPair.SignSchnorr([]byte("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		keyPair *ElGamalKeyPair
+		userID  string
+		proof   []byte
+	}{
+		{"other user", keyPair, "bob", proof},
+		{"other key", other, "alice", proof},
+		{"flipped bit", keyPair, "alice", flipped},
+		{"Schnorr signature of the user ID", keyPair, "alice", schnorr},
+		{"truncated", keyPair, "alice", proof[1:]},
+		{"no proof", keyPair, "alice", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.keyPair.VerifyPossession(tt.userID, tt.proof); err == nil {
+				t.Fatal("VerifyPossession succeeded")
+			}
+		})
+	}
+}
+
+func TestPossessionRejectsKeyOutOfRange(t *testing.T) {
+	keyPair, err := CreateElGamalKeyPairFromGroup("modp2048", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := keyPair.ProvePossession("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var forged ElGamalKeyPair
+	forged.P.Set(&keyPair.P)
+	forged.G.Set(&keyPair.G)
+	forged.Y.SetInt64(1)
+	if err := forged.VerifyPossession("alice", proof); err == nil {
+		t.Fatal("VerifyPossession accepted Y = 1")
+	}
+}
+
+func TestPossessionChecksGroup(t *testing.T) {
+	custom, err := GenerateElGamalKeyPair(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := custom.ProvePossession("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := custom.VerifyPossession("alice", proof); err != nil {
+		t.Fatalf("VerifyPossession over a generated group: %v", err)
+	}
+
+	modp2048, err := LookupGroup("modp2048")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modp3072, err := LookupGroup("modp3072")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pMinus1 := new(big.Int).Sub(modp2048.P, one)
+
+	tests := []struct {
+		name  string
+		p, g  *big.Int
+		group string
+	}{
+		{"composite P", big.NewInt(1000 * 1009), big.NewInt(2), ""},
+		{"G of order 2", modp2048.P, pMinus1, ""},
+		{"G of order 5", big.NewInt(101), big.NewInt(36), ""},
+		{"group name with other parameters", modp3072.P, modp3072.G, "modp2048"},
+		{"unknown group name", modp2048.P, modp2048.G, "modp1024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Build the key without the checks CreateElGamalKeyPair makes.
+			keyPair, err := createKeyPair(tt.p, tt.g, big.NewInt(3), tt.group)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, err := keyPair.ProvePossession("alice")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := keyPair.VerifyPossession("alice", proof); err == nil {
+				t.Fatal("VerifyPossession accepted the key")
+			}
+		})
+	}
+}
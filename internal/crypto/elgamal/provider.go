@@ -21,16 +21,69 @@ func NewElGamalProvider(keyStore crypto.KeyStore, userID string) *ElGamalProvide
 	}
 }
 
-func (p *ElGamalProvider) StorePublicKey(userID string, publicKeyData []byte) error {
+var _ crypto.Provider = (*ElGamalProvider)(nil)
+var _ crypto.PossessionProver = (*ElGamalProvider)(nil)
+
+// DefaultGroup is the built-in group used by GenerateKey.
+const DefaultGroup = "modp2048"
+
+// ParsePublicKey checks a peer's public key and stores it. Keys over a
+// built-in group were already checked against it while decoding; keys over
+// custom parameters are validated with ValidateParameters.
+func (p *ElGamalProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	publicKey, err := DecodePublicKey(string(publicKeyData))
+	if err != nil {
+		return err
+	}
+
+	if publicKey.Group == "" {
+		if err := ValidateParameters(&publicKey.P, &publicKey.G); err != nil {
+			return err
+		}
+	}
+
+	if publicKey.Y.Sign() <= 0 || publicKey.Y.Cmp(&publicKey.P) >= 0 {
+		return fmt.Errorf("Y must be between 1 and P-1")
+	}
+
 	return p.keyStore.StorePublicKey(userID, crypto.ElGamal, publicKeyData)
 }
 
+// GenerateKey creates a key pair over DefaultGroup.
+func (p *ElGamalProvider) GenerateKey() error {
+	return p.GenerateKeyPairForGroup(DefaultGroup)
+}
+
 func (p *ElGamalProvider) StoreKeyPair(primeP, generatorG, secretX big.Int) error {
 	keyPair, err := CreateElGamalKeyPair(&primeP, &generatorG, &secretX)
 	if err != nil {
 		return err
 	}
 
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *ElGamalProvider) GenerateKeyPair(bits int) error {
+	keyPair, err := GenerateElGamalKeyPair(bits)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+// GenerateKeyPairForGroup creates a key pair with a random secret over one
+// of the built-in groups listed by GroupNames.
+func (p *ElGamalProvider) GenerateKeyPairForGroup(groupName string) error {
+	keyPair, err := CreateElGamalKeyPairFromGroup(groupName, nil)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *ElGamalProvider) storeKeyPair(keyPair *ElGamalKeyPair) error {
 	p.keyPair = keyPair
 
 	privateKeyStr, publicKeyStr, err := keyPair.EncodeToString()
@@ -46,13 +99,27 @@ func (p *ElGamalProvider) StoreKeyPair(primeP, generatorG, secretX big.Int) erro
 	return p.keyStore.StorePublicKey(p.userID, crypto.ElGamal, []byte(publicKeyStr))
 }
 
-func (p *ElGamalProvider) Encrypt(message []byte, recipientID string, k big.Int) ([]byte, error) {
-	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.ElGamal)
+// Encrypt encrypts the message for the recipient with a fresh random k.
+func (p *ElGamalProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKey, err := p.recipientKey(recipientID)
 	if err != nil {
 		return nil, err
 	}
 
-	recipientKey, err := DecodePublicKey(string(recipientKeyBytes))
+	k, err := RandomEphemeral(&recipientKey.P)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.encrypt(recipientKey, message, k)
+}
+
+// EncryptDeterministic is a teaching mode that encrypts with a caller-chosen
+// k. The same k and message always give the same ciphertext, and reusing k
+// across messages lets anyone relate their plaintexts; use Encrypt instead
+// for real messages.
+func (p *ElGamalProvider) EncryptDeterministic(message []byte, recipientID string, k big.Int) ([]byte, error) {
+	recipientKey, err := p.recipientKey(recipientID)
 	if err != nil {
 		return nil, err
 	}
@@ -61,32 +128,36 @@ func (p *ElGamalProvider) Encrypt(message []byte, recipientID string, k big.Int)
 		return nil, fmt.Errorf("k must be greater than 0 and less than P")
 	}
 
-	a, b, err := Encrypt(recipientKey, message, &k)
+	return p.encrypt(recipientKey, message, &k)
+}
+
+func (p *ElGamalProvider) encrypt(recipientKey *ElGamalKeyPair, message []byte, k *big.Int) ([]byte, error) {
+	a, b, err := Encrypt(recipientKey, message, k)
 	if err != nil {
 		return nil, err
 	}
 
-	return append(a.Bytes(), b.Bytes()...), nil
+	return EncodeCiphertext(a, b, &recipientKey.P)
 }
 
-func (p *ElGamalProvider) Decrypt(ciphertext []byte) ([]byte, error) {
-	if p.keyPair == nil {
-		privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.ElGamal)
-		if err != nil {
-			return nil, fmt.Errorf("private key not available: %v", err)
-		}
+func (p *ElGamalProvider) recipientKey(recipientID string) (*ElGamalKeyPair, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.ElGamal)
+	if err != nil {
+		return nil, err
+	}
 
-		keyPair, err := DecodePrivateKey(string(privateKeyBytes))
-		if err != nil {
-			return nil, err
-		}
+	return DecodePublicKey(string(recipientKeyBytes))
+}
 
-		p.keyPair = keyPair
+func (p *ElGamalProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
 	}
 
-	ciphertextLength := len(ciphertext) / 2
-	a := new(big.Int).SetBytes(ciphertext[:ciphertextLength])
-	b := new(big.Int).SetBytes(ciphertext[ciphertextLength:])
+	a, b, err := DecodeCiphertext(ciphertext)
+	if err != nil {
+		return nil, err
+	}
 
 	m, err := Decrypt(p.keyPair, a, b)
 	if err != nil {
@@ -96,21 +167,76 @@ func (p *ElGamalProvider) Decrypt(ciphertext []byte) ([]byte, error) {
 	return m, nil
 }
 
-func (p *ElGamalProvider) GetPublicKey() ([]byte, error) {
-	if p.keyPair == nil {
-		privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.ElGamal)
-		if err != nil {
-			return nil, fmt.Errorf("key pair not available: %v", err)
-		}
-
-		keyPair, err := DecodePrivateKey(string(privateKeyBytes))
-		if err != nil {
-			return nil, err
-		}
-
-		p.keyPair = keyPair
+func (p *ElGamalProvider) PublicKey() ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
 	}
 
 	_, publicKeyStr, err := p.keyPair.EncodeToString()
 	return []byte(publicKeyStr), err
 }
+
+// ProvePossession proves to the server that the user holds the private key
+// of the public key being registered.
+func (p *ElGamalProvider) ProvePossession(userID string) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	return p.keyPair.ProvePossession(userID)
+}
+
+// Sign signs the message with the user's private key using the given
+// signature algorithm, either crypto.ElGamalSignature or crypto.ElGamalSchnorr.
+func (p *ElGamalProvider) Sign(message []byte, scheme crypto.Algorithm) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	switch scheme {
+	case crypto.ElGamalSignature:
+		return p.keyPair.SignElGamal(message)
+	case crypto.ElGamalSchnorr:
+		return p.keyPair.SignSchnorr(message)
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", scheme)
+	}
+}
+
+// Verify checks the signature against the ElGamal public key stored for
+// signerID.
+func (p *ElGamalProvider) Verify(message, signature []byte, signerID string, scheme crypto.Algorithm) error {
+	signerKey, err := p.recipientKey(signerID)
+	if err != nil {
+		return err
+	}
+
+	switch scheme {
+	case crypto.ElGamalSignature:
+		return signerKey.VerifyElGamal(message, signature)
+	case crypto.ElGamalSchnorr:
+		return signerKey.VerifySchnorr(message, signature)
+	default:
+		return fmt.Errorf("unsupported signature algorithm: %s", scheme)
+	}
+}
+
+// loadKeyPair decodes the user's private key from the key store on first use.
+func (p *ElGamalProvider) loadKeyPair() error {
+	if p.keyPair != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.ElGamal)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	keyPair, err := DecodePrivateKey(string(privateKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	p.keyPair = keyPair
+	return nil
+}
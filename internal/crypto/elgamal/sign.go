@@ -0,0 +1,181 @@
+package elgamal
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+var errVerification = errors.New("verification error")
+
+// SignElGamal signs the SHA-256 hash of message with the original ElGamal
+// signature scheme. With H = hash mod P-1 and a fresh k coprime to P-1:
+//
+//	r = g^k mod P,  s = (H - x·r)·k⁻¹ mod P-1
+//
+// k must never be reused: two signatures with the same k reveal x.
+func (kp *ElGamalKeyPair) SignElGamal(message []byte) ([]byte, error) {
+	if err := kp.checkSigningKey(); err != nil {
+		return nil, err
+	}
+
+	pMinus1 := new(big.Int).Sub(&kp.P, one)
+	h := hashToExponent(message, pMinus1)
+
+	for {
+		k, err := RandomCoprimeEphemeral(&kp.P)
+		if err != nil {
+			return nil, err
+		}
+
+		r := new(big.Int).Exp(&kp.G, k, &kp.P)
+
+		s := new(big.Int).Mul(&kp.X, r)
+		s.Sub(h, s)
+		s.Mul(s, new(big.Int).ModInverse(k, pMinus1))
+		s.Mod(s, pMinus1)
+
+		// s = 0 would make the signature independent of k; draw again.
+		if s.Sign() == 0 {
+			continue
+		}
+
+		return kp.encodeSignature(r, s), nil
+	}
+}
+
+// VerifyElGamal checks a signature produced by SignElGamal:
+// 0 < r < P, 0 < s < P-1 and g^H = y^r · r^s mod P.
+func (kp *ElGamalKeyPair) VerifyElGamal(message, signature []byte) error {
+	r, s, err := kp.decodeSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	pMinus1 := new(big.Int).Sub(&kp.P, one)
+	if s.Sign() <= 0 || s.Cmp(pMinus1) >= 0 {
+		return errVerification
+	}
+
+	left := new(big.Int).Exp(&kp.G, hashToExponent(message, pMinus1), &kp.P)
+
+	right := new(big.Int).Exp(&kp.Y, r, &kp.P)
+	right.Mul(right, new(big.Int).Exp(r, s, &kp.P))
+	right.Mod(right, &kp.P)
+
+	if left.Cmp(right) != 0 {
+		return errVerification
+	}
+	return nil
+}
+
+// SignSchnorr signs message with a Schnorr-style scheme over the same group.
+// With a fresh k:
+//
+//	r = g^k mod P,  e = SHA-256(r || message) mod P-1,  s = k + x·e mod P-1
+//
+// Unlike SignElGamal it needs no inverse of k, and the hash binds r to the
+// message. Exponents are reduced mod P-1, which the order of g divides.
+func (kp *ElGamalKeyPair) SignSchnorr(message []byte) ([]byte, error) {
+	if err := kp.checkSigningKey(); err != nil {
+		return nil, err
+	}
+
+	k, err := RandomEphemeral(&kp.P)
+	if err != nil {
+		return nil, err
+	}
+
+	pMinus1 := new(big.Int).Sub(&kp.P, one)
+	r := new(big.Int).Exp(&kp.G, k, &kp.P)
+	e := kp.schnorrChallenge(r, message)
+
+	s := new(big.Int).Mul(&kp.X, e)
+	s.Add(s, k)
+	s.Mod(s, pMinus1)
+
+	return kp.encodeSignature(r, s), nil
+}
+
+// VerifySchnorr checks a signature produced by SignSchnorr:
+// 0 < r < P, 0 ≤ s < P-1 and g^s = r · y^e mod P.
+func (kp *ElGamalKeyPair) VerifySchnorr(message, signature []byte) error {
+	r, s, err := kp.decodeSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	pMinus1 := new(big.Int).Sub(&kp.P, one)
+	if s.Cmp(pMinus1) >= 0 {
+		return errVerification
+	}
+
+	left := new(big.Int).Exp(&kp.G, s, &kp.P)
+
+	right := new(big.Int).Exp(&kp.Y, kp.schnorrChallenge(r, message), &kp.P)
+	right.Mul(right, r)
+	right.Mod(right, &kp.P)
+
+	if left.Cmp(right) != 0 {
+		return errVerification
+	}
+	return nil
+}
+
+// schnorrChallenge returns e = SHA-256(r || message) mod P-1, with r padded
+// to the length of P.
+func (kp *ElGamalKeyPair) schnorrChallenge(r *big.Int, message []byte) *big.Int {
+	hash := sha256.New()
+	hash.Write(r.FillBytes(make([]byte, kp.size())))
+	hash.Write(message)
+
+	e := new(big.Int).SetBytes(hash.Sum(nil))
+	return e.Mod(e, new(big.Int).Sub(&kp.P, one))
+}
+
+func hashToExponent(message []byte, pMinus1 *big.Int) *big.Int {
+	digest := sha256.Sum256(message)
+	h := new(big.Int).SetBytes(digest[:])
+	return h.Mod(h, pMinus1)
+}
+
+func (kp *ElGamalKeyPair) checkSigningKey() error {
+	if kp.X.Sign() == 0 {
+		return errors.New("private key required to sign")
+	}
+	if kp.P.Sign() == 0 {
+		return errors.New("private key has no group parameters")
+	}
+	return nil
+}
+
+// size returns the length of P in bytes.
+func (kp *ElGamalKeyPair) size() int {
+	return (kp.P.BitLen() + 7) / 8
+}
+
+// encodeSignature writes r || s, each padded to the length of P.
+func (kp *ElGamalKeyPair) encodeSignature(r, s *big.Int) []byte {
+	size := kp.size()
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	s.FillBytes(signature[size:])
+	return signature
+}
+
+// decodeSignature splits r || s and checks 0 < r < P.
+func (kp *ElGamalKeyPair) decodeSignature(signature []byte) (*big.Int, *big.Int, error) {
+	size := kp.size()
+	if len(signature) != 2*size {
+		return nil, nil, errVerification
+	}
+
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+
+	if r.Sign() <= 0 || r.Cmp(&kp.P) >= 0 {
+		return nil, nil, errVerification
+	}
+
+	return r, s, nil
+}
@@ -0,0 +1,91 @@
+package elgamal
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	keyPair, err := GenerateElGamalKeyPair(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateElGamalKeyPair(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemes := []struct {
+		name   string
+		sign   func(*ElGamalKeyPair, []byte) ([]byte, error)
+		verify func(*ElGamalKeyPair, []byte, []byte) error
+	}{
+		{"ElGamal", (*ElGamalKeyPair).SignElGamal, (*ElGamalKeyPair).VerifyElGamal},
+		{"Schnorr", (*ElGamalKeyPair).SignSchnorr, (*ElGamalKeyPair).VerifySchnorr},
+	}
+
+	for _, scheme := range schemes {
+		t.Run(scheme.name, func(t *testing.T) {
+			message := []byte("pay bob 10 coins")
+
+			for i := 0; i < 50; i++ {
+				signature, err := scheme.sign(keyPair, message)
+				if err != nil {
+					t.Fatalf("sign: %v", err)
+				}
+				if err := scheme.verify(keyPair, message, signature); err != nil {
+					t.Fatalf("verify: %v", err)
+				}
+			}
+
+			signature, err := scheme.sign(keyPair, message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			flipped := bytes.Clone(signature)
+			flipped[len(flipped)-1] ^= 0x01
+			zeroR := bytes.Clone(signature)
+			clear(zeroR[:len(zeroR)/2])
+
+			for _, tt := range []struct {
+				name      string
+				keyPair   *ElGamalKeyPair
+				message   []byte
+				signature []byte
+			}{
+				{"other message", keyPair, []byte("pay bob 99 coins"), signature},
+				{"flipped bit", keyPair, message, flipped},
+				{"r = 0", keyPair, message, zeroR},
+				{"other key", other, message, signature},
+				{"truncated", keyPair, message, signature[1:]},
+				{"empty", keyPair, message, nil},
+			} {
+				if err := scheme.verify(tt.keyPair, tt.message, tt.signature); err == nil {
+					t.Errorf("%s: verify succeeded", tt.name)
+				}
+			}
+		})
+	}
+}
+
+func TestSignRequiresPrivateKey(t *testing.T) {
+	keyPair, err := GenerateElGamalKeyPair(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, publicKey, err := keyPair.EncodeToString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := DecodePublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := public.SignElGamal([]byte("m")); err == nil {
+		t.Error("SignElGamal succeeded without a private key")
+	}
+	if _, err := public.SignSchnorr([]byte("m")); err == nil {
+		t.Error("SignSchnorr succeeded without a private key")
+	}
+}
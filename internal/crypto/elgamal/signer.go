@@ -0,0 +1,54 @@
+package elgamal
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// SignatureAlgorithms lists the signature schemes over ElGamal keys, the
+// preferred one first.
+var SignatureAlgorithms = []crypto.Algorithm{crypto.ElGamalSchnorr, crypto.ElGamalSignature}
+
+// ElGamalSigner exposes one of ElGamalProvider's signature schemes as a
+// crypto.Signer. It shares the provider's key, which stays registered under
+// crypto.ElGamal.
+type ElGamalSigner struct {
+	provider *ElGamalProvider
+	scheme   crypto.Algorithm
+}
+
+func NewElGamalSigner(provider *ElGamalProvider, scheme crypto.Algorithm) (*ElGamalSigner, error) {
+	if !slices.Contains(SignatureAlgorithms, scheme) {
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", scheme)
+	}
+
+	return &ElGamalSigner{provider: provider, scheme: scheme}, nil
+}
+
+var _ crypto.Signer = (*ElGamalSigner)(nil)
+
+func (s *ElGamalSigner) GenerateKey() error {
+	return s.provider.GenerateKey()
+}
+
+func (s *ElGamalSigner) PublicKey() ([]byte, error) {
+	return s.provider.PublicKey()
+}
+
+func (s *ElGamalSigner) ParsePublicKey(userID string, publicKeyData []byte) error {
+	return s.provider.ParsePublicKey(userID, publicKeyData)
+}
+
+func (s *ElGamalSigner) ProvePossession(userID string) ([]byte, error) {
+	return s.provider.ProvePossession(userID)
+}
+
+func (s *ElGamalSigner) Sign(message []byte) ([]byte, error) {
+	return s.provider.Sign(message, s.scheme)
+}
+
+func (s *ElGamalSigner) Verify(message, signature []byte, signerID string) error {
+	return s.provider.Verify(message, signature, signerID, s.scheme)
+}
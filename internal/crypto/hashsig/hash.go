@@ -0,0 +1,84 @@
+package hashsig
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// N is the length in bytes of every hash value, key and seed.
+const N = sha256.Size
+
+// Domain separators, prepended to every hash input so the functions below
+// can never produce the same output for different purposes.
+const (
+	domainChain byte = iota
+	domainNode
+	domainMessage
+	domainPRF
+	domainLeaf
+)
+
+// Address kinds.
+const (
+	addrLamport uint32 = iota
+	addrOTS
+	addrLeaf
+	addrTree
+)
+
+// address says where in a key a hash is computed, so no two hash calls
+// share an input. XMSS (RFC 8391) uses the same idea.
+type address struct {
+	kind       uint32
+	leaf       uint32
+	chain      uint32
+	step       uint32
+	level      uint32
+	index      uint32
+	keyAndMask uint32
+}
+
+func (a address) bytes() []byte {
+	b := make([]byte, 0, 28)
+	for _, v := range []uint32{a.kind, a.leaf, a.chain, a.step, a.level, a.index, a.keyAndMask} {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+// hash returns SHA-256(domain || inputs...).
+func hash(domain byte, inputs ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{domain})
+	for _, input := range inputs {
+		h.Write(input)
+	}
+	return h.Sum(nil)
+}
+
+// prf derives a pseudorandom value from a seed and an address.
+func prf(seed []byte, addr address) []byte {
+	return hash(domainPRF, seed, addr.bytes())
+}
+
+// chainStep is one step of a WOTS+ chain: the input is masked with a
+// bitmask and hashed under a key, both derived from the public seed.
+func chainStep(pubSeed []byte, addr address, x []byte) []byte {
+	addr.keyAndMask = 0
+	key := prf(pubSeed, addr)
+	addr.keyAndMask = 1
+	mask := prf(pubSeed, addr)
+
+	masked := make([]byte, N)
+	for i := range masked {
+		masked[i] = x[i] ^ mask[i]
+	}
+
+	return hash(domainChain, key, masked)
+}
+
+// node hashes two children into their parent in the Merkle tree.
+func node(pubSeed []byte, level, index uint32, left, right []byte) []byte {
+	addr := address{kind: addrTree, level: level, index: index}
+	return hash(domainNode, pubSeed, addr.bytes(), left, right)
+}
@@ -0,0 +1,100 @@
+package hashsig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// Lamport signs the 256 bits of SHA-256(message). The private key is two
+// secret values per bit, derived from a seed; the public key is their
+// hashes. A signature reveals one of the two values for each bit, so a
+// second signature with the same key would let anyone mix the two.
+const (
+	lamportBits          = 8 * sha256.Size
+	LamportPublicKeySize = 2 * lamportBits * N
+	LamportSignatureSize = lamportBits * N
+)
+
+var errVerification = errors.New("verification error")
+
+// lamportSecret returns the secret value revealed when bit i of the digest
+// equals b.
+func lamportSecret(seed []byte, i, b int) []byte {
+	return prf(seed, address{kind: addrLamport, chain: uint32(i), step: uint32(b)})
+}
+
+// LamportPublicKey derives the public key H(sk[i][0]) || H(sk[i][1]) || …
+// from a private seed.
+func LamportPublicKey(seed []byte) []byte {
+	publicKey := make([]byte, 0, LamportPublicKeySize)
+	for i := 0; i < lamportBits; i++ {
+		for b := 0; b < 2; b++ {
+			publicKey = append(publicKey, hash(domainChain, lamportSecret(seed, i, b))...)
+		}
+	}
+	return publicKey
+}
+
+// LamportSign reveals sk[i][bit i] for every bit of SHA-256(message). The
+// caller must make sure the seed never signs twice.
+func LamportSign(seed, message []byte) []byte {
+	digest := sha256.Sum256(message)
+
+	signature := make([]byte, 0, LamportSignatureSize)
+	for i := 0; i < lamportBits; i++ {
+		signature = append(signature, lamportSecret(seed, i, digestBit(digest[:], i))...)
+	}
+	return signature
+}
+
+// LamportVerify hashes each revealed value and compares it with the public
+// key entry for the corresponding digest bit.
+func LamportVerify(publicKey, message, signature []byte) error {
+	if len(publicKey) != LamportPublicKeySize || len(signature) != LamportSignatureSize {
+		return errVerification
+	}
+
+	digest := sha256.Sum256(message)
+	for i := 0; i < lamportBits; i++ {
+		revealed := signature[i*N : (i+1)*N]
+		offset := (2*i + digestBit(digest[:], i)) * N
+
+		if !bytes.Equal(hash(domainChain, revealed), publicKey[offset:offset+N]) {
+			return errVerification
+		}
+	}
+
+	return nil
+}
+
+// digestBit returns bit i of the digest, most significant bit first.
+func digestBit(digest []byte, i int) int {
+	return int(digest[i/8]>>(7-i%8)) & 1
+}
+
+// EncodeLamportPrivateKey encodes a seed as "Used,Seed" with a base64 seed
+// and Used either 0 or 1.
+func EncodeLamportPrivateKey(seed []byte, used bool) string {
+	state := "0"
+	if used {
+		state = "1"
+	}
+	return state + "," + base64.StdEncoding.EncodeToString(seed)
+}
+
+func DecodeLamportPrivateKey(data string) (seed []byte, used bool, err error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 2 || (fields[0] != "0" && fields[0] != "1") {
+		return nil, false, errors.New("invalid private key format: expected Used,Seed")
+	}
+
+	values, err := decodeHashes(fields[1:], "Seed")
+	if err != nil {
+		return nil, false, errors.New("invalid private key format: " + err.Error())
+	}
+
+	return values[0], fields[0] == "1", nil
+}
@@ -0,0 +1,382 @@
+package hashsig
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// XMSSProvider signs with an XMSS-style key. The key store is the only
+// record of which leaves are used: every signature reads the key from it,
+// and the advanced key is stored back before the signature is returned.
+type XMSSProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+
+	// levels caches the tree of the key with seed treeSeed, which only
+	// depends on the seeds and is slow to compute.
+	levels   [][][]byte
+	treeSeed []byte
+
+	mutex sync.Mutex
+}
+
+func NewXMSSProvider(keyStore crypto.KeyStore, userID string) *XMSSProvider {
+	return &XMSSProvider{
+		keyStore: keyStore,
+		userID:   userID,
+	}
+}
+
+var _ crypto.Signer = (*XMSSProvider)(nil)
+
+// GenerateKey creates a key of DefaultHeight.
+func (p *XMSSProvider) GenerateKey() error {
+	return p.GenerateKeyWithHeight(DefaultHeight)
+}
+
+func (p *XMSSProvider) GenerateKeyWithHeight(height int) error {
+	key, err := GenerateXMSSKey(height)
+	if err != nil {
+		return err
+	}
+
+	privateKeyStr, publicKeyStr, err := key.EncodeToString()
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	err = p.keyStore.StorePrivateKey(crypto.XMSS, []byte(privateKeyStr))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.XMSS, []byte(publicKeyStr))
+}
+
+func (p *XMSSProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodeXMSSPublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.XMSS, publicKeyData)
+}
+
+func (p *XMSSProvider) PublicKey() ([]byte, error) {
+	key, err := p.loadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	_, publicKeyStr, err := key.EncodeToString()
+	return []byte(publicKeyStr), err
+}
+
+// Remaining returns how many more signatures the user's key can make.
+func (p *XMSSProvider) Remaining() (uint32, error) {
+	key, err := p.loadKey()
+	if err != nil {
+		return 0, err
+	}
+
+	return key.Remaining(), nil
+}
+
+// Sign signs with the next unused leaf and marks it used in the key store.
+// If the key store cannot be updated the signature is discarded, since
+// handing it out would allow the leaf to be used again.
+func (p *XMSSProvider) Sign(message []byte) ([]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	key, err := p.loadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := key.sign(p.tree(key), message)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Next++
+	privateKeyStr, _, err := key.EncodeToString()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.keyStore.StorePrivateKey(crypto.XMSS, []byte(privateKeyStr))
+	if err != nil {
+		return nil, fmt.Errorf("saving key state: %v", err)
+	}
+
+	return signature, nil
+}
+
+func (p *XMSSProvider) Verify(message, signature []byte, signerID string) error {
+	signerKeyBytes, err := p.keyStore.GetPublicKey(signerID, crypto.XMSS)
+	if err != nil {
+		return err
+	}
+
+	signerKey, err := DecodeXMSSPublicKey(string(signerKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	return signerKey.Verify(message, signature)
+}
+
+// tree returns the cached tree for key, computing it if the key changed.
+func (p *XMSSProvider) tree(key *XMSSKey) [][][]byte {
+	if p.levels == nil || !bytes.Equal(p.treeSeed, key.SKSeed) {
+		p.levels = key.tree()
+		p.treeSeed = key.SKSeed
+	}
+	return p.levels
+}
+
+// loadKey decodes the user's private key, with its current state, from the
+// key store. Unlike other providers it is never cached.
+func (p *XMSSProvider) loadKey() (*XMSSKey, error) {
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.XMSS)
+	if err != nil {
+		return nil, fmt.Errorf("private key not available: %v", err)
+	}
+
+	return DecodeXMSSPrivateKey(string(privateKeyBytes))
+}
+
+// LamportProvider signs with a Lamport one-time key. Like XMSSProvider it
+// keeps the key's state, used or not, only in the key store.
+type LamportProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+	mutex    sync.Mutex
+}
+
+func NewLamportProvider(keyStore crypto.KeyStore, userID string) *LamportProvider {
+	return &LamportProvider{
+		keyStore: keyStore,
+		userID:   userID,
+	}
+}
+
+var _ crypto.Signer = (*LamportProvider)(nil)
+
+func (p *LamportProvider) GenerateKey() error {
+	seed := make([]byte, N)
+	if _, err := rand.Read(seed); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	err := p.keyStore.StorePrivateKey(crypto.Lamport, []byte(EncodeLamportPrivateKey(seed, false)))
+	if err != nil {
+		return err
+	}
+
+	publicKey := base64.StdEncoding.EncodeToString(LamportPublicKey(seed))
+	return p.keyStore.StorePublicKey(p.userID, crypto.Lamport, []byte(publicKey))
+}
+
+func (p *LamportProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := decodeLamportPublicKey(publicKeyData); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.Lamport, publicKeyData)
+}
+
+func (p *LamportProvider) PublicKey() ([]byte, error) {
+	seed, _, err := p.loadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(LamportPublicKey(seed))), nil
+}
+
+// Sign signs once. The key is marked used in the key store before the
+// signature is returned, and later calls fail.
+func (p *LamportProvider) Sign(message []byte) ([]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	seed, used, err := p.loadKey()
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		return nil, errors.New("one-time key has already been used")
+	}
+
+	signature := LamportSign(seed, message)
+
+	err = p.keyStore.StorePrivateKey(crypto.Lamport, []byte(EncodeLamportPrivateKey(seed, true)))
+	if err != nil {
+		return nil, fmt.Errorf("saving key state: %v", err)
+	}
+
+	return signature, nil
+}
+
+func (p *LamportProvider) Verify(message, signature []byte, signerID string) error {
+	signerKeyBytes, err := p.keyStore.GetPublicKey(signerID, crypto.Lamport)
+	if err != nil {
+		return err
+	}
+
+	signerKey, err := decodeLamportPublicKey(signerKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	return LamportVerify(signerKey, message, signature)
+}
+
+func (p *LamportProvider) loadKey() ([]byte, bool, error) {
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.Lamport)
+	if err != nil {
+		return nil, false, fmt.Errorf("private key not available: %v", err)
+	}
+
+	return DecodeLamportPrivateKey(string(privateKeyBytes))
+}
+
+func decodeLamportPublicKey(data []byte) ([]byte, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+	if len(publicKey) != LamportPublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes", LamportPublicKeySize)
+	}
+
+	return publicKey, nil
+}
+
+// WOTSProvider signs with a standalone WOTS+ one-time key. Like
+// LamportProvider it keeps the key's state only in the key store.
+type WOTSProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+	mutex    sync.Mutex
+}
+
+func NewWOTSProvider(keyStore crypto.KeyStore, userID string) *WOTSProvider {
+	return &WOTSProvider{
+		keyStore: keyStore,
+		userID:   userID,
+	}
+}
+
+var _ crypto.Signer = (*WOTSProvider)(nil)
+
+func (p *WOTSProvider) GenerateKey() error {
+	skSeed := make([]byte, N)
+	if _, err := rand.Read(skSeed); err != nil {
+		return err
+	}
+	pubSeed := make([]byte, N)
+	if _, err := rand.Read(pubSeed); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	err := p.keyStore.StorePrivateKey(crypto.WOTS, []byte(EncodeWOTSPrivateKey(skSeed, pubSeed, false)))
+	if err != nil {
+		return err
+	}
+
+	publicKey := base64.StdEncoding.EncodeToString(WOTSPublicKey(skSeed, pubSeed))
+	return p.keyStore.StorePublicKey(p.userID, crypto.WOTS, []byte(publicKey))
+}
+
+func (p *WOTSProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := decodeWOTSPublicKey(publicKeyData); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.WOTS, publicKeyData)
+}
+
+func (p *WOTSProvider) PublicKey() ([]byte, error) {
+	skSeed, pubSeed, _, err := p.loadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(WOTSPublicKey(skSeed, pubSeed))), nil
+}
+
+// Sign signs once. The key is marked used in the key store before the
+// signature is returned, and later calls fail.
+func (p *WOTSProvider) Sign(message []byte) ([]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	skSeed, pubSeed, used, err := p.loadKey()
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		return nil, errors.New("one-time key has already been used")
+	}
+
+	signature := WOTSSign(skSeed, pubSeed, message)
+
+	err = p.keyStore.StorePrivateKey(crypto.WOTS, []byte(EncodeWOTSPrivateKey(skSeed, pubSeed, true)))
+	if err != nil {
+		return nil, fmt.Errorf("saving key state: %v", err)
+	}
+
+	return signature, nil
+}
+
+func (p *WOTSProvider) Verify(message, signature []byte, signerID string) error {
+	signerKeyBytes, err := p.keyStore.GetPublicKey(signerID, crypto.WOTS)
+	if err != nil {
+		return err
+	}
+
+	signerKey, err := decodeWOTSPublicKey(signerKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	return WOTSVerify(signerKey, message, signature)
+}
+
+func (p *WOTSProvider) loadKey() ([]byte, []byte, bool, error) {
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.WOTS)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("private key not available: %v", err)
+	}
+
+	return DecodeWOTSPrivateKey(string(privateKeyBytes))
+}
+
+func decodeWOTSPublicKey(data []byte) ([]byte, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+	if len(publicKey) != WOTSPublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes", WOTSPublicKeySize)
+	}
+
+	return publicKey, nil
+}
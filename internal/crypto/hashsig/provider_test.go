@@ -0,0 +1,247 @@
+package hashsig
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+// reload copies alice's keys into a new key store, as if the client had
+// been restarted from a saved key file.
+func reload(t *testing.T, ks crypto.KeyStore, algorithm crypto.Algorithm) *keystore.ClientKeyStore {
+	t.Helper()
+
+	reloaded := keystore.NewClientKeyStore("alice")
+
+	privateKey, err := ks.GetPrivateKey(algorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.StorePrivateKey(algorithm, privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := ks.GetPublicKey("alice", algorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.StorePublicKey("alice", algorithm, publicKey); err != nil {
+		t.Fatal(err)
+	}
+	return reloaded
+}
+
+// readOnlyKeyStore fails to store private keys.
+type readOnlyKeyStore struct {
+	crypto.KeyStore
+}
+
+func (readOnlyKeyStore) StorePrivateKey(crypto.Algorithm, []byte) error {
+	return errors.New("read-only key store")
+}
+
+func TestLamportProvider(t *testing.T) {
+	ks := keystore.NewClientKeyStore("alice")
+	alice := NewLamportProvider(ks, "alice")
+	if err := alice.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := alice.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob := NewLamportProvider(keystore.NewClientKeyStore("bob"), "bob")
+	if err := bob.ParsePublicKey("alice", publicKey); err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+
+	message := []byte("pay bob 10 coins")
+	signature, err := alice.Sign(message)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := bob.Verify(message, signature, "alice"); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	flipped := bytes.Clone(signature)
+	flipped[0] ^= 0x01
+	if err := bob.Verify([]byte("pay bob 99 coins"), signature, "alice"); err == nil {
+		t.Error("Verify accepted another message")
+	}
+	if err := bob.Verify(message, flipped, "alice"); err == nil {
+		t.Error("Verify accepted a flipped bit")
+	}
+	if err := bob.Verify(message, signature[1:], "alice"); err == nil {
+		t.Error("Verify accepted a truncated signature")
+	}
+
+	if _, err := alice.Sign(message); err == nil {
+		t.Error("Sign reused the one-time key")
+	}
+	if _, err := NewLamportProvider(ks, "alice").Sign(message); err == nil {
+		t.Error("a new provider over the same key store reused the key")
+	}
+	if _, err := NewLamportProvider(reload(t, ks, crypto.Lamport), "alice").Sign(message); err == nil {
+		t.Error("the key was reused after a reload")
+	}
+}
+
+func TestLamportSignFailsWhenStateCannotBeSaved(t *testing.T) {
+	ks := keystore.NewClientKeyStore("alice")
+	if err := NewLamportProvider(ks, "alice").GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+
+	if signature, err := NewLamportProvider(readOnlyKeyStore{ks}, "alice").Sign([]byte("m")); err == nil || signature != nil {
+		t.Fatal("Sign returned a signature it could not record")
+	}
+}
+
+func TestXMSSProvider(t *testing.T) {
+	ks := keystore.NewClientKeyStore("alice")
+	alice := NewXMSSProvider(ks, "alice")
+	if err := alice.GenerateKeyWithHeight(MinHeight); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := alice.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob := NewXMSSProvider(keystore.NewClientKeyStore("bob"), "bob")
+	if err := bob.ParsePublicKey("alice", publicKey); err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+
+	message := []byte("pay bob 10 coins")
+	leaves := 1 << MinHeight
+
+	// Alternate between the provider, a new one over the same key store
+	// and one over a reloaded copy: every signature must use a new leaf.
+	for i := 0; i < leaves; i++ {
+		var signer *XMSSProvider
+		switch i % 3 {
+		case 0:
+			signer = alice
+		case 1:
+			signer = NewXMSSProvider(ks, "alice")
+		case 2:
+			ks = reload(t, ks, crypto.XMSS)
+			alice = NewXMSSProvider(ks, "alice")
+			signer = alice
+		}
+
+		signature, err := signer.Sign(message)
+		if err != nil {
+			t.Fatalf("signature %d: %v", i, err)
+		}
+		if leaf := binary.BigEndian.Uint32(signature); leaf != uint32(i) {
+			t.Fatalf("signature %d used leaf %d", i, leaf)
+		}
+		if err := bob.Verify(message, signature, "alice"); err != nil {
+			t.Fatalf("Verify of signature %d: %v", i, err)
+		}
+
+		remaining, err := NewXMSSProvider(ks, "alice").Remaining()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remaining != uint32(leaves-i-1) {
+			t.Fatalf("Remaining = %d after %d signatures", remaining, i+1)
+		}
+	}
+
+	if _, err := alice.Sign(message); err == nil {
+		t.Fatal("Sign succeeded with every leaf used")
+	}
+}
+
+func TestXMSSSignFailsWhenStateCannotBeSaved(t *testing.T) {
+	ks := keystore.NewClientKeyStore("alice")
+	if err := NewXMSSProvider(ks, "alice").GenerateKeyWithHeight(MinHeight); err != nil {
+		t.Fatal(err)
+	}
+
+	if signature, err := NewXMSSProvider(readOnlyKeyStore{ks}, "alice").Sign([]byte("m")); err == nil || signature != nil {
+		t.Fatal("Sign returned a signature it could not record")
+	}
+
+	remaining, err := NewXMSSProvider(ks, "alice").Remaining()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 1<<MinHeight {
+		t.Fatalf("Remaining = %d, want %d", remaining, 1<<MinHeight)
+	}
+}
+
+func TestWOTSProvider(t *testing.T) {
+	ks := keystore.NewClientKeyStore("alice")
+	alice := NewWOTSProvider(ks, "alice")
+	if err := alice.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := alice.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob := NewWOTSProvider(keystore.NewClientKeyStore("bob"), "bob")
+	if err := bob.ParsePublicKey("alice", publicKey); err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+	if err := bob.ParsePublicKey("carol", publicKey[1:]); err == nil {
+		t.Error("ParsePublicKey accepted a truncated key")
+	}
+
+	message := []byte("pay bob 10 coins")
+	signature, err := alice.Sign(message)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := bob.Verify(message, signature, "alice"); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	flipped := bytes.Clone(signature)
+	flipped[0] ^= 0x01
+	if err := bob.Verify([]byte("pay bob 99 coins"), signature, "alice"); err == nil {
+		t.Error("Verify accepted another message")
+	}
+	if err := bob.Verify(message, flipped, "alice"); err == nil {
+		t.Error("Verify accepted a flipped bit")
+	}
+	if err := bob.Verify(message, signature[1:], "alice"); err == nil {
+		t.Error("Verify accepted a truncated signature")
+	}
+
+	if _, err := alice.Sign(message); err == nil {
+		t.Error("Sign reused the one-time key")
+	}
+	if _, err := NewWOTSProvider(ks, "alice").Sign(message); err == nil {
+		t.Error("a new provider over the same key store reused the key")
+	}
+	if _, err := NewWOTSProvider(reload(t, ks, crypto.WOTS), "alice").Sign(message); err == nil {
+		t.Error("the key was reused after a reload")
+	}
+}
+
+func TestWOTSSignFailsWhenStateCannotBeSaved(t *testing.T) {
+	ks := keystore.NewClientKeyStore("alice")
+	if err := NewWOTSProvider(ks, "alice").GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+
+	if signature, err := NewWOTSProvider(readOnlyKeyStore{ks}, "alice").Sign([]byte("m")); err == nil || signature != nil {
+		t.Fatal("Sign returned a signature it could not record")
+	}
+	if _, err := NewWOTSProvider(ks, "alice").Sign([]byte("m")); err != nil {
+		t.Fatalf("Sign after a failed save: %v", err)
+	}
+}
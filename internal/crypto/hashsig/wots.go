@@ -0,0 +1,155 @@
+package hashsig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// WOTS+ parameters for n = 32 and Winternitz parameter w = 16: the digest is
+// read as 64 base-16 digits, followed by a 3-digit checksum.
+const (
+	W         = 16
+	logW      = 4
+	wotsLen1  = 8 * N / logW
+	wotsLen2  = 3
+	wotsLen   = wotsLen1 + wotsLen2
+	wotsBytes = wotsLen * N
+)
+
+// wotsSecret returns the start of chain i of the one-time key for leaf.
+func wotsSecret(skSeed []byte, leaf, i uint32) []byte {
+	return prf(skSeed, address{kind: addrOTS, leaf: leaf, chain: i})
+}
+
+// chain applies steps chain steps to x, starting at position start.
+func chain(pubSeed []byte, leaf, i uint32, x []byte, start, steps int) []byte {
+	addr := address{kind: addrOTS, leaf: leaf, chain: i}
+	for j := start; j < start+steps; j++ {
+		addr.step = uint32(j)
+		x = chainStep(pubSeed, addr, x)
+	}
+	return x
+}
+
+// wotsDigits splits the digest into base-w digits and appends the checksum
+// Σ (w-1-dᵢ), also in base w. Raising any message digit lowers the
+// checksum, so a signature cannot be pushed further along its chains to
+// sign a different digest.
+func wotsDigits(digest []byte) []int {
+	digits := make([]int, 0, wotsLen)
+	for _, b := range digest {
+		digits = append(digits, int(b>>4), int(b&0x0f))
+	}
+
+	checksum := 0
+	for _, d := range digits {
+		checksum += W - 1 - d
+	}
+	for i := wotsLen2 - 1; i >= 0; i-- {
+		digits = append(digits, (checksum>>(logW*i))&(W-1))
+	}
+
+	return digits
+}
+
+// wotsPublicKey returns the ends of all chains of the one-time key for leaf.
+func wotsPublicKey(skSeed, pubSeed []byte, leaf uint32) []byte {
+	publicKey := make([]byte, 0, wotsBytes)
+	for i := uint32(0); i < wotsLen; i++ {
+		publicKey = append(publicKey, chain(pubSeed, leaf, i, wotsSecret(skSeed, leaf, i), 0, W-1)...)
+	}
+	return publicKey
+}
+
+// wotsSign walks chain i up to the i-th digit of the digest.
+func wotsSign(skSeed, pubSeed []byte, leaf uint32, digest []byte) []byte {
+	signature := make([]byte, 0, wotsBytes)
+	for i, d := range wotsDigits(digest) {
+		signature = append(signature, chain(pubSeed, leaf, uint32(i), wotsSecret(skSeed, leaf, uint32(i)), 0, d)...)
+	}
+	return signature
+}
+
+// wotsPublicKeyFromSignature finishes every chain of the signature. The
+// result equals the public key only if the signature is valid for digest.
+func wotsPublicKeyFromSignature(pubSeed []byte, leaf uint32, digest, signature []byte) []byte {
+	publicKey := make([]byte, 0, wotsBytes)
+	for i, d := range wotsDigits(digest) {
+		x := signature[i*N : (i+1)*N]
+		publicKey = append(publicKey, chain(pubSeed, leaf, uint32(i), x, d, W-1-d)...)
+	}
+	return publicKey
+}
+
+// wotsLeaf compresses a one-time public key into a Merkle tree leaf.
+func wotsLeaf(pubSeed []byte, leaf uint32, publicKey []byte) []byte {
+	addr := address{kind: addrLeaf, leaf: leaf}
+	return hash(domainLeaf, pubSeed, addr.bytes(), publicKey)
+}
+
+// A WOTS+ key also signs on its own, as a one-time key like Lamport's with
+// signatures about a quarter of the size. The standalone key uses the
+// addresses of leaf 0, and its public key is PubSeed followed by the end of
+// every chain.
+const (
+	WOTSPublicKeySize = N + wotsBytes
+	WOTSSignatureSize = wotsBytes
+)
+
+// wotsMessageDigest binds the message to the key's public seed.
+func wotsMessageDigest(pubSeed, message []byte) []byte {
+	return hash(domainMessage, pubSeed, message)
+}
+
+// WOTSPublicKey derives the standalone public key from the two seeds.
+func WOTSPublicKey(skSeed, pubSeed []byte) []byte {
+	return append(bytes.Clone(pubSeed), wotsPublicKey(skSeed, pubSeed, 0)...)
+}
+
+// WOTSSign signs message with the standalone key. The caller must make
+// sure the key never signs twice.
+func WOTSSign(skSeed, pubSeed, message []byte) []byte {
+	return wotsSign(skSeed, pubSeed, 0, wotsMessageDigest(pubSeed, message))
+}
+
+// WOTSVerify finishes the chains of the signature and compares their ends
+// with the public key.
+func WOTSVerify(publicKey, message, signature []byte) error {
+	if len(publicKey) != WOTSPublicKeySize || len(signature) != WOTSSignatureSize {
+		return errVerification
+	}
+
+	pubSeed := publicKey[:N]
+	ends := wotsPublicKeyFromSignature(pubSeed, 0, wotsMessageDigest(pubSeed, message), signature)
+	if !bytes.Equal(ends, publicKey[N:]) {
+		return errVerification
+	}
+	return nil
+}
+
+// EncodeWOTSPrivateKey encodes a standalone key as "Used,SKSeed,PubSeed"
+// with base64 seeds and Used either 0 or 1.
+func EncodeWOTSPrivateKey(skSeed, pubSeed []byte, used bool) string {
+	state := "0"
+	if used {
+		state = "1"
+	}
+	encode := base64.StdEncoding.EncodeToString
+	return state + "," + encode(skSeed) + "," + encode(pubSeed)
+}
+
+func DecodeWOTSPrivateKey(data string) (skSeed, pubSeed []byte, used bool, err error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 3 || (fields[0] != "0" && fields[0] != "1") {
+		return nil, nil, false, errors.New("invalid private key format: expected Used,SKSeed,PubSeed")
+	}
+
+	values, err := decodeHashes(fields[1:], "SKSeed", "PubSeed")
+	if err != nil {
+		return nil, nil, false, errors.New("invalid private key format: " + err.Error())
+	}
+
+	return values[0], values[1], fields[0] == "1", nil
+}
@@ -0,0 +1,81 @@
+package hashsig
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func randomSeeds(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	skSeed, pubSeed := make([]byte, N), make([]byte, N)
+	if _, err := rand.Read(skSeed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rand.Read(pubSeed); err != nil {
+		t.Fatal(err)
+	}
+	return skSeed, pubSeed
+}
+
+func TestWOTSSignVerify(t *testing.T) {
+	skSeed, pubSeed := randomSeeds(t)
+	publicKey := WOTSPublicKey(skSeed, pubSeed)
+	if len(publicKey) != WOTSPublicKeySize {
+		t.Fatalf("public key is %d bytes, want %d", len(publicKey), WOTSPublicKeySize)
+	}
+
+	message := []byte("message")
+	signature := WOTSSign(skSeed, pubSeed, message)
+	if len(signature) != WOTSSignatureSize {
+		t.Fatalf("signature is %d bytes, want %d", len(signature), WOTSSignatureSize)
+	}
+	if err := WOTSVerify(publicKey, message, signature); err != nil {
+		t.Fatalf("WOTSVerify: %v", err)
+	}
+
+	otherSK, otherPub := randomSeeds(t)
+	otherSeed := append(bytes.Clone(otherPub), publicKey[N:]...)
+	lastChain := bytes.Clone(signature)
+	lastChain[len(lastChain)-1] ^= 0x01
+
+	for _, tt := range []struct {
+		name      string
+		publicKey []byte
+		message   []byte
+		signature []byte
+	}{
+		{"other message", publicKey, []byte("messagf"), signature},
+		{"other key", WOTSPublicKey(otherSK, otherPub), message, signature},
+		{"other public seed", otherSeed, message, signature},
+		{"flipped checksum chain", publicKey, message, lastChain},
+		{"truncated signature", publicKey, message, signature[:len(signature)-1]},
+		{"truncated public key", publicKey[:len(publicKey)-1], message, signature},
+	} {
+		if err := WOTSVerify(tt.publicKey, tt.message, tt.signature); err == nil {
+			t.Errorf("%s: WOTSVerify succeeded", tt.name)
+		}
+	}
+}
+
+func TestWOTSPrivateKeyEncoding(t *testing.T) {
+	skSeed, pubSeed := randomSeeds(t)
+
+	for _, used := range []bool{false, true} {
+		decodedSK, decodedPub, decodedUsed, err := DecodeWOTSPrivateKey(EncodeWOTSPrivateKey(skSeed, pubSeed, used))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decodedSK, skSeed) || !bytes.Equal(decodedPub, pubSeed) || decodedUsed != used {
+			t.Fatalf("round trip with used = %v changed the key", used)
+		}
+	}
+
+	encoded := EncodeWOTSPrivateKey(skSeed, pubSeed, false)
+	for _, data := range []string{"", "2" + encoded[1:], encoded[2:], encoded + ",x"} {
+		if _, _, _, err := DecodeWOTSPrivateKey(data); err == nil {
+			t.Errorf("DecodeWOTSPrivateKey(%q) succeeded", data)
+		}
+	}
+}
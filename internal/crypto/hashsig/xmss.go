@@ -0,0 +1,244 @@
+package hashsig
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Tree heights accepted for XMSS keys. A key of height h signs 2^h
+// messages; key generation hashes every leaf, so it grows with 2^h too.
+const (
+	MinHeight     = 2
+	MaxHeight     = 12
+	DefaultHeight = 8
+)
+
+// XMSSKey is an XMSS-style many-time key: a Merkle tree over 2^Height WOTS+
+// one-time keys derived from SKSeed. Next is the first unused leaf; it is
+// the only state, and it must be saved before a signature leaves the
+// signer. SKSeed is nil in public keys.
+type XMSSKey struct {
+	Height  int
+	Next    uint32
+	SKSeed  []byte
+	PubSeed []byte
+	Root    []byte
+}
+
+// GenerateXMSSKey creates a key of the given height with random seeds.
+func GenerateXMSSKey(height int) (*XMSSKey, error) {
+	if height < MinHeight || height > MaxHeight {
+		return nil, fmt.Errorf("height must be between %d and %d", MinHeight, MaxHeight)
+	}
+
+	key := &XMSSKey{
+		Height:  height,
+		SKSeed:  make([]byte, N),
+		PubSeed: make([]byte, N),
+	}
+	if _, err := rand.Read(key.SKSeed); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(key.PubSeed); err != nil {
+		return nil, err
+	}
+
+	levels := key.tree()
+	key.Root = levels[height][0]
+	return key, nil
+}
+
+// Leaves returns the number of signatures the key can make in total.
+func (key *XMSSKey) Leaves() uint32 {
+	return 1 << key.Height
+}
+
+// Remaining returns the number of unused leaves.
+func (key *XMSSKey) Remaining() uint32 {
+	return key.Leaves() - key.Next
+}
+
+// SignatureSize returns the length of a signature: the leaf index, the
+// WOTS+ signature and one sibling per tree level.
+func (key *XMSSKey) SignatureSize() int {
+	return 4 + wotsBytes + key.Height*N
+}
+
+// tree computes every node, level 0 being the leaves.
+func (key *XMSSKey) tree() [][][]byte {
+	levels := make([][][]byte, key.Height+1)
+
+	levels[0] = make([][]byte, key.Leaves())
+	for i := range levels[0] {
+		leaf := uint32(i)
+		levels[0][i] = wotsLeaf(key.PubSeed, leaf, wotsPublicKey(key.SKSeed, key.PubSeed, leaf))
+	}
+
+	for level := 1; level <= key.Height; level++ {
+		below := levels[level-1]
+		levels[level] = make([][]byte, len(below)/2)
+		for i := range levels[level] {
+			levels[level][i] = node(key.PubSeed, uint32(level), uint32(i), below[2*i], below[2*i+1])
+		}
+	}
+
+	return levels
+}
+
+// sign signs message with the leaf Next using a tree from tree(), which
+// callers may keep between signatures. It does not advance Next: callers
+// must record the leaf as used, and persist that, before handing out the
+// signature.
+func (key *XMSSKey) sign(levels [][][]byte, message []byte) ([]byte, error) {
+	if key.SKSeed == nil {
+		return nil, errors.New("private key required to sign")
+	}
+	if key.Next >= key.Leaves() {
+		return nil, errors.New("all one-time keys have been used")
+	}
+
+	leaf := key.Next
+	signature := binary.BigEndian.AppendUint32(nil, leaf)
+	signature = append(signature, wotsSign(key.SKSeed, key.PubSeed, leaf, key.digest(leaf, message))...)
+
+	for level := 0; level < key.Height; level++ {
+		sibling := (leaf >> level) ^ 1
+		signature = append(signature, levels[level][sibling]...)
+	}
+
+	return signature, nil
+}
+
+// digest binds the message to the key and the leaf it is signed with.
+func (key *XMSSKey) digest(leaf uint32, message []byte) []byte {
+	return hash(domainMessage, key.PubSeed, key.Root, binary.BigEndian.AppendUint32(nil, leaf), message)
+}
+
+// Verify recomputes the leaf from the WOTS+ signature, climbs to the root
+// along the authentication path and compares it with the public root.
+func (key *XMSSKey) Verify(message, signature []byte) error {
+	if len(signature) != key.SignatureSize() {
+		return errVerification
+	}
+
+	leaf := binary.BigEndian.Uint32(signature[:4])
+	if leaf >= key.Leaves() {
+		return errVerification
+	}
+	wotsSignature := signature[4 : 4+wotsBytes]
+	authPath := signature[4+wotsBytes:]
+
+	publicKey := wotsPublicKeyFromSignature(key.PubSeed, leaf, key.digest(leaf, message), wotsSignature)
+	current := wotsLeaf(key.PubSeed, leaf, publicKey)
+
+	for level := 0; level < key.Height; level++ {
+		sibling := authPath[level*N : (level+1)*N]
+		index := leaf >> (level + 1)
+
+		if (leaf>>level)&1 == 0 {
+			current = node(key.PubSeed, uint32(level+1), index, current, sibling)
+		} else {
+			current = node(key.PubSeed, uint32(level+1), index, sibling, current)
+		}
+	}
+
+	if !bytes.Equal(current, key.Root) {
+		return errVerification
+	}
+	return nil
+}
+
+// EncodeToString returns the text encodings, with base64 byte strings:
+// private "Height,Next,SKSeed,PubSeed,Root", public "Height,Root,PubSeed".
+func (key *XMSSKey) EncodeToString() (privateKey, publicKey string, err error) {
+	if key.SKSeed == nil {
+		return "", "", errors.New("private seed is required to encode the private key")
+	}
+
+	encode := base64.StdEncoding.EncodeToString
+	publicKey = fmt.Sprintf("%d,%s,%s", key.Height, encode(key.Root), encode(key.PubSeed))
+	privateKey = fmt.Sprintf("%d,%d,%s,%s,%s",
+		key.Height, key.Next, encode(key.SKSeed), encode(key.PubSeed), encode(key.Root))
+
+	return privateKey, publicKey, nil
+}
+
+func DecodeXMSSPrivateKey(data string) (*XMSSKey, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 5 {
+		return nil, errors.New("invalid private key format: expected Height,Next,SKSeed,PubSeed,Root")
+	}
+
+	height, err := parseHeight(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil || next > 1<<height {
+		return nil, errors.New("invalid private key format: invalid Next value")
+	}
+
+	seeds, err := decodeHashes(fields[2:], "SKSeed", "PubSeed", "Root")
+	if err != nil {
+		return nil, errors.New("invalid private key format: " + err.Error())
+	}
+
+	return &XMSSKey{
+		Height:  height,
+		Next:    uint32(next),
+		SKSeed:  seeds[0],
+		PubSeed: seeds[1],
+		Root:    seeds[2],
+	}, nil
+}
+
+func DecodeXMSSPublicKey(data string) (*XMSSKey, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 3 {
+		return nil, errors.New("invalid public key format: expected Height,Root,PubSeed")
+	}
+
+	height, err := parseHeight(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decodeHashes(fields[1:], "Root", "PubSeed")
+	if err != nil {
+		return nil, errors.New("invalid public key format: " + err.Error())
+	}
+
+	return &XMSSKey{
+		Height:  height,
+		Root:    values[0],
+		PubSeed: values[1],
+	}, nil
+}
+
+func parseHeight(field string) (int, error) {
+	height, err := strconv.Atoi(field)
+	if err != nil || height < MinHeight || height > MaxHeight {
+		return 0, fmt.Errorf("height must be between %d and %d", MinHeight, MaxHeight)
+	}
+	return height, nil
+}
+
+// decodeHashes decodes base64 fields that must each be N bytes long.
+func decodeHashes(fields []string, names ...string) ([][]byte, error) {
+	values := make([][]byte, len(names))
+	for i, name := range names {
+		value, err := base64.StdEncoding.DecodeString(fields[i])
+		if err != nil || len(value) != N {
+			return nil, fmt.Errorf("invalid %s value", name)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,123 @@
+package hashsig
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestXMSSSignVerify(t *testing.T) {
+	key, err := GenerateXMSSKey(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, publicKeyStr, err := key.EncodeToString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := DecodeXMSSPublicKey(publicKeyStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	levels := key.tree()
+	message := []byte("message")
+
+	for key.Next < key.Leaves() {
+		signature, err := key.sign(levels, message)
+		if err != nil {
+			t.Fatalf("leaf %d: %v", key.Next, err)
+		}
+		if len(signature) != key.SignatureSize() {
+			t.Fatalf("signature is %d bytes, want %d", len(signature), key.SignatureSize())
+		}
+		if err := publicKey.Verify(message, signature); err != nil {
+			t.Fatalf("Verify of leaf %d: %v", key.Next, err)
+		}
+
+		flipped := bytes.Clone(signature)
+		flipped[len(flipped)-1] ^= 0x01
+		otherLeaf := bytes.Clone(signature)
+		binary.BigEndian.PutUint32(otherLeaf, (key.Next+1)%key.Leaves())
+
+		for name, bad := range map[string][]byte{
+			"flipped auth path": flipped,
+			"other leaf index":  otherLeaf,
+			"truncated":         signature[:len(signature)-1],
+		} {
+			if err := publicKey.Verify(message, bad); err == nil {
+				t.Errorf("leaf %d, %s: Verify succeeded", key.Next, name)
+			}
+		}
+		if err := publicKey.Verify([]byte("other"), signature); err == nil {
+			t.Errorf("leaf %d: Verify accepted another message", key.Next)
+		}
+
+		key.Next++
+	}
+
+	if _, err := key.sign(levels, message); err == nil {
+		t.Fatal("sign succeeded with every leaf used")
+	}
+}
+
+func TestXMSSKeyEncoding(t *testing.T) {
+	key, err := GenerateXMSSKey(MinHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.Next = 3
+
+	privateKey, _, err := key.EncodeToString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeXMSSPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Next != 3 || !bytes.Equal(decoded.Root, key.Root) {
+		t.Fatal("private key did not round-trip")
+	}
+
+	for _, bad := range []string{
+		"",
+		"1,0,a,b,c",
+		"2,5," + privateKey[4:],
+	} {
+		if _, err := DecodeXMSSPrivateKey(bad); err == nil {
+			t.Errorf("DecodeXMSSPrivateKey(%q) succeeded", bad)
+		}
+	}
+	if _, err := GenerateXMSSKey(MaxHeight + 1); err == nil {
+		t.Error("GenerateXMSSKey accepted a height above MaxHeight")
+	}
+}
+
+func TestLamportSignVerify(t *testing.T) {
+	seed := bytes.Repeat([]byte{1}, N)
+	publicKey := LamportPublicKey(seed)
+	if len(publicKey) != LamportPublicKeySize {
+		t.Fatalf("public key is %d bytes, want %d", len(publicKey), LamportPublicKeySize)
+	}
+
+	signature := LamportSign(seed, []byte("message"))
+	if err := LamportVerify(publicKey, []byte("message"), signature); err != nil {
+		t.Fatalf("LamportVerify: %v", err)
+	}
+	if err := LamportVerify(publicKey, []byte("other"), signature); err == nil {
+		t.Fatal("LamportVerify accepted another message")
+	}
+	if err := LamportVerify(LamportPublicKey(bytes.Repeat([]byte{2}, N)), []byte("message"), signature); err == nil {
+		t.Fatal("LamportVerify accepted another key")
+	}
+
+	encoded := EncodeLamportPrivateKey(seed, true)
+	decodedSeed, used, err := DecodeLamportPrivateKey(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !used || !bytes.Equal(decodedSeed, seed) {
+		t.Fatal("private key did not round-trip")
+	}
+}
@@ -0,0 +1,111 @@
+package hybrid
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// XWingProvider encrypts with the X25519 + ML-KEM-768 hybrid KEM. The
+// private key is stored as its 32-byte seed and the public key as a single
+// blob, both in base64.
+type XWingProvider struct {
+	keyStore   crypto.KeyStore
+	userID     string
+	privateKey *PrivateKey
+}
+
+func NewXWingProvider(keyStore crypto.KeyStore, userID string) *XWingProvider {
+	return &XWingProvider{
+		keyStore:   keyStore,
+		userID:     userID,
+		privateKey: nil,
+	}
+}
+
+var _ crypto.Provider = (*XWingProvider)(nil)
+
+func (p *XWingProvider) GenerateKey() error {
+	privateKey, err := NewPrivateKey(GenerateSeed())
+	if err != nil {
+		return err
+	}
+
+	p.privateKey = privateKey
+
+	err = p.keyStore.StorePrivateKey(crypto.XWing, []byte(base64.StdEncoding.EncodeToString(privateKey.Seed())))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.XWing, []byte(base64.StdEncoding.EncodeToString(privateKey.PublicKey())))
+}
+
+func (p *XWingProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	publicKey, err := base64.StdEncoding.DecodeString(string(publicKeyData))
+	if err != nil {
+		return fmt.Errorf("invalid public key encoding: %v", err)
+	}
+
+	if err := CheckPublicKey(publicKey); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.XWing, publicKeyData)
+}
+
+func (p *XWingProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.XWing)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientKey, err := base64.StdEncoding.DecodeString(string(recipientKeyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+
+	return Encrypt(recipientKey, message)
+}
+
+func (p *XWingProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	return p.privateKey.Decrypt(ciphertext)
+}
+
+func (p *XWingProvider) PublicKey() ([]byte, error) {
+	if err := p.loadPrivateKey(); err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(p.privateKey.PublicKey())), nil
+}
+
+// loadPrivateKey decodes the user's seed from the key store on first use.
+func (p *XWingProvider) loadPrivateKey() error {
+	if p.privateKey != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.XWing)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(string(privateKeyBytes))
+	if err != nil {
+		return fmt.Errorf("invalid private key encoding: %v", err)
+	}
+
+	privateKey, err := NewPrivateKey(seed)
+	if err != nil {
+		return err
+	}
+
+	p.privateKey = privateKey
+	return nil
+}
@@ -0,0 +1,230 @@
+// Package hybrid combines a classical and a post-quantum KEM so a message
+// stays secret as long as either one holds.
+package hybrid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha3"
+	"errors"
+	"io"
+)
+
+// X-Wing sizes. Keys and ciphertexts are the ML-KEM-768 part followed by
+// the X25519 part.
+const (
+	SeedSize          = 32
+	PublicKeySize     = mlkem.EncapsulationKeySize768 + 32
+	encapsulationSize = mlkem.CiphertextSize768 + 32
+	expandedSeedSize  = mlkem.SeedSize + 32
+	xwingNonceSize    = 12
+)
+
+// xwingLabel is the X-Wing domain separator, the ASCII art \.//^\.
+var xwingLabel = []byte{0x5c, 0x2e, 0x2f, 0x2f, 0x5e, 0x5c}
+
+var errDecryption = errors.New("x-wing: decryption error")
+
+// PrivateKey is an X-Wing decapsulation key, derived from a 32-byte seed.
+type PrivateKey struct {
+	seed   []byte
+	mlkem  *mlkem.DecapsulationKey768
+	x25519 *ecdh.PrivateKey
+}
+
+// GenerateSeed returns a fresh random seed.
+func GenerateSeed() []byte {
+	seed := make([]byte, SeedSize)
+	rand.Read(seed)
+	return seed
+}
+
+// NewPrivateKey expands the seed with SHAKE-256 into the ML-KEM-768 seed
+// and the X25519 scalar.
+func NewPrivateKey(seed []byte) (*PrivateKey, error) {
+	if len(seed) != SeedSize {
+		return nil, errors.New("x-wing: seed must be 32 bytes")
+	}
+
+	expanded := sha3.SumSHAKE256(seed, expandedSeedSize)
+
+	mlkemKey, err := mlkem.NewDecapsulationKey768(expanded[:mlkem.SeedSize])
+	if err != nil {
+		return nil, err
+	}
+
+	x25519Key, err := ecdh.X25519().NewPrivateKey(expanded[mlkem.SeedSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{
+		seed:   append([]byte(nil), seed...),
+		mlkem:  mlkemKey,
+		x25519: x25519Key,
+	}, nil
+}
+
+func (sk *PrivateKey) Seed() []byte {
+	return append([]byte(nil), sk.seed...)
+}
+
+// PublicKey returns the ML-KEM-768 encapsulation key followed by the X25519
+// public key.
+func (sk *PrivateKey) PublicKey() []byte {
+	return append(sk.mlkem.EncapsulationKey().Bytes(), sk.x25519.PublicKey().Bytes()...)
+}
+
+// CheckPublicKey reports whether the blob is a valid X-Wing public key.
+func CheckPublicKey(publicKey []byte) error {
+	_, _, err := parsePublicKey(publicKey)
+	return err
+}
+
+func parsePublicKey(publicKey []byte) (*mlkem.EncapsulationKey768, *ecdh.PublicKey, error) {
+	if len(publicKey) != PublicKeySize {
+		return nil, nil, errors.New("x-wing: invalid public key length")
+	}
+
+	mlkemKey, err := mlkem.NewEncapsulationKey768(publicKey[:mlkem.EncapsulationKeySize768])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	x25519Key, err := ecdh.X25519().NewPublicKey(publicKey[mlkem.EncapsulationKeySize768:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mlkemKey, x25519Key, nil
+}
+
+// combine is the X-Wing combiner:
+//
+//	SHA3-256(ss_M || ss_X || ct_X || pk_X || label)
+func combine(mlkemSecret, x25519Secret, x25519Ciphertext, x25519PublicKey []byte) []byte {
+	h := sha3.New256()
+	h.Write(mlkemSecret)
+	h.Write(x25519Secret)
+	h.Write(x25519Ciphertext)
+	h.Write(x25519PublicKey)
+	h.Write(xwingLabel)
+	return h.Sum(nil)
+}
+
+// Encapsulate returns a shared secret and its encapsulation to the public
+// key: the ML-KEM-768 ciphertext followed by an ephemeral X25519 public key.
+func Encapsulate(publicKey []byte) (sharedSecret, encapsulation []byte, err error) {
+	mlkemKey, x25519Key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mlkemSecret, mlkemCiphertext := mlkemKey.Encapsulate()
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	x25519Secret, err := ephemeral.ECDH(x25519Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	x25519Ciphertext := ephemeral.PublicKey().Bytes()
+
+	sharedSecret = combine(mlkemSecret, x25519Secret, x25519Ciphertext, x25519Key.Bytes())
+	return sharedSecret, append(mlkemCiphertext, x25519Ciphertext...), nil
+}
+
+// Decapsulate recovers the shared secret from an encapsulation.
+func (sk *PrivateKey) Decapsulate(encapsulation []byte) ([]byte, error) {
+	if len(encapsulation) != encapsulationSize {
+		return nil, errors.New("x-wing: invalid encapsulation length")
+	}
+
+	mlkemSecret, err := sk.mlkem.Decapsulate(encapsulation[:mlkem.CiphertextSize768])
+	if err != nil {
+		return nil, err
+	}
+
+	x25519Ciphertext := encapsulation[mlkem.CiphertextSize768:]
+	ephemeral, err := ecdh.X25519().NewPublicKey(x25519Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	x25519Secret, err := sk.x25519.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	return combine(mlkemSecret, x25519Secret, x25519Ciphertext, sk.x25519.PublicKey().Bytes()), nil
+}
+
+// Encrypt seals the message under the X-Wing shared secret, used directly
+// as the AES-256-GCM key:
+//
+//	encapsulation (1120 bytes) || nonce (12 bytes) || AES-GCM ciphertext and tag
+//
+// The encapsulation is authenticated as additional data.
+func Encrypt(publicKey, message []byte) ([]byte, error) {
+	sharedSecret, encapsulation, err := Encapsulate(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(sharedSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	k := len(encapsulation)
+	out := make([]byte, k+xwingNonceSize, k+xwingNonceSize+len(message)+aead.Overhead())
+	copy(out, encapsulation)
+
+	nonce := out[k:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(out, nonce, message, encapsulation), nil
+}
+
+// Decrypt reverses Encrypt.
+func (sk *PrivateKey) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < encapsulationSize+xwingNonceSize {
+		return nil, errDecryption
+	}
+
+	encapsulation := ciphertext[:encapsulationSize]
+	nonce := ciphertext[encapsulationSize : encapsulationSize+xwingNonceSize]
+	sealed := ciphertext[encapsulationSize+xwingNonceSize:]
+
+	sharedSecret, err := sk.Decapsulate(encapsulation)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	aead, err := newAEAD(sharedSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := aead.Open(nil, nonce, sealed, encapsulation)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	return message, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
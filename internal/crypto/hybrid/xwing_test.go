@@ -0,0 +1,143 @@
+package hybrid
+
+import (
+	"bytes"
+	"crypto/mlkem"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+func newTestKey(t *testing.T) *PrivateKey {
+	t.Helper()
+
+	sk, err := NewPrivateKey(GenerateSeed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk
+}
+
+func TestPrivateKeyFromSeed(t *testing.T) {
+	sk := newTestKey(t)
+
+	again, err := NewPrivateKey(sk.Seed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again.PublicKey(), sk.PublicKey()) {
+		t.Fatal("the same seed gave different public keys")
+	}
+	if len(sk.PublicKey()) != PublicKeySize {
+		t.Fatalf("public key is %d bytes, want %d", len(sk.PublicKey()), PublicKeySize)
+	}
+
+	if _, err := NewPrivateKey(make([]byte, SeedSize-1)); err == nil {
+		t.Fatal("NewPrivateKey accepted a short seed")
+	}
+}
+
+func TestEncapsulateDecapsulate(t *testing.T) {
+	sk := newTestKey(t)
+
+	for i := 0; i < 10; i++ {
+		sharedSecret, encapsulation, err := Encapsulate(sk.PublicKey())
+		if err != nil {
+			t.Fatalf("Encapsulate: %v", err)
+		}
+
+		decapsulated, err := sk.Decapsulate(encapsulation)
+		if err != nil {
+			t.Fatalf("Decapsulate: %v", err)
+		}
+		if !bytes.Equal(decapsulated, sharedSecret) {
+			t.Fatal("Decapsulate returned a different shared secret")
+		}
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	sk := newTestKey(t)
+
+	for _, message := range [][]byte{nil, []byte("hello"), bytes.Repeat([]byte("x"), 5000)} {
+		ciphertext, err := Encrypt(sk.PublicKey(), message)
+		if err != nil {
+			t.Fatalf("Encrypt: %v", err)
+		}
+
+		decrypted, err := sk.Decrypt(ciphertext)
+		if err != nil {
+			t.Fatalf("Decrypt: %v", err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("Decrypt(Encrypt(m)) differs for a %d-byte message", len(message))
+		}
+	}
+}
+
+func TestDecryptRejectsTampering(t *testing.T) {
+	sk := newTestKey(t)
+
+	ciphertext, err := Encrypt(sk.PublicKey(), []byte("attack at dawn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One byte in each part: the ML-KEM ciphertext, the X25519 share, the
+	// nonce, the body and the tag.
+	for _, i := range []int{0, mlkem.CiphertextSize768, encapsulationSize, encapsulationSize + xwingNonceSize, len(ciphertext) - 1} {
+		tampered := bytes.Clone(ciphertext)
+		tampered[i] ^= 0x01
+		if _, err := sk.Decrypt(tampered); err == nil {
+			t.Errorf("Decrypt succeeded with byte %d flipped", i)
+		}
+	}
+
+	if _, err := sk.Decrypt(ciphertext[:encapsulationSize+xwingNonceSize-1]); err == nil {
+		t.Error("Decrypt succeeded on a truncated ciphertext")
+	}
+	if _, err := newTestKey(t).Decrypt(ciphertext); err == nil {
+		t.Error("Decrypt succeeded with another key")
+	}
+}
+
+func TestCheckPublicKey(t *testing.T) {
+	publicKey := newTestKey(t).PublicKey()
+
+	if err := CheckPublicKey(publicKey); err != nil {
+		t.Fatalf("CheckPublicKey: %v", err)
+	}
+	for _, bad := range [][]byte{nil, publicKey[1:], append(bytes.Clone(publicKey), 0)} {
+		if err := CheckPublicKey(bad); err == nil {
+			t.Errorf("CheckPublicKey accepted a %d-byte key", len(bad))
+		}
+	}
+}
+
+func TestProviderRoundTrip(t *testing.T) {
+	alice := NewXWingProvider(keystore.NewClientKeyStore("alice"), "alice")
+	bob := NewXWingProvider(keystore.NewClientKeyStore("bob"), "bob")
+	if err := bob.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := bob.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := alice.ParsePublicKey("bob", publicKey); err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+
+	ciphertext, err := alice.Encrypt([]byte("hi bob"), "bob")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	decrypted, err := bob.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if string(decrypted) != "hi bob" {
+		t.Fatalf("Decrypt = %q", decrypted)
+	}
+}
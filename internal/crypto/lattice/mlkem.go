@@ -0,0 +1,200 @@
+package lattice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+const (
+	mlkemInfo      = "crypto-grpc ML-KEM AES-256-GCM"
+	mlkemKeySize   = 32
+	mlkemNonceSize = 12
+)
+
+var errDecryption = errors.New("ml-kem: decryption error")
+
+// Algorithms lists the ML-KEM parameter sets in the order the client offers
+// them.
+var Algorithms = []crypto.Algorithm{
+	crypto.MLKEM768,
+	crypto.MLKEM1024,
+}
+
+// GenerateSeed returns a fresh 64-byte decapsulation key seed.
+func GenerateSeed() []byte {
+	seed := make([]byte, mlkem.SeedSize)
+	rand.Read(seed)
+	return seed
+}
+
+// EncapsulationKey derives the public encapsulation key from a seed.
+func EncapsulationKey(algorithm crypto.Algorithm, seed []byte) ([]byte, error) {
+	switch algorithm {
+	case crypto.MLKEM768:
+		dk, err := mlkem.NewDecapsulationKey768(seed)
+		if err != nil {
+			return nil, err
+		}
+		return dk.EncapsulationKey().Bytes(), nil
+	case crypto.MLKEM1024:
+		dk, err := mlkem.NewDecapsulationKey1024(seed)
+		if err != nil {
+			return nil, err
+		}
+		return dk.EncapsulationKey().Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported lattice algorithm: %s", algorithm)
+	}
+}
+
+// CheckEncapsulationKey reports whether ek is a valid encapsulation key.
+func CheckEncapsulationKey(algorithm crypto.Algorithm, ek []byte) error {
+	var err error
+	switch algorithm {
+	case crypto.MLKEM768:
+		_, err = mlkem.NewEncapsulationKey768(ek)
+	case crypto.MLKEM1024:
+		_, err = mlkem.NewEncapsulationKey1024(ek)
+	default:
+		err = fmt.Errorf("unsupported lattice algorithm: %s", algorithm)
+	}
+	return err
+}
+
+func encapsulate(algorithm crypto.Algorithm, ek []byte) (sharedKey, ciphertext []byte, err error) {
+	switch algorithm {
+	case crypto.MLKEM768:
+		key, err := mlkem.NewEncapsulationKey768(ek)
+		if err != nil {
+			return nil, nil, err
+		}
+		sharedKey, ciphertext = key.Encapsulate()
+		return sharedKey, ciphertext, nil
+	case crypto.MLKEM1024:
+		key, err := mlkem.NewEncapsulationKey1024(ek)
+		if err != nil {
+			return nil, nil, err
+		}
+		sharedKey, ciphertext = key.Encapsulate()
+		return sharedKey, ciphertext, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported lattice algorithm: %s", algorithm)
+	}
+}
+
+func decapsulate(algorithm crypto.Algorithm, seed, ciphertext []byte) ([]byte, error) {
+	switch algorithm {
+	case crypto.MLKEM768:
+		dk, err := mlkem.NewDecapsulationKey768(seed)
+		if err != nil {
+			return nil, err
+		}
+		return dk.Decapsulate(ciphertext)
+	case crypto.MLKEM1024:
+		dk, err := mlkem.NewDecapsulationKey1024(seed)
+		if err != nil {
+			return nil, err
+		}
+		return dk.Decapsulate(ciphertext)
+	default:
+		return nil, fmt.Errorf("unsupported lattice algorithm: %s", algorithm)
+	}
+}
+
+func ciphertextSize(algorithm crypto.Algorithm) (int, error) {
+	switch algorithm {
+	case crypto.MLKEM768:
+		return mlkem.CiphertextSize768, nil
+	case crypto.MLKEM1024:
+		return mlkem.CiphertextSize1024, nil
+	default:
+		return 0, fmt.Errorf("unsupported lattice algorithm: %s", algorithm)
+	}
+}
+
+// Encrypt encapsulates a shared secret to the encapsulation key, derives an
+// AES-256 key from it with HKDF-SHA-256 and seals the message with AES-GCM:
+//
+//	ML-KEM ciphertext || nonce (12 bytes) || AES-GCM ciphertext and tag
+//
+// The ML-KEM ciphertext is authenticated as additional data.
+func Encrypt(algorithm crypto.Algorithm, ek, message []byte) ([]byte, error) {
+	sharedKey, kemCiphertext, err := encapsulate(algorithm, ek)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := mlkemAEAD(sharedKey, kemCiphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	k := len(kemCiphertext)
+	out := make([]byte, k+mlkemNonceSize, k+mlkemNonceSize+len(message)+aead.Overhead())
+	copy(out, kemCiphertext)
+
+	nonce := out[k:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(out, nonce, message, kemCiphertext), nil
+}
+
+// Decrypt reverses Encrypt with the decapsulation key seed.
+func Decrypt(algorithm crypto.Algorithm, seed, ciphertext []byte) ([]byte, error) {
+	k, err := ciphertextSize(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < k+mlkemNonceSize {
+		return nil, errDecryption
+	}
+
+	kemCiphertext := ciphertext[:k]
+	nonce := ciphertext[k : k+mlkemNonceSize]
+	sealed := ciphertext[k+mlkemNonceSize:]
+
+	sharedKey, err := decapsulate(algorithm, seed, kemCiphertext)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	aead, err := mlkemAEAD(sharedKey, kemCiphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := aead.Open(nil, nonce, sealed, kemCiphertext)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	return message, nil
+}
+
+// mlkemAEAD derives the AES-GCM key from the shared key, binding it to the
+// ML-KEM ciphertext.
+func mlkemAEAD(sharedKey, kemCiphertext []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, sharedKey, kemCiphertext, mlkemInfo, mlkemKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
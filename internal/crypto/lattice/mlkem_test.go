@@ -0,0 +1,121 @@
+package lattice
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+func TestMLKEMRoundTrip(t *testing.T) {
+	long := make([]byte, 5000)
+	if _, err := rand.Read(long); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, algorithm := range Algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			seed := GenerateSeed()
+			ek, err := EncapsulationKey(algorithm, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := CheckEncapsulationKey(algorithm, ek); err != nil {
+				t.Fatalf("CheckEncapsulationKey: %v", err)
+			}
+
+			for _, message := range [][]byte{nil, []byte("hello"), long} {
+				ciphertext, err := Encrypt(algorithm, ek, message)
+				if err != nil {
+					t.Fatalf("Encrypt: %v", err)
+				}
+
+				decrypted, err := Decrypt(algorithm, seed, ciphertext)
+				if err != nil {
+					t.Fatalf("Decrypt: %v", err)
+				}
+				if !bytes.Equal(decrypted, message) {
+					t.Fatalf("Decrypt(Encrypt(m)) differs for a %d-byte message", len(message))
+				}
+			}
+		})
+	}
+}
+
+func TestMLKEMRejectsTampering(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			seed := GenerateSeed()
+			ek, err := EncapsulationKey(algorithm, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ciphertext, err := Encrypt(algorithm, ek, []byte("attack at dawn"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			kemSize, err := ciphertextSize(algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, i := range []int{0, kemSize - 1, kemSize, len(ciphertext) - 1} {
+				tampered := bytes.Clone(ciphertext)
+				tampered[i] ^= 0x01
+				if _, err := Decrypt(algorithm, seed, tampered); err == nil {
+					t.Errorf("Decrypt succeeded with byte %d flipped", i)
+				}
+			}
+
+			if _, err := Decrypt(algorithm, seed, ciphertext[:kemSize]); err == nil {
+				t.Error("Decrypt succeeded on a truncated ciphertext")
+			}
+			if _, err := Decrypt(algorithm, GenerateSeed(), ciphertext); err == nil {
+				t.Error("Decrypt succeeded with another key")
+			}
+		})
+	}
+}
+
+func TestMLKEMProvider(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			alice, err := NewMLKEMProvider(keystore.NewClientKeyStore("alice"), "alice", algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bob, err := NewMLKEMProvider(keystore.NewClientKeyStore("bob"), "bob", algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := bob.GenerateKey(); err != nil {
+				t.Fatal(err)
+			}
+
+			publicKey, err := bob.PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := alice.ParsePublicKey("bob", publicKey); err != nil {
+				t.Fatalf("ParsePublicKey: %v", err)
+			}
+			if err := alice.ParsePublicKey("eve", publicKey[1:]); err == nil {
+				t.Error("ParsePublicKey accepted a truncated key")
+			}
+
+			ciphertext, err := alice.Encrypt([]byte("hi bob"), "bob")
+			if err != nil {
+				t.Fatalf("Encrypt: %v", err)
+			}
+			decrypted, err := bob.Decrypt(ciphertext)
+			if err != nil {
+				t.Fatalf("Decrypt: %v", err)
+			}
+			if string(decrypted) != "hi bob" {
+				t.Fatalf("Decrypt = %q", decrypted)
+			}
+		})
+	}
+}
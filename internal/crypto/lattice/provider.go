@@ -0,0 +1,239 @@
+package lattice
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// MLKEMProvider encrypts with one ML-KEM parameter set. The decapsulation
+// key is kept as its 64-byte seed; both keys are stored in base64.
+type MLKEMProvider struct {
+	keyStore  crypto.KeyStore
+	userID    string
+	algorithm crypto.Algorithm
+	seed      []byte
+}
+
+func NewMLKEMProvider(keyStore crypto.KeyStore, userID string, algorithm crypto.Algorithm) (*MLKEMProvider, error) {
+	if _, err := ciphertextSize(algorithm); err != nil {
+		return nil, err
+	}
+
+	return &MLKEMProvider{
+		keyStore:  keyStore,
+		userID:    userID,
+		algorithm: algorithm,
+		seed:      nil,
+	}, nil
+}
+
+var _ crypto.Provider = (*MLKEMProvider)(nil)
+
+func (p *MLKEMProvider) GenerateKey() error {
+	seed := GenerateSeed()
+
+	ek, err := EncapsulationKey(p.algorithm, seed)
+	if err != nil {
+		return err
+	}
+
+	p.seed = seed
+
+	err = p.keyStore.StorePrivateKey(p.algorithm, []byte(base64.StdEncoding.EncodeToString(seed)))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, p.algorithm, []byte(base64.StdEncoding.EncodeToString(ek)))
+}
+
+func (p *MLKEMProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	ek, err := base64.StdEncoding.DecodeString(string(publicKeyData))
+	if err != nil {
+		return fmt.Errorf("invalid public key encoding: %v", err)
+	}
+
+	if err := CheckEncapsulationKey(p.algorithm, ek); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, p.algorithm, publicKeyData)
+}
+
+func (p *MLKEMProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, p.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	ek, err := base64.StdEncoding.DecodeString(string(recipientKeyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %v", err)
+	}
+
+	return Encrypt(p.algorithm, ek, message)
+}
+
+func (p *MLKEMProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadSeed(); err != nil {
+		return nil, err
+	}
+
+	return Decrypt(p.algorithm, p.seed, ciphertext)
+}
+
+func (p *MLKEMProvider) PublicKey() ([]byte, error) {
+	if err := p.loadSeed(); err != nil {
+		return nil, err
+	}
+
+	ek, err := EncapsulationKey(p.algorithm, p.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(ek)), nil
+}
+
+// loadSeed decodes the user's decapsulation key seed from the key store on
+// first use.
+func (p *MLKEMProvider) loadSeed() error {
+	if p.seed != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(p.algorithm)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(string(privateKeyBytes))
+	if err != nil {
+		return fmt.Errorf("invalid private key encoding: %v", err)
+	}
+
+	if _, err := EncapsulationKey(p.algorithm, seed); err != nil {
+		return err
+	}
+
+	p.seed = seed
+	return nil
+}
+
+// RegevProvider encrypts with the toy Regev LWE scheme, one bit at a time.
+type RegevProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+	key      *RegevKey
+}
+
+func NewRegevProvider(keyStore crypto.KeyStore, userID string) *RegevProvider {
+	return &RegevProvider{
+		keyStore: keyStore,
+		userID:   userID,
+		key:      nil,
+	}
+}
+
+var _ crypto.Provider = (*RegevProvider)(nil)
+
+// GenerateKey creates a key with the DefaultPreset parameters.
+func (p *RegevProvider) GenerateKey() error {
+	params, err := LookupPreset(DefaultPreset)
+	if err != nil {
+		return err
+	}
+
+	return p.GenerateKeyWithParams(params)
+}
+
+func (p *RegevProvider) GenerateKeyWithParams(params Params) error {
+	key, err := GenerateRegevKey(params)
+	if err != nil {
+		return err
+	}
+
+	privateKeyStr, publicKeyStr, err := key.EncodeToString()
+	if err != nil {
+		return err
+	}
+
+	p.key = key
+
+	err = p.keyStore.StorePrivateKey(crypto.RegevLWE, []byte(privateKeyStr))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.RegevLWE, []byte(publicKeyStr))
+}
+
+// Params returns the parameters of the user's key.
+func (p *RegevProvider) Params() (Params, error) {
+	if err := p.loadKey(); err != nil {
+		return Params{}, err
+	}
+
+	return p.key.Params, nil
+}
+
+func (p *RegevProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodeRegevPublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.RegevLWE, publicKeyData)
+}
+
+func (p *RegevProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.RegevLWE)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientKey, err := DecodeRegevPublicKey(string(recipientKeyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return EncryptRegev(recipientKey, message)
+}
+
+func (p *RegevProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadKey(); err != nil {
+		return nil, err
+	}
+
+	return DecryptRegev(p.key, ciphertext)
+}
+
+func (p *RegevProvider) PublicKey() ([]byte, error) {
+	if err := p.loadKey(); err != nil {
+		return nil, err
+	}
+
+	_, publicKeyStr, err := p.key.EncodeToString()
+	return []byte(publicKeyStr), err
+}
+
+// loadKey decodes the user's private key from the key store on first use.
+func (p *RegevProvider) loadKey() error {
+	if p.key != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.RegevLWE)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	key, err := DecodeRegevPrivateKey(string(privateKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	p.key = key
+	return nil
+}
@@ -0,0 +1,455 @@
+package lattice
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// This file implements Regev's learning-with-errors scheme for teaching. It
+// encrypts one bit per ciphertext and its parameters are far too small to be
+// secure; use MLKEMProvider for real messages.
+//
+// Key generation picks a secret s in Z_q^n, a uniform m×n matrix A and a
+// small error vector e, and publishes (A, b = As + e). A bit μ is encrypted
+// by summing a random subset S of the rows:
+//
+//	u = Σ_{i∈S} a_i,  v = Σ_{i∈S} b_i + μ·⌊q/2⌋
+//
+// Decryption computes v - <u, s> = Σ_{i∈S} e_i + μ·⌊q/2⌋ and rounds: the
+// result is 1 when it is closer to q/2 than to 0. Once the accumulated
+// error reaches q/4 the bit flips, which NoiseDemo shows.
+
+// Noise is the distribution the error terms are drawn from.
+type Noise string
+
+const (
+	// NoiseUniform draws integers uniformly from [-Width, Width].
+	NoiseUniform Noise = "uniform"
+	// NoiseGaussian rounds a normal sample with standard deviation Width.
+	NoiseGaussian Noise = "gaussian"
+)
+
+// MaxModulus bounds Q so every coefficient fits in 32 bits.
+const MaxModulus = 1 << 31
+
+// maxMatrixSize bounds M·N to keep keys a manageable size.
+const maxMatrixSize = 1 << 20
+
+// Params are the LWE dimensions, modulus and error distribution.
+type Params struct {
+	N     int
+	M     int
+	Q     int64
+	Noise Noise
+	Width float64
+}
+
+// Preset is a named parameter set.
+type Preset struct {
+	Name        string
+	Description string
+	Params      Params
+}
+
+// Presets lists the built-in parameter sets. "noisy" uses the dimensions of
+// "small" with errors wide enough that many bits decrypt wrongly.
+var Presets = []Preset{
+	{"toy", "n=4, small enough to follow by hand", Params{N: 4, M: 16, Q: 97, Noise: NoiseUniform, Width: 1}},
+	{"small", "n=64, reliable decryption", Params{N: 64, M: 512, Q: 12289, Noise: NoiseGaussian, Width: 3.2}},
+	{"noisy", "n=64 with too much noise", Params{N: 64, M: 512, Q: 12289, Noise: NoiseUniform, Width: 250}},
+}
+
+// DefaultPreset is used by RegevProvider.GenerateKey.
+const DefaultPreset = "small"
+
+// LookupPreset returns the parameters of a built-in preset.
+func LookupPreset(name string) (Params, error) {
+	for _, preset := range Presets {
+		if preset.Name == name {
+			return preset.Params, nil
+		}
+	}
+	return Params{}, fmt.Errorf("unknown LWE preset: %s", name)
+}
+
+// Validate checks that the parameters can be used at all. It does not check
+// that decryption will be reliable; see FailureProbability.
+func (p Params) Validate() error {
+	if p.N < 1 || p.M < 1 {
+		return errors.New("n and m must be positive")
+	}
+	// Bound each dimension before multiplying, so m·n cannot overflow.
+	if p.N > maxMatrixSize || p.M > maxMatrixSize/p.N {
+		return fmt.Errorf("m·n must be at most %d", maxMatrixSize)
+	}
+	if p.Q < 4 || p.Q >= MaxModulus {
+		return fmt.Errorf("q must be between 4 and %d", MaxModulus-1)
+	}
+	if p.Noise != NoiseUniform && p.Noise != NoiseGaussian {
+		return fmt.Errorf("unknown noise distribution: %s", p.Noise)
+	}
+	// Errors as wide as q are meaningless, and the bound keeps the
+	// conversions in sampleError within int64.
+	if p.Width < 0 || math.IsNaN(p.Width) || p.Width >= float64(p.Q) {
+		return errors.New("noise width must be a non-negative number below q")
+	}
+	return nil
+}
+
+// NoiseStdDev estimates the standard deviation of the error left after
+// decryption, Σ_{i∈S} e_i, where S holds about m/2 rows, averaged over
+// keys.
+func (p Params) NoiseStdDev() float64 {
+	variance := p.Width * p.Width
+	if p.Noise == NoiseUniform {
+		b := math.Floor(p.Width)
+		variance = b * (b + 1) / 3
+	}
+	return math.Sqrt(float64(p.M) / 2 * variance)
+}
+
+// FailureProbability approximates the chance that one bit decrypts wrongly,
+// treating the accumulated error as normal: P(|error| ≥ q/4). Once the error
+// wraps around q the decrypted bit is a coin flip, so it is capped at 1/2.
+func (p Params) FailureProbability() float64 {
+	stdDev := p.NoiseStdDev()
+	if stdDev == 0 {
+		return 0
+	}
+	return math.Min(0.5, math.Erfc(float64(p.Q)/4/(stdDev*math.Sqrt2)))
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf("n=%d, m=%d, q=%d, noise=%s(%g)", p.N, p.M, p.Q, p.Noise, p.Width)
+}
+
+// RegevKey is an LWE key pair. S is nil in public keys.
+type RegevKey struct {
+	Params Params
+	A      [][]int64
+	B      []int64
+	S      []int64
+}
+
+// newRNG returns a generator seeded from crypto/rand. math/rand/v2 is used
+// for its Gaussian sampler.
+func newRNG() *rand.Rand {
+	var seed [32]byte
+	crand.Read(seed[:])
+	return rand.New(rand.NewChaCha8(seed))
+}
+
+// sampleError draws one error term.
+func (p Params) sampleError(rng *rand.Rand) int64 {
+	if p.Noise == NoiseGaussian {
+		return int64(math.Round(rng.NormFloat64() * p.Width))
+	}
+
+	bound := int64(p.Width)
+	return rng.Int64N(2*bound+1) - bound
+}
+
+func mod(x, q int64) int64 {
+	x %= q
+	if x < 0 {
+		x += q
+	}
+	return x
+}
+
+// GenerateRegevKey creates a key pair for the parameters.
+func GenerateRegevKey(params Params) (*RegevKey, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	rng := newRNG()
+	q := params.Q
+
+	s := make([]int64, params.N)
+	for j := range s {
+		s[j] = rng.Int64N(q)
+	}
+
+	a := make([][]int64, params.M)
+	b := make([]int64, params.M)
+	for i := range a {
+		a[i] = make([]int64, params.N)
+		sum := params.sampleError(rng)
+		for j := range a[i] {
+			a[i][j] = rng.Int64N(q)
+			sum = mod(sum+a[i][j]*s[j], q)
+		}
+		b[i] = mod(sum, q)
+	}
+
+	return &RegevKey{Params: params, A: a, B: b, S: s}, nil
+}
+
+// EncryptBit encrypts one bit as (u, v).
+func (key *RegevKey) EncryptBit(bit uint8, rng *rand.Rand) (u []int64, v int64) {
+	q := key.Params.Q
+	u = make([]int64, key.Params.N)
+
+	for i := range key.A {
+		if rng.IntN(2) == 0 {
+			continue
+		}
+		for j := range u {
+			u[j] = mod(u[j]+key.A[i][j], q)
+		}
+		v = mod(v+key.B[i], q)
+	}
+
+	if bit == 1 {
+		v = mod(v+q/2, q)
+	}
+
+	return u, v
+}
+
+// DecryptBit returns the bit (u, v) decrypts to and the raw value
+// v - <u, s> mod q it was rounded from.
+func (key *RegevKey) DecryptBit(u []int64, v int64) (bit uint8, raw int64) {
+	q := key.Params.Q
+
+	raw = v
+	for j := range u {
+		raw = mod(raw-u[j]*key.S[j], q)
+	}
+
+	if raw > q/4 && raw < q-q/4 {
+		bit = 1
+	}
+	return bit, raw
+}
+
+// RegevCiphertextVersion is the current ciphertext format:
+//
+//	version (1 byte) || n (4 bytes) || length (4 bytes) || bits
+//
+// length is the plaintext length in bytes. Each of its 8·length bits, most
+// significant first, is stored as u_1..u_n, v as 4-byte big-endian values.
+const RegevCiphertextVersion = 0x01
+
+const regevHeaderSize = 9
+
+// EncryptRegev encrypts the message bit by bit.
+func EncryptRegev(key *RegevKey, message []byte) ([]byte, error) {
+	n := key.Params.N
+	if uint64(len(message)) > math.MaxUint32/8 {
+		return nil, errors.New("message too long")
+	}
+
+	out := make([]byte, regevHeaderSize, regevHeaderSize+len(message)*8*(n+1)*4)
+	out[0] = RegevCiphertextVersion
+	binary.BigEndian.PutUint32(out[1:5], uint32(n))
+	binary.BigEndian.PutUint32(out[5:9], uint32(len(message)))
+
+	rng := newRNG()
+	for _, c := range message {
+		for i := 7; i >= 0; i-- {
+			u, v := key.EncryptBit((c>>i)&1, rng)
+			for _, x := range u {
+				out = binary.BigEndian.AppendUint32(out, uint32(x))
+			}
+			out = binary.BigEndian.AppendUint32(out, uint32(v))
+		}
+	}
+
+	return out, nil
+}
+
+// DecryptRegev reverses EncryptRegev. With too much noise it returns a
+// message with flipped bits rather than an error: the scheme cannot tell.
+func DecryptRegev(key *RegevKey, data []byte) ([]byte, error) {
+	if len(data) < regevHeaderSize {
+		return nil, errors.New("ciphertext too short")
+	}
+	if data[0] != RegevCiphertextVersion {
+		return nil, fmt.Errorf("unsupported ciphertext version %d", data[0])
+	}
+
+	n := int(binary.BigEndian.Uint32(data[1:5]))
+	length := int(binary.BigEndian.Uint32(data[5:9]))
+	if n != key.Params.N {
+		return nil, errors.New("ciphertext does not match the key's dimension")
+	}
+
+	bitSize := (n + 1) * 4
+	body := data[regevHeaderSize:]
+	if uint64(len(body)) != uint64(length)*8*uint64(bitSize) {
+		return nil, errors.New("ciphertext length does not match its header")
+	}
+
+	message := make([]byte, length)
+	u := make([]int64, n)
+	for i := range message {
+		for b := 7; b >= 0; b-- {
+			chunk := body[:bitSize]
+			body = body[bitSize:]
+
+			for j := range u {
+				u[j] = int64(binary.BigEndian.Uint32(chunk[4*j:]))
+			}
+			v := int64(binary.BigEndian.Uint32(chunk[4*n:]))
+
+			bit, _ := key.DecryptBit(u, v)
+			message[i] |= bit << b
+		}
+	}
+
+	return message, nil
+}
+
+// NoiseDemo generates a key for the parameters, encrypts random bits and
+// counts how many decrypt wrongly.
+func NoiseDemo(params Params, trials int) (failures int, err error) {
+	key, err := GenerateRegevKey(params)
+	if err != nil {
+		return 0, err
+	}
+
+	rng := newRNG()
+	for range trials {
+		bit := uint8(rng.IntN(2))
+		u, v := key.EncryptBit(bit, rng)
+		if decrypted, _ := key.DecryptBit(u, v); decrypted != bit {
+			failures++
+		}
+	}
+
+	return failures, nil
+}
+
+// EncodeToString returns the text encodings of the key:
+//
+//	public:  n,m,q,noise,width;A (row by row);b
+//	private: the public encoding followed by ;s
+func (key *RegevKey) EncodeToString() (privateKey, publicKey string, err error) {
+	if key.S == nil {
+		return "", "", errors.New("key has no secret")
+	}
+
+	p := key.Params
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d,%d,%d,%s,%s;", p.N, p.M, p.Q, p.Noise, strconv.FormatFloat(p.Width, 'g', -1, 64))
+	for i, row := range key.A {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writeValues(&sb, row)
+	}
+	sb.WriteByte(';')
+	writeValues(&sb, key.B)
+
+	publicKey = sb.String()
+	sb.WriteByte(';')
+	writeValues(&sb, key.S)
+
+	return sb.String(), publicKey, nil
+}
+
+func writeValues(sb *strings.Builder, values []int64) {
+	for i, x := range values {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatInt(x, 10))
+	}
+}
+
+func DecodeRegevPublicKey(data string) (*RegevKey, error) {
+	sections := strings.Split(data, ";")
+	if len(sections) != 3 {
+		return nil, errors.New("invalid public key format: expected 3 sections")
+	}
+
+	key, err := decodeRegevSections(sections)
+	if err != nil {
+		return nil, errors.New("invalid public key format: " + err.Error())
+	}
+
+	return key, nil
+}
+
+func DecodeRegevPrivateKey(data string) (*RegevKey, error) {
+	sections := strings.Split(data, ";")
+	if len(sections) != 4 {
+		return nil, errors.New("invalid private key format: expected 4 sections")
+	}
+
+	key, err := decodeRegevSections(sections[:3])
+	if err != nil {
+		return nil, errors.New("invalid private key format: " + err.Error())
+	}
+
+	key.S, err = decodeValues(sections[3], key.Params.N, key.Params.Q)
+	if err != nil {
+		return nil, errors.New("invalid private key format: s: " + err.Error())
+	}
+
+	return key, nil
+}
+
+func decodeRegevSections(sections []string) (*RegevKey, error) {
+	fields := strings.Split(sections[0], ",")
+	if len(fields) != 5 {
+		return nil, errors.New("expected n,m,q,noise,width")
+	}
+
+	n, errN := strconv.Atoi(fields[0])
+	m, errM := strconv.Atoi(fields[1])
+	q, errQ := strconv.ParseInt(fields[2], 10, 64)
+	width, errW := strconv.ParseFloat(fields[4], 64)
+	if err := errors.Join(errN, errM, errQ, errW); err != nil {
+		return nil, err
+	}
+
+	params := Params{N: n, M: m, Q: q, Noise: Noise(fields[3]), Width: width}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries, err := decodeValues(sections[1], m*n, q)
+	if err != nil {
+		return nil, fmt.Errorf("A: %v", err)
+	}
+
+	b, err := decodeValues(sections[2], m, q)
+	if err != nil {
+		return nil, fmt.Errorf("b: %v", err)
+	}
+
+	a := make([][]int64, m)
+	for i := range a {
+		a[i] = entries[i*n : (i+1)*n]
+	}
+
+	return &RegevKey{Params: params, A: a, B: b}, nil
+}
+
+// decodeValues parses count comma-separated values in [0, q).
+func decodeValues(data string, count int, q int64) ([]int64, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != count {
+		return nil, fmt.Errorf("expected %d values", count)
+	}
+
+	values := make([]int64, count)
+	for i, field := range fields {
+		x, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || x < 0 || x >= q {
+			return nil, fmt.Errorf("value %d out of range", i)
+		}
+		values[i] = x
+	}
+
+	return values, nil
+}
@@ -0,0 +1,172 @@
+package lattice
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestRegevRoundTrip(t *testing.T) {
+	for _, name := range []string{"toy", "small"} {
+		t.Run(name, func(t *testing.T) {
+			params, err := LookupPreset(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := GenerateRegevKey(params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			message := []byte("lattices!")
+			ciphertext, err := EncryptRegev(key, message)
+			if err != nil {
+				t.Fatalf("EncryptRegev: %v", err)
+			}
+
+			decrypted, err := DecryptRegev(key, ciphertext)
+			if err != nil {
+				t.Fatalf("DecryptRegev: %v", err)
+			}
+			if !bytes.Equal(decrypted, message) {
+				t.Fatalf("DecryptRegev = %q, want %q", decrypted, message)
+			}
+		})
+	}
+}
+
+func TestRegevKeyEncoding(t *testing.T) {
+	params, err := LookupPreset("toy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := GenerateRegevKey(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, publicKey, err := key.EncodeToString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := DecodeRegevPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("DecodeRegevPublicKey: %v", err)
+	}
+	private, err := DecodeRegevPrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("DecodeRegevPrivateKey: %v", err)
+	}
+
+	ciphertext, err := EncryptRegev(public, []byte{0xa5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := DecryptRegev(private, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, []byte{0xa5}) {
+		t.Fatalf("DecryptRegev = %x, want a5", decrypted)
+	}
+}
+
+func TestRegevRejectsMalformedCiphertexts(t *testing.T) {
+	params, err := LookupPreset("toy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := GenerateRegevKey(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := EncryptRegev(key, []byte("hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modified := func(change func([]byte) []byte) []byte {
+		return change(bytes.Clone(ciphertext))
+	}
+
+	for _, tt := range []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"version", modified(func(d []byte) []byte { d[0]++; return d })},
+		{"dimension", modified(func(d []byte) []byte { d[4]++; return d })},
+		{"length", modified(func(d []byte) []byte { d[8]++; return d })},
+		{"truncated", ciphertext[:len(ciphertext)-1]},
+	} {
+		if _, err := DecryptRegev(key, tt.data); err == nil {
+			t.Errorf("%s: DecryptRegev succeeded", tt.name)
+		}
+	}
+}
+
+func TestNoiseDemo(t *testing.T) {
+	small, err := LookupPreset("small")
+	if err != nil {
+		t.Fatal(err)
+	}
+	noisy, err := LookupPreset("noisy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failures, err := NoiseDemo(small, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failures != 0 {
+		t.Errorf("%d of 1000 bits failed with the small preset", failures)
+	}
+
+	failures, err = NoiseDemo(noisy, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failures < 10 {
+		t.Errorf("only %d of 1000 bits failed with the noisy preset", failures)
+	}
+}
+
+func TestParamsValidate(t *testing.T) {
+	for _, preset := range Presets {
+		if err := preset.Params.Validate(); err != nil {
+			t.Errorf("preset %s: %v", preset.Name, err)
+		}
+	}
+
+	valid, err := LookupPreset("toy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	with := func(change func(*Params)) Params {
+		p := valid
+		change(&p)
+		return p
+	}
+
+	for _, tt := range []struct {
+		name   string
+		params Params
+	}{
+		{"n = 0", with(func(p *Params) { p.N = 0 })},
+		{"m negative", with(func(p *Params) { p.M = -1 })},
+		{"m·n too large", with(func(p *Params) { p.N, p.M = 1024, 1025 })},
+		{"m·n overflows", with(func(p *Params) { p.N, p.M = math.MaxInt/2+1, 2 })},
+		{"q too small", with(func(p *Params) { p.Q = 3 })},
+		{"q too large", with(func(p *Params) { p.Q = MaxModulus })},
+		{"unknown noise", with(func(p *Params) { p.Noise = "laplace" })},
+		{"negative width", with(func(p *Params) { p.Width = -1 })},
+		{"NaN width", with(func(p *Params) { p.Width = math.NaN() })},
+		{"infinite width", with(func(p *Params) { p.Width = math.Inf(1) })},
+		{"width = q", with(func(p *Params) { p.Width = float64(p.Q) })},
+	} {
+		if err := tt.params.Validate(); err == nil {
+			t.Errorf("%s: Validate succeeded", tt.name)
+		}
+	}
+}
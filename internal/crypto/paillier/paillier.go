@@ -0,0 +1,266 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var one = big.NewInt(1)
+
+// PaillierKeyPair is a Paillier key with generator g = N+1. Lambda and Mu are
+// zero in public keys.
+//
+// With g = N+1, encryption is c = (1 + mN) r^N mod N² for a random r, and
+// decryption is m = L(c^λ mod N²) μ mod N where L(x) = (x-1)/N.
+type PaillierKeyPair struct {
+	N      big.Int
+	N2     big.Int
+	Lambda big.Int
+	Mu     big.Int
+}
+
+// MinKeySize is the smallest modulus GeneratePaillierKeyPair accepts.
+const MinKeySize = 512
+
+// GeneratePaillierKeyPair creates a key with a bits-bit modulus from two
+// primes of equal length.
+func GeneratePaillierKeyPair(bits int) (*PaillierKeyPair, error) {
+	if bits < MinKeySize {
+		return nil, fmt.Errorf("key size must be at least %d bits", MinKeySize)
+	}
+
+	for {
+		p, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := rand.Prime(rand.Reader, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPair, err := CreatePaillierKeyPair(p, q)
+		if err != nil || keyPair.N.BitLen() != bits {
+			continue
+		}
+
+		return keyPair, nil
+	}
+}
+
+// CreatePaillierKeyPair builds a key from two distinct primes with
+// gcd(pq, (p-1)(q-1)) = 1, which holds for primes of equal length.
+func CreatePaillierKeyPair(p, q *big.Int) (*PaillierKeyPair, error) {
+	if !p.ProbablyPrime(20) || !q.ProbablyPrime(20) {
+		return nil, errors.New("p and q must be prime")
+	}
+	if p.Cmp(q) == 0 {
+		return nil, errors.New("p and q must be different")
+	}
+
+	n := new(big.Int).Mul(p, q)
+	pMinus1 := new(big.Int).Sub(p, one)
+	qMinus1 := new(big.Int).Sub(q, one)
+	phi := new(big.Int).Mul(pMinus1, qMinus1)
+
+	if new(big.Int).GCD(nil, nil, n, phi).Cmp(one) != 0 {
+		return nil, errors.New("gcd(pq, (p-1)(q-1)) must be 1")
+	}
+
+	// λ = lcm(p-1, q-1)
+	gcd := new(big.Int).GCD(nil, nil, pMinus1, qMinus1)
+	lambda := new(big.Int).Div(phi, gcd)
+
+	return newKeyPair(n, lambda)
+}
+
+// newKeyPair computes N² and μ = λ⁻¹ mod N, which is L(g^λ mod N²)⁻¹ for
+// g = N+1.
+func newKeyPair(n, lambda *big.Int) (*PaillierKeyPair, error) {
+	kp := &PaillierKeyPair{}
+	kp.N.Set(n)
+	kp.N2.Mul(n, n)
+
+	if lambda != nil {
+		if kp.Mu.ModInverse(lambda, n) == nil {
+			return nil, errors.New("λ is not invertible mod N")
+		}
+		kp.Lambda.Set(lambda)
+	}
+
+	return kp, nil
+}
+
+// Encrypt encrypts m in [0, N) with a fresh random r.
+func (kp *PaillierKeyPair) Encrypt(m *big.Int) (*big.Int, error) {
+	if m.Sign() < 0 || m.Cmp(&kp.N) >= 0 {
+		return nil, errors.New("message must be in [0, N)")
+	}
+
+	r, err := kp.randomUnit()
+	if err != nil {
+		return nil, err
+	}
+
+	// (1 + mN) r^N mod N²
+	c := new(big.Int).Mul(m, &kp.N)
+	c.Add(c, one)
+	c.Mul(c, r.Exp(r, &kp.N, &kp.N2))
+	return c.Mod(c, &kp.N2), nil
+}
+
+// randomUnit returns r in [1, N) with gcd(r, N) = 1.
+func (kp *PaillierKeyPair) randomUnit() (*big.Int, error) {
+	gcd := new(big.Int)
+	for {
+		r, err := rand.Int(rand.Reader, &kp.N)
+		if err != nil {
+			return nil, err
+		}
+		if r.Sign() > 0 && gcd.GCD(nil, nil, r, &kp.N).Cmp(one) == 0 {
+			return r, nil
+		}
+	}
+}
+
+// Decrypt returns m = L(c^λ mod N²) μ mod N.
+func (kp *PaillierKeyPair) Decrypt(c *big.Int) (*big.Int, error) {
+	if kp.Lambda.Sign() == 0 {
+		return nil, errors.New("private key not available")
+	}
+	if err := kp.checkCiphertext(c); err != nil {
+		return nil, err
+	}
+
+	u := new(big.Int).Exp(c, &kp.Lambda, &kp.N2)
+	u.Sub(u, one)
+	u.Div(u, &kp.N)
+	u.Mul(u, &kp.Mu)
+	return u.Mod(u, &kp.N), nil
+}
+
+func (kp *PaillierKeyPair) checkCiphertext(c *big.Int) error {
+	if c.Sign() <= 0 || c.Cmp(&kp.N2) >= 0 {
+		return errors.New("ciphertext must be in (0, N²)")
+	}
+	return nil
+}
+
+// Add returns a ciphertext of m1 + m2 mod N given ciphertexts of m1 and m2:
+// c1·c2 mod N².
+func (kp *PaillierKeyPair) Add(c1, c2 *big.Int) (*big.Int, error) {
+	if err := kp.checkCiphertext(c1); err != nil {
+		return nil, err
+	}
+	if err := kp.checkCiphertext(c2); err != nil {
+		return nil, err
+	}
+
+	c := new(big.Int).Mul(c1, c2)
+	return c.Mod(c, &kp.N2), nil
+}
+
+// MulConst returns a ciphertext of k·m mod N given a ciphertext of m:
+// c^k mod N².
+func (kp *PaillierKeyPair) MulConst(c, k *big.Int) (*big.Int, error) {
+	if err := kp.checkCiphertext(c); err != nil {
+		return nil, err
+	}
+	if k.Sign() < 0 {
+		return nil, errors.New("constant must not be negative")
+	}
+
+	return new(big.Int).Exp(c, k, &kp.N2), nil
+}
+
+// Sum adds encoded ciphertexts under the public key, returning the encoded
+// ciphertext of the total. It never needs the private key, so the server can
+// tally votes it cannot read.
+func Sum(publicKey string, ciphertexts [][]byte) ([]byte, error) {
+	kp, err := DecodePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertexts) == 0 {
+		return nil, errors.New("nothing to add")
+	}
+
+	total, err := kp.DecodeCiphertext(ciphertexts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range ciphertexts[1:] {
+		c, err := kp.DecodeCiphertext(data)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err = kp.Add(total, c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return kp.EncodeCiphertext(total), nil
+}
+
+// EncodeCiphertext left-pads c to the byte length of N².
+func (kp *PaillierKeyPair) EncodeCiphertext(c *big.Int) []byte {
+	return c.FillBytes(make([]byte, (kp.N2.BitLen()+7)/8))
+}
+
+func (kp *PaillierKeyPair) DecodeCiphertext(data []byte) (*big.Int, error) {
+	if len(data) != (kp.N2.BitLen()+7)/8 {
+		return nil, errors.New("ciphertext length does not match the key")
+	}
+
+	c := new(big.Int).SetBytes(data)
+	if err := kp.checkCiphertext(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// EncodeToString returns the text encodings: private "N,Lambda", public "N".
+func (kp *PaillierKeyPair) EncodeToString() (privateKey, publicKey string, err error) {
+	publicKey = kp.N.String()
+	privateKey = fmt.Sprintf("%s,%s", kp.N.String(), kp.Lambda.String())
+	return privateKey, publicKey, nil
+}
+
+func DecodePrivateKey(data string) (*PaillierKeyPair, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 2 {
+		return nil, errors.New("invalid private key format: expected N,Lambda")
+	}
+
+	n, ok := new(big.Int).SetString(fields[0], 10)
+	if !ok || n.Cmp(one) <= 0 {
+		return nil, errors.New("invalid private key format: invalid N value")
+	}
+
+	lambda, ok := new(big.Int).SetString(fields[1], 10)
+	if !ok || lambda.Sign() <= 0 {
+		return nil, errors.New("invalid private key format: invalid Lambda value")
+	}
+
+	return newKeyPair(n, lambda)
+}
+
+func DecodePublicKey(data string) (*PaillierKeyPair, error) {
+	n, ok := new(big.Int).SetString(data, 10)
+	if !ok || n.Cmp(one) <= 0 {
+		return nil, errors.New("invalid public key format: invalid N value")
+	}
+	if n.Bit(0) == 0 {
+		return nil, errors.New("N must be odd")
+	}
+
+	return newKeyPair(n, nil)
+}
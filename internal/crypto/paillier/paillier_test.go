@@ -0,0 +1,192 @@
+package paillier
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	mrand "math/rand/v2"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+func newTestProvider(t *testing.T) *PaillierProvider {
+	t.Helper()
+
+	provider := NewPaillierProvider(keystore.NewClientKeyStore("alice"), "alice")
+	if err := provider.GenerateKeyPair(MinKeySize); err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	keyPair, err := GeneratePaillierKeyPair(MinKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nMinus1 := new(big.Int).Sub(&keyPair.N, one)
+
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), nMinus1}
+	for i := 0; i < 50; i++ {
+		m, err := rand.Int(rand.Reader, &keyPair.N)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, m)
+	}
+
+	for _, m := range values {
+		c, err := keyPair.Encrypt(m)
+		if err != nil {
+			t.Fatalf("Encrypt(%s): %v", m, err)
+		}
+		decrypted, err := keyPair.Decrypt(c)
+		if err != nil {
+			t.Fatalf("Decrypt: %v", err)
+		}
+		if decrypted.Cmp(m) != 0 {
+			t.Fatalf("Decrypt(Encrypt(%s)) = %s", m, decrypted)
+		}
+	}
+
+	for _, m := range []*big.Int{big.NewInt(-1), &keyPair.N} {
+		if _, err := keyPair.Encrypt(m); err == nil {
+			t.Errorf("Encrypt(%s) succeeded", m)
+		}
+	}
+}
+
+func TestHomomorphism(t *testing.T) {
+	keyPair, err := GeneratePaillierKeyPair(MinKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 20; i++ {
+		m1, m2, k := big.NewInt(mrand.Int64()), big.NewInt(mrand.Int64()), big.NewInt(mrand.Int64N(1000))
+
+		c1, err := keyPair.Encrypt(m1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c2, err := keyPair.Encrypt(m2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum, err := keyPair.Add(c1, c2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err := keyPair.Decrypt(sum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := new(big.Int).Add(m1, m2); decrypted.Cmp(want) != 0 {
+			t.Fatalf("Add decrypts to %s, want %s", decrypted, want)
+		}
+
+		product, err := keyPair.MulConst(c1, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err = keyPair.Decrypt(product)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := new(big.Int).Mul(m1, k); decrypted.Cmp(want) != 0 {
+			t.Fatalf("MulConst decrypts to %s, want %s", decrypted, want)
+		}
+	}
+}
+
+func TestSumAddsVotes(t *testing.T) {
+	provider := newTestProvider(t)
+	publicKey, err := provider.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ciphertexts [][]byte
+	var want int64
+	for i := 0; i < 100; i++ {
+		vote := mrand.Int64N(2)
+		want += vote
+
+		ciphertext, err := provider.EncryptValue(big.NewInt(vote), "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ciphertexts = append(ciphertexts, ciphertext)
+	}
+
+	sum, err := Sum(string(publicKey), ciphertexts)
+	if err != nil {
+		t.Fatalf("Sum: %v", err)
+	}
+
+	total, err := provider.DecryptValue(sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.Int64() != want {
+		t.Fatalf("sum decrypts to %s, want %d", total, want)
+	}
+}
+
+func TestRejectsMalformedCiphertexts(t *testing.T) {
+	provider := newTestProvider(t)
+	publicKey, err := provider.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := provider.EncryptValue(big.NewInt(1), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		ciphertext []byte
+	}{
+		{"zero", make([]byte, len(valid))},
+		{"not below N²", bytes.Repeat([]byte{0xff}, len(valid))},
+		{"truncated", valid[1:]},
+		{"too long", append([]byte{0}, valid...)},
+	} {
+		if _, err := provider.DecryptValue(tt.ciphertext); err == nil {
+			t.Errorf("%s: DecryptValue succeeded", tt.name)
+		}
+		if _, err := Sum(string(publicKey), [][]byte{valid, tt.ciphertext}); err == nil {
+			t.Errorf("%s: Sum succeeded", tt.name)
+		}
+	}
+
+	if _, err := Sum(string(publicKey), nil); err == nil {
+		t.Error("Sum of no ciphertexts succeeded")
+	}
+
+	// Paillier is malleable, so a flipped bit cannot be detected, but it
+	// must not decrypt to the original vote.
+	flipped := bytes.Clone(valid)
+	flipped[len(flipped)-1] ^= 0x01
+	if m, err := provider.DecryptValue(flipped); err == nil && m.Cmp(one) == 0 {
+		t.Error("a tampered ciphertext decrypted to the original value")
+	}
+}
+
+func TestCreatePaillierKeyPair(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		p, q int64
+	}{
+		{"equal primes", 1009, 1009},
+		{"composite", 1009, 1011},
+	} {
+		if _, err := CreatePaillierKeyPair(big.NewInt(tt.p), big.NewInt(tt.q)); err == nil {
+			t.Errorf("%s: CreatePaillierKeyPair succeeded", tt.name)
+		}
+	}
+}
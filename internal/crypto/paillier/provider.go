@@ -0,0 +1,152 @@
+package paillier
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// DefaultKeySize is the modulus size used by GenerateKey.
+const DefaultKeySize = 2048
+
+type PaillierProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+	keyPair  *PaillierKeyPair
+}
+
+func NewPaillierProvider(keyStore crypto.KeyStore, userID string) *PaillierProvider {
+	return &PaillierProvider{
+		keyStore: keyStore,
+		userID:   userID,
+		keyPair:  nil,
+	}
+}
+
+var _ crypto.Provider = (*PaillierProvider)(nil)
+
+func (p *PaillierProvider) GenerateKey() error {
+	return p.GenerateKeyPair(DefaultKeySize)
+}
+
+func (p *PaillierProvider) GenerateKeyPair(bits int) error {
+	keyPair, err := GeneratePaillierKeyPair(bits)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *PaillierProvider) StoreKeyPair(primeP, primeQ big.Int) error {
+	keyPair, err := CreatePaillierKeyPair(&primeP, &primeQ)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *PaillierProvider) storeKeyPair(keyPair *PaillierKeyPair) error {
+	p.keyPair = keyPair
+
+	privateKeyStr, publicKeyStr, err := keyPair.EncodeToString()
+	if err != nil {
+		return err
+	}
+
+	err = p.keyStore.StorePrivateKey(crypto.Paillier, []byte(privateKeyStr))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.Paillier, []byte(publicKeyStr))
+}
+
+func (p *PaillierProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodePublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.Paillier, publicKeyData)
+}
+
+// Encrypt encrypts the message as one integer, so it must be shorter than
+// the recipient's modulus.
+func (p *PaillierProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	return p.EncryptValue(new(big.Int).SetBytes(message), recipientID)
+}
+
+// EncryptValue encrypts a number in [0, N) for the recipient, for example a
+// vote to be added up by the server.
+func (p *PaillierProvider) EncryptValue(value *big.Int, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.Paillier)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientKey, err := DecodePublicKey(string(recipientKeyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := recipientKey.Encrypt(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipientKey.EncodeCiphertext(c), nil
+}
+
+func (p *PaillierProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	m, err := p.DecryptValue(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Bytes(), nil
+}
+
+// DecryptValue decrypts a ciphertext to the number it holds.
+func (p *PaillierProvider) DecryptValue(ciphertext []byte) (*big.Int, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	c, err := p.keyPair.DecodeCiphertext(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.keyPair.Decrypt(c)
+}
+
+func (p *PaillierProvider) PublicKey() ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	_, publicKeyStr, err := p.keyPair.EncodeToString()
+	return []byte(publicKeyStr), err
+}
+
+// loadKeyPair decodes the user's private key from the key store on first use.
+func (p *PaillierProvider) loadKeyPair() error {
+	if p.keyPair != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.Paillier)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	keyPair, err := DecodePrivateKey(string(privateKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	p.keyPair = keyPair
+	return nil
+}
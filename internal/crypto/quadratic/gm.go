@@ -0,0 +1,246 @@
+package quadratic
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// MaxGMMessageSize bounds Goldwasser–Micali messages. Every bit becomes a
+// number mod N, so a byte costs eight modulus-sized blocks.
+const MaxGMMessageSize = 1024
+
+var errGMDecryption = errors.New("goldwasser-micali: decryption error")
+
+// GMKeyPair is a Goldwasser–Micali key: N = P·Q and a pseudosquare X, a
+// number with Jacobi symbol (X/N) = 1 that is not a square mod N. P and Q
+// are nil in public keys.
+//
+// A bit b is encrypted as c = y²·X^b mod N for a random y, so zeros are
+// squares and ones are not. Both have Jacobi symbol 1, so telling them
+// apart without P or Q is the quadratic residuosity problem; with P it is
+// the Legendre symbol (c/P).
+type GMKeyPair struct {
+	N *big.Int
+	X *big.Int
+	P *big.Int
+	Q *big.Int
+}
+
+// GenerateGMKeyPair creates a key with a bits-bit modulus from two primes of
+// equal length.
+func GenerateGMKeyPair(bits int) (*GMKeyPair, error) {
+	if bits < MinKeySize {
+		return nil, fmt.Errorf("key size must be at least %d bits", MinKeySize)
+	}
+
+	for {
+		p, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := rand.Prime(rand.Reader, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPair, err := CreateGMKeyPair(p, q)
+		if err != nil || keyPair.N.BitLen() != bits {
+			continue
+		}
+
+		return keyPair, nil
+	}
+}
+
+// CreateGMKeyPair builds a key from two distinct odd primes and picks a
+// random X that is a non-residue mod both of them.
+func CreateGMKeyPair(p, q *big.Int) (*GMKeyPair, error) {
+	if !p.ProbablyPrime(20) || !q.ProbablyPrime(20) {
+		return nil, errors.New("p and q must be prime")
+	}
+	if p.Cmp(q) == 0 {
+		return nil, errors.New("p and q must be different")
+	}
+	if p.Bit(0) == 0 || q.Bit(0) == 0 {
+		return nil, errors.New("p and q must be odd")
+	}
+
+	n := new(big.Int).Mul(p, q)
+	for {
+		x, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return nil, err
+		}
+
+		if big.Jacobi(x, p) == -1 && big.Jacobi(x, q) == -1 {
+			return &GMKeyPair{
+				N: n,
+				X: x,
+				P: new(big.Int).Set(p),
+				Q: new(big.Int).Set(q),
+			}, nil
+		}
+	}
+}
+
+// size returns the length of the modulus in bytes.
+func (kp *GMKeyPair) size() int {
+	return (kp.N.BitLen() + 7) / 8
+}
+
+// Encrypt encrypts the message bit by bit, most significant bit first, each
+// as one size()-byte block.
+func (kp *GMKeyPair) Encrypt(message []byte) ([]byte, error) {
+	if len(message) > MaxGMMessageSize {
+		return nil, fmt.Errorf("message too long: at most %d bytes", MaxGMMessageSize)
+	}
+
+	size := kp.size()
+	ciphertext := make([]byte, 8*len(message)*size)
+	for i, b := range message {
+		for j := 0; j < 8; j++ {
+			c, err := kp.EncryptBit(uint(b>>(7-j)) & 1)
+			if err != nil {
+				return nil, err
+			}
+
+			offset := (8*i + j) * size
+			c.FillBytes(ciphertext[offset : offset+size])
+		}
+	}
+
+	return ciphertext, nil
+}
+
+// EncryptBit returns y²·X^bit mod N for a random unit y.
+func (kp *GMKeyPair) EncryptBit(bit uint) (*big.Int, error) {
+	if bit > 1 {
+		return nil, errors.New("bit must be 0 or 1")
+	}
+
+	y, err := kp.randomUnit()
+	if err != nil {
+		return nil, err
+	}
+
+	c := y.Mul(y, y)
+	if bit == 1 {
+		c.Mul(c, kp.X)
+	}
+	return c.Mod(c, kp.N), nil
+}
+
+// randomUnit returns y in [1, N) with gcd(y, N) = 1.
+func (kp *GMKeyPair) randomUnit() (*big.Int, error) {
+	gcd := new(big.Int)
+	for {
+		y, err := rand.Int(rand.Reader, kp.N)
+		if err != nil {
+			return nil, err
+		}
+		if y.Sign() > 0 && gcd.GCD(nil, nil, y, kp.N).Cmp(one) == 0 {
+			return y, nil
+		}
+	}
+}
+
+func (kp *GMKeyPair) Decrypt(ciphertext []byte) ([]byte, error) {
+	if kp.P == nil || kp.Q == nil {
+		return nil, errors.New("private key not available")
+	}
+
+	size := kp.size()
+	if len(ciphertext) == 0 || len(ciphertext)%(8*size) != 0 {
+		return nil, errGMDecryption
+	}
+
+	message := make([]byte, len(ciphertext)/(8*size))
+	for i := range message {
+		for j := 0; j < 8; j++ {
+			offset := (8*i + j) * size
+			bit, err := kp.DecryptBit(new(big.Int).SetBytes(ciphertext[offset : offset+size]))
+			if err != nil {
+				return nil, err
+			}
+
+			message[i] |= byte(bit) << (7 - j)
+		}
+	}
+
+	return message, nil
+}
+
+// DecryptBit returns 0 if c is a square mod P and 1 otherwise. Values that
+// no encryption produces, those with Jacobi symbol (c/N) ≠ 1, are rejected.
+func (kp *GMKeyPair) DecryptBit(c *big.Int) (uint, error) {
+	if c.Sign() <= 0 || c.Cmp(kp.N) >= 0 || big.Jacobi(c, kp.N) != 1 {
+		return 0, errGMDecryption
+	}
+
+	if big.Jacobi(c, kp.P) == 1 {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// EncodeToString returns the text encodings: private "P,Q,X", public "N,X".
+func (kp *GMKeyPair) EncodeToString() (privateKey, publicKey string, err error) {
+	if kp.P == nil || kp.Q == nil {
+		return "", "", errors.New("p and q are required to encode the private key")
+	}
+
+	publicKey = fmt.Sprintf("%s,%s", kp.N.String(), kp.X.String())
+	privateKey = fmt.Sprintf("%s,%s,%s", kp.P.String(), kp.Q.String(), kp.X.String())
+	return privateKey, publicKey, nil
+}
+
+func DecodeGMPrivateKey(data string) (*GMKeyPair, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 3 {
+		return nil, errors.New("invalid private key format: expected P,Q,X")
+	}
+
+	values, err := parseValues(fields, "P", "Q", "X")
+	if err != nil {
+		return nil, err
+	}
+	p, q, x := values[0], values[1], values[2]
+
+	if p.Bit(0) == 0 || q.Bit(0) == 0 {
+		return nil, errors.New("invalid private key: P and Q must be odd")
+	}
+	if big.Jacobi(x, p) != -1 || big.Jacobi(x, q) != -1 {
+		return nil, errors.New("invalid private key: X must be a non-residue mod P and Q")
+	}
+
+	return &GMKeyPair{N: new(big.Int).Mul(p, q), X: x, P: p, Q: q}, nil
+}
+
+// DecodeGMPublicKey checks what can be checked without the factors: N is odd
+// and (X/N) = 1. Whether X really is a non-square cannot be checked, which is
+// the point of the scheme.
+func DecodeGMPublicKey(data string) (*GMKeyPair, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 2 {
+		return nil, errors.New("invalid public key format: expected N,X")
+	}
+
+	values, err := parseValues(fields, "N", "X")
+	if err != nil {
+		return nil, err
+	}
+	n, x := values[0], values[1]
+
+	if n.Cmp(one) <= 0 || n.Bit(0) == 0 {
+		return nil, errors.New("N must be odd and greater than 1")
+	}
+	if x.Sign() <= 0 || x.Cmp(n) >= 0 || big.Jacobi(x, n) != 1 {
+		return nil, errors.New("X must be in (0, N) with Jacobi symbol 1")
+	}
+
+	return &GMKeyPair{N: n, X: x}, nil
+}
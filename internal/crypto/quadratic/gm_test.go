@@ -0,0 +1,151 @@
+package quadratic
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+func TestGMRoundTrip(t *testing.T) {
+	keyPair, err := GenerateGMKeyPair(MinKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, message := range [][]byte{{0}, {0xff}, []byte("hello"), bytes.Repeat([]byte{0xa5}, 64)} {
+		ciphertext, err := keyPair.Encrypt(message)
+		if err != nil {
+			t.Fatalf("Encrypt(%x): %v", message, err)
+		}
+
+		decrypted, err := keyPair.Decrypt(ciphertext)
+		if err != nil {
+			t.Fatalf("Decrypt of %x: %v", message, err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("Decrypt(Encrypt(%x)) = %x", message, decrypted)
+		}
+	}
+
+	if _, err := keyPair.Encrypt(make([]byte, MaxGMMessageSize+1)); err == nil {
+		t.Fatal("Encrypt accepted a message longer than MaxGMMessageSize")
+	}
+}
+
+func TestGMBitsAreHomomorphic(t *testing.T) {
+	keyPair, err := GenerateGMKeyPair(MinKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The product of two ciphertexts encrypts the XOR of their bits.
+	for _, bits := range [][2]uint{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+		c1, err := keyPair.EncryptBit(bits[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		c2, err := keyPair.EncryptBit(bits[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		product := new(big.Int).Mul(c1, c2)
+		bit, err := keyPair.DecryptBit(product.Mod(product, keyPair.N))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bit != bits[0]^bits[1] {
+			t.Fatalf("%d XOR %d decrypts to %d", bits[0], bits[1], bit)
+		}
+	}
+
+	if _, err := keyPair.EncryptBit(2); err == nil {
+		t.Fatal("EncryptBit(2) succeeded")
+	}
+}
+
+func TestGMRejectsMalformedCiphertexts(t *testing.T) {
+	keyPair, err := GenerateGMKeyPair(MinKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ciphertext, err := keyPair.Encrypt([]byte("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := keyPair.size()
+
+	// No encryption produces 0 or a value with Jacobi symbol -1 mod N.
+	var nonResidue *big.Int
+	for x := int64(2); nonResidue == nil; x++ {
+		if big.Jacobi(big.NewInt(x), keyPair.N) == -1 {
+			nonResidue = big.NewInt(x)
+		}
+	}
+
+	replace := func(block *big.Int) []byte {
+		tampered := bytes.Clone(ciphertext)
+		block.FillBytes(tampered[:size])
+		return tampered
+	}
+
+	for _, tt := range []struct {
+		name       string
+		ciphertext []byte
+	}{
+		{"empty", nil},
+		{"truncated", ciphertext[1:]},
+		{"zero block", replace(big.NewInt(0))},
+		{"Jacobi symbol -1", replace(nonResidue)},
+	} {
+		if _, err := keyPair.Decrypt(tt.ciphertext); err == nil {
+			t.Errorf("%s: Decrypt succeeded", tt.name)
+		}
+	}
+}
+
+func TestProvidersRoundTrip(t *testing.T) {
+	type provider interface {
+		GenerateKeyPair(bits int) error
+		PublicKey() ([]byte, error)
+		ParsePublicKey(userID string, publicKeyData []byte) error
+		Encrypt(message []byte, recipientID string) ([]byte, error)
+		Decrypt(ciphertext []byte) ([]byte, error)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		alice, bob provider
+	}{
+		{"Rabin", NewRabinProvider(keystore.NewClientKeyStore("alice"), "alice"), NewRabinProvider(keystore.NewClientKeyStore("bob"), "bob")},
+		{"GM", NewGMProvider(keystore.NewClientKeyStore("alice"), "alice"), NewGMProvider(keystore.NewClientKeyStore("bob"), "bob")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.bob.GenerateKeyPair(MinKeySize); err != nil {
+				t.Fatal(err)
+			}
+			publicKey, err := tt.bob.PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.alice.ParsePublicKey("bob", publicKey); err != nil {
+				t.Fatalf("ParsePublicKey: %v", err)
+			}
+
+			ciphertext, err := tt.alice.Encrypt([]byte("hi bob"), "bob")
+			if err != nil {
+				t.Fatalf("Encrypt: %v", err)
+			}
+			decrypted, err := tt.bob.Decrypt(ciphertext)
+			if err != nil {
+				t.Fatalf("Decrypt: %v", err)
+			}
+			if string(decrypted) != "hi bob" {
+				t.Fatalf("Decrypt = %q", decrypted)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package quadratic
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// Algorithms lists the schemes this package provides.
+var Algorithms = []crypto.Algorithm{crypto.Rabin, crypto.GoldwasserMicali}
+
+// DefaultKeySize is the modulus size used by GenerateKey.
+const DefaultKeySize = 2048
+
+type RabinProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+	keyPair  *RabinKeyPair
+}
+
+func NewRabinProvider(keyStore crypto.KeyStore, userID string) *RabinProvider {
+	return &RabinProvider{
+		keyStore: keyStore,
+		userID:   userID,
+		keyPair:  nil,
+	}
+}
+
+var _ crypto.Provider = (*RabinProvider)(nil)
+
+func (p *RabinProvider) GenerateKey() error {
+	return p.GenerateKeyPair(DefaultKeySize)
+}
+
+func (p *RabinProvider) GenerateKeyPair(bits int) error {
+	keyPair, err := GenerateRabinKeyPair(bits)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *RabinProvider) StoreKeyPair(primeP, primeQ big.Int) error {
+	keyPair, err := CreateRabinKeyPair(&primeP, &primeQ)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *RabinProvider) storeKeyPair(keyPair *RabinKeyPair) error {
+	p.keyPair = keyPair
+
+	privateKeyStr, publicKeyStr, err := keyPair.EncodeToString()
+	if err != nil {
+		return err
+	}
+
+	err = p.keyStore.StorePrivateKey(crypto.Rabin, []byte(privateKeyStr))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.Rabin, []byte(publicKeyStr))
+}
+
+func (p *RabinProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodeRabinPublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.Rabin, publicKeyData)
+}
+
+// Encrypt encrypts the message as one integer, so it must fit in
+// MaxMessageSize bytes of the recipient's key.
+func (p *RabinProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.Rabin)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientKey, err := DecodeRabinPublicKey(string(recipientKeyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return recipientKey.Encrypt(message)
+}
+
+func (p *RabinProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	return p.keyPair.Decrypt(ciphertext)
+}
+
+func (p *RabinProvider) PublicKey() ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	_, publicKeyStr, err := p.keyPair.EncodeToString()
+	return []byte(publicKeyStr), err
+}
+
+// loadKeyPair decodes the user's private key from the key store on first use.
+func (p *RabinProvider) loadKeyPair() error {
+	if p.keyPair != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.Rabin)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	keyPair, err := DecodeRabinPrivateKey(string(privateKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	p.keyPair = keyPair
+	return nil
+}
+
+// GMProvider encrypts with Goldwasser–Micali. Ciphertexts hold one
+// modulus-sized block per message bit, so messages are limited to
+// MaxGMMessageSize bytes.
+type GMProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+	keyPair  *GMKeyPair
+}
+
+func NewGMProvider(keyStore crypto.KeyStore, userID string) *GMProvider {
+	return &GMProvider{
+		keyStore: keyStore,
+		userID:   userID,
+		keyPair:  nil,
+	}
+}
+
+var _ crypto.Provider = (*GMProvider)(nil)
+
+func (p *GMProvider) GenerateKey() error {
+	return p.GenerateKeyPair(DefaultKeySize)
+}
+
+func (p *GMProvider) GenerateKeyPair(bits int) error {
+	keyPair, err := GenerateGMKeyPair(bits)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *GMProvider) StoreKeyPair(primeP, primeQ big.Int) error {
+	keyPair, err := CreateGMKeyPair(&primeP, &primeQ)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *GMProvider) storeKeyPair(keyPair *GMKeyPair) error {
+	p.keyPair = keyPair
+
+	privateKeyStr, publicKeyStr, err := keyPair.EncodeToString()
+	if err != nil {
+		return err
+	}
+
+	err = p.keyStore.StorePrivateKey(crypto.GoldwasserMicali, []byte(privateKeyStr))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.GoldwasserMicali, []byte(publicKeyStr))
+}
+
+func (p *GMProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodeGMPublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.GoldwasserMicali, publicKeyData)
+}
+
+func (p *GMProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.GoldwasserMicali)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientKey, err := DecodeGMPublicKey(string(recipientKeyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return recipientKey.Encrypt(message)
+}
+
+func (p *GMProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	return p.keyPair.Decrypt(ciphertext)
+}
+
+func (p *GMProvider) PublicKey() ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	_, publicKeyStr, err := p.keyPair.EncodeToString()
+	return []byte(publicKeyStr), err
+}
+
+// loadKeyPair decodes the user's private key from the key store on first use.
+func (p *GMProvider) loadKeyPair() error {
+	if p.keyPair != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.GoldwasserMicali)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	keyPair, err := DecodeGMPrivateKey(string(privateKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	p.keyPair = keyPair
+	return nil
+}
@@ -0,0 +1,266 @@
+package quadratic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	one   = big.NewInt(1)
+	three = big.NewInt(3)
+	four  = big.NewInt(4)
+)
+
+// MinKeySize is the smallest modulus the key generators accept.
+const MinKeySize = 512
+
+// TagSize is the number of SHA-256 bytes Rabin appends to each message.
+// Decryption yields four square roots and keeps the one whose tag matches;
+// a wrong root passes with probability 2^-64.
+const TagSize = 8
+
+// rabinMarker starts every encoded message so leading zero bytes of the
+// message survive the trip through an integer.
+const rabinMarker = 0x01
+
+var errRabinDecryption = errors.New("rabin: decryption error")
+
+// RabinKeyPair is a Rabin key: N = P·Q with P ≡ Q ≡ 3 (mod 4), so square
+// roots mod each prime are a single exponentiation. P and Q are nil in
+// public keys.
+//
+// Encryption is c = m² mod N. It is as hard to invert as factoring N, but
+// every c has four square roots, so the message carries redundancy to pick
+// the right one.
+type RabinKeyPair struct {
+	N *big.Int
+	P *big.Int
+	Q *big.Int
+}
+
+// GenerateRabinKeyPair creates a key with a bits-bit modulus from two
+// primes of equal length that are both 3 mod 4.
+func GenerateRabinKeyPair(bits int) (*RabinKeyPair, error) {
+	if bits < MinKeySize {
+		return nil, fmt.Errorf("key size must be at least %d bits", MinKeySize)
+	}
+
+	for {
+		p, err := blumPrime(bits / 2)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := blumPrime(bits - bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPair, err := CreateRabinKeyPair(p, q)
+		if err != nil || keyPair.N.BitLen() != bits {
+			continue
+		}
+
+		return keyPair, nil
+	}
+}
+
+// blumPrime returns a random bits-bit prime p with p ≡ 3 (mod 4).
+func blumPrime(bits int) (*big.Int, error) {
+	for {
+		p, err := rand.Prime(rand.Reader, bits)
+		if err != nil {
+			return nil, err
+		}
+		if isBlum(p) {
+			return p, nil
+		}
+	}
+}
+
+func isBlum(p *big.Int) bool {
+	return new(big.Int).Mod(p, four).Cmp(three) == 0
+}
+
+// CreateRabinKeyPair builds a key from two distinct primes that are both
+// 3 mod 4.
+func CreateRabinKeyPair(p, q *big.Int) (*RabinKeyPair, error) {
+	if !p.ProbablyPrime(20) || !q.ProbablyPrime(20) {
+		return nil, errors.New("p and q must be prime")
+	}
+	if p.Cmp(q) == 0 {
+		return nil, errors.New("p and q must be different")
+	}
+	if !isBlum(p) || !isBlum(q) {
+		return nil, errors.New("p and q must be 3 mod 4")
+	}
+
+	return &RabinKeyPair{
+		N: new(big.Int).Mul(p, q),
+		P: new(big.Int).Set(p),
+		Q: new(big.Int).Set(q),
+	}, nil
+}
+
+// size returns the length of the modulus in bytes.
+func (kp *RabinKeyPair) size() int {
+	return (kp.N.BitLen() + 7) / 8
+}
+
+// MaxMessageSize returns the longest message Encrypt accepts: the encoded
+// message 0x01 || message || tag must be shorter than N.
+func (kp *RabinKeyPair) MaxMessageSize() int {
+	return kp.size() - 2 - TagSize
+}
+
+// Encrypt returns c = m² mod N for m = 0x01 || message || tag.
+func (kp *RabinKeyPair) Encrypt(message []byte) ([]byte, error) {
+	if kp.MaxMessageSize() < 0 {
+		return nil, errors.New("modulus too small to hold the tag")
+	}
+	if len(message) > kp.MaxMessageSize() {
+		return nil, fmt.Errorf("message too long: at most %d bytes", kp.MaxMessageSize())
+	}
+
+	encoded := make([]byte, 0, 1+len(message)+TagSize)
+	encoded = append(encoded, rabinMarker)
+	encoded = append(encoded, message...)
+	encoded = append(encoded, rabinTag(message)...)
+
+	m := new(big.Int).SetBytes(encoded)
+	c := new(big.Int).Exp(m, big.NewInt(2), kp.N)
+
+	return c.FillBytes(make([]byte, kp.size())), nil
+}
+
+func rabinTag(message []byte) []byte {
+	digest := sha256.Sum256(message)
+	return digest[:TagSize]
+}
+
+// Decrypt computes the four square roots of c and returns the message of
+// the only one that carries a valid tag.
+func (kp *RabinKeyPair) Decrypt(ciphertext []byte) ([]byte, error) {
+	if kp.P == nil || kp.Q == nil {
+		return nil, errors.New("private key not available")
+	}
+	if len(ciphertext) != kp.size() {
+		return nil, errRabinDecryption
+	}
+
+	c := new(big.Int).SetBytes(ciphertext)
+	if c.Cmp(kp.N) >= 0 {
+		return nil, errRabinDecryption
+	}
+
+	for _, root := range kp.SquareRoots(c) {
+		if message, ok := openRabin(root); ok {
+			return message, nil
+		}
+	}
+
+	return nil, errRabinDecryption
+}
+
+// openRabin checks that a root has the form 0x01 || message || tag.
+func openRabin(root *big.Int) ([]byte, bool) {
+	encoded := root.Bytes()
+	if len(encoded) < 1+TagSize || encoded[0] != rabinMarker {
+		return nil, false
+	}
+
+	message := encoded[1 : len(encoded)-TagSize]
+	if !bytes.Equal(encoded[len(encoded)-TagSize:], rabinTag(message)) {
+		return nil, false
+	}
+
+	return message, true
+}
+
+// SquareRoots returns the four square roots of c mod N. Since P ≡ 3 (mod 4),
+// mp = c^((P+1)/4) is a root mod P, and likewise mq mod Q; the Chinese
+// remainder theorem joins ±mp and ±mq into the four roots mod N. If c is not
+// a square the results are not roots at all, which the tag check catches.
+func (kp *RabinKeyPair) SquareRoots(c *big.Int) [4]*big.Int {
+	mp := new(big.Int).Exp(c, rootExponent(kp.P), kp.P)
+	mq := new(big.Int).Exp(c, rootExponent(kp.Q), kp.Q)
+
+	// yp·P + yq·Q = 1
+	yp, yq := new(big.Int), new(big.Int)
+	new(big.Int).GCD(yp, yq, kp.P, kp.Q)
+
+	// u = yp·P·mq, v = yq·Q·mp
+	u := new(big.Int).Mul(yp, kp.P)
+	u.Mul(u, mq)
+	v := new(big.Int).Mul(yq, kp.Q)
+	v.Mul(v, mp)
+
+	r1 := new(big.Int).Add(u, v)
+	r1.Mod(r1, kp.N)
+	r2 := new(big.Int).Sub(kp.N, r1)
+	r3 := new(big.Int).Sub(u, v)
+	r3.Mod(r3, kp.N)
+	r4 := new(big.Int).Sub(kp.N, r3)
+
+	return [4]*big.Int{r1, r2.Mod(r2, kp.N), r3, r4.Mod(r4, kp.N)}
+}
+
+// rootExponent returns (p+1)/4.
+func rootExponent(p *big.Int) *big.Int {
+	e := new(big.Int).Add(p, one)
+	return e.Rsh(e, 2)
+}
+
+// EncodeToString returns the text encodings: private "P,Q", public "N".
+func (kp *RabinKeyPair) EncodeToString() (privateKey, publicKey string, err error) {
+	if kp.P == nil || kp.Q == nil {
+		return "", "", errors.New("p and q are required to encode the private key")
+	}
+
+	publicKey = kp.N.String()
+	privateKey = fmt.Sprintf("%s,%s", kp.P.String(), kp.Q.String())
+	return privateKey, publicKey, nil
+}
+
+func DecodeRabinPrivateKey(data string) (*RabinKeyPair, error) {
+	fields := strings.Split(data, ",")
+	if len(fields) != 2 {
+		return nil, errors.New("invalid private key format: expected P,Q")
+	}
+
+	values, err := parseValues(fields, "P", "Q")
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateRabinKeyPair(values[0], values[1])
+}
+
+func DecodeRabinPublicKey(data string) (*RabinKeyPair, error) {
+	n, ok := new(big.Int).SetString(data, 10)
+	if !ok || n.Cmp(one) <= 0 {
+		return nil, errors.New("invalid public key format: invalid N value")
+	}
+	// N = P·Q with P ≡ Q ≡ 3 (mod 4) is 1 mod 4.
+	if new(big.Int).Mod(n, four).Cmp(one) != 0 {
+		return nil, errors.New("N must be 1 mod 4")
+	}
+
+	return &RabinKeyPair{N: n}, nil
+}
+
+func parseValues(fields []string, names ...string) ([]*big.Int, error) {
+	values := make([]*big.Int, len(names))
+	for i, name := range names {
+		values[i] = new(big.Int)
+		if _, ok := values[i].SetString(fields[i], 10); !ok {
+			return nil, fmt.Errorf("invalid %s value", name)
+		}
+	}
+	return values, nil
+}
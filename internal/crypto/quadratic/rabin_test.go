@@ -0,0 +1,108 @@
+package quadratic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func newTestRabinKey(t *testing.T) *RabinKeyPair {
+	t.Helper()
+
+	keyPair, err := GenerateRabinKeyPair(MinKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyPair
+}
+
+func TestRabinRoundTrip(t *testing.T) {
+	keyPair := newTestRabinKey(t)
+
+	long := make([]byte, keyPair.MaxMessageSize())
+	if _, err := rand.Read(long); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, message := range [][]byte{nil, {0}, []byte("hello"), long} {
+		ciphertext, err := keyPair.Encrypt(message)
+		if err != nil {
+			t.Fatalf("Encrypt(%x): %v", message, err)
+		}
+
+		decrypted, err := keyPair.Decrypt(ciphertext)
+		if err != nil {
+			t.Fatalf("Decrypt of %x: %v", message, err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("Decrypt(Encrypt(%x)) = %x", message, decrypted)
+		}
+	}
+
+	if _, err := keyPair.Encrypt(make([]byte, keyPair.MaxMessageSize()+1)); err == nil {
+		t.Fatal("Encrypt accepted a message longer than MaxMessageSize")
+	}
+}
+
+func TestRabinSquareRoots(t *testing.T) {
+	keyPair := newTestRabinKey(t)
+
+	for i := 0; i < 20; i++ {
+		m, err := rand.Int(rand.Reader, keyPair.N)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := new(big.Int).Exp(m, big.NewInt(2), keyPair.N)
+
+		found := false
+		for _, root := range keyPair.SquareRoots(c) {
+			if new(big.Int).Exp(root, big.NewInt(2), keyPair.N).Cmp(c) != 0 {
+				t.Fatalf("%s is not a square root of %s", root, c)
+			}
+			found = found || root.Cmp(m) == 0
+		}
+		if !found {
+			t.Fatalf("SquareRoots(%s) does not include %s", c, m)
+		}
+	}
+}
+
+func TestRabinRejectsTampering(t *testing.T) {
+	keyPair := newTestRabinKey(t)
+
+	ciphertext, err := keyPair.Encrypt([]byte("attack at dawn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flipped := bytes.Clone(ciphertext)
+	flipped[len(flipped)-1] ^= 0x01
+
+	for _, tt := range []struct {
+		name       string
+		ciphertext []byte
+	}{
+		{"flipped bit", flipped},
+		{"truncated", ciphertext[1:]},
+		{"not below N", bytes.Repeat([]byte{0xff}, len(ciphertext))},
+	} {
+		if _, err := keyPair.Decrypt(tt.ciphertext); err == nil {
+			t.Errorf("%s: Decrypt succeeded", tt.name)
+		}
+	}
+}
+
+func TestCreateRabinKeyPairRejects(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		p, q int64
+	}{
+		{"p = q", 1019, 1019},
+		{"p is 1 mod 4", 1013, 1019},
+		{"q is composite", 1019, 1027},
+	} {
+		if _, err := CreateRabinKeyPair(big.NewInt(tt.p), big.NewInt(tt.q)); err == nil {
+			t.Errorf("%s: CreateRabinKeyPair succeeded", tt.name)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package crypto
+
+import (
+	"fmt"
+	"sync"
+)
+
+// KeyHolder is the key management shared by every algorithm.
+type KeyHolder interface {
+	// GenerateKey creates a key pair with the algorithm's default
+	// parameters and stores it in the key store.
+	GenerateKey() error
+	// PublicKey returns the encoding of the user's own public key.
+	PublicKey() ([]byte, error)
+	// ParsePublicKey checks an encoded public key and stores it for userID.
+	ParsePublicKey(userID string, publicKey []byte) error
+}
+
+// Provider is implemented by every public-key encryption algorithm, so
+// callers can encrypt and decrypt without knowing which one they hold.
+type Provider interface {
+	KeyHolder
+	// Encrypt encrypts message under the public key stored for recipientID.
+	Encrypt(message []byte, recipientID string) ([]byte, error)
+	// Decrypt decrypts a ciphertext addressed to the provider's user.
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// Signer is implemented by every signature algorithm.
+type Signer interface {
+	KeyHolder
+	// Sign signs message with the user's private key.
+	Sign(message []byte) ([]byte, error)
+	// Verify checks signature against the public key stored for signerID.
+	Verify(message, signature []byte, signerID string) error
+}
+
+// PossessionProver is implemented by key holders whose public keys are
+// registered together with a proof that the user holds the private key.
+type PossessionProver interface {
+	// ProvePossession proves knowledge of the user's private key, bound to
+	// userID.
+	ProvePossession(userID string) ([]byte, error)
+}
+
+// ChallengeResponder is implemented by key holders that prove they hold the
+// private key by answering a nonce issued by the server.
+type ChallengeResponder interface {
+	// AnswerChallenge answers the nonce issued to userID with the user's
+	// private key.
+	AnswerChallenge(userID string, nonce []byte) ([]byte, error)
+}
+
+// AlgorithmRegistry maps algorithms to their implementations.
+type AlgorithmRegistry[T any] struct {
+	kind      string
+	providers map[Algorithm]T
+	order     []Algorithm
+	mutex     sync.RWMutex
+}
+
+// Registry holds the encryption providers.
+type Registry = AlgorithmRegistry[Provider]
+
+// SignerRegistry holds the signature providers.
+type SignerRegistry = AlgorithmRegistry[Signer]
+
+func NewRegistry() *Registry {
+	return NewAlgorithmRegistry[Provider]("provider")
+}
+
+func NewSignerRegistry() *SignerRegistry {
+	return NewAlgorithmRegistry[Signer]("signer")
+}
+
+// NewAlgorithmRegistry returns an empty registry. kind names what it holds
+// in error messages.
+func NewAlgorithmRegistry[T any](kind string) *AlgorithmRegistry[T] {
+	return &AlgorithmRegistry[T]{
+		kind:      kind,
+		providers: make(map[Algorithm]T),
+	}
+}
+
+// Register adds an implementation. Like sql.Register, it panics if the
+// algorithm is registered twice.
+func (r *AlgorithmRegistry[T]) Register(algorithm Algorithm, provider T) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.providers[algorithm]; exists {
+		panic(fmt.Sprintf("crypto: %s %s already registered", algorithm, r.kind))
+	}
+
+	r.providers[algorithm] = provider
+	r.order = append(r.order, algorithm)
+}
+
+func (r *AlgorithmRegistry[T]) Get(algorithm Algorithm) (T, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	provider, exists := r.providers[algorithm]
+	if !exists {
+		return provider, fmt.Errorf("no %s registered for %s", r.kind, algorithm)
+	}
+
+	return provider, nil
+}
+
+// Algorithms returns the registered algorithms in registration order.
+func (r *AlgorithmRegistry[T]) Algorithms() []Algorithm {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	algorithms := make([]Algorithm, len(r.order))
+	copy(algorithms, r.order)
+	return algorithms
+}
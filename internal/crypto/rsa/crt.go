@@ -0,0 +1,68 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+// Precompute fills in the CRT values Dp = D mod (P-1), Dq = D mod (Q-1) and
+// Qinv = Q^-1 mod P. It does nothing if P or Q is unknown.
+func (kp *RSAKeyPair) Precompute() {
+	if kp.P == nil || kp.Q == nil || kp.D == nil {
+		return
+	}
+
+	qInv := new(big.Int).ModInverse(kp.Q, kp.P)
+	if qInv == nil {
+		return
+	}
+
+	pMinus1 := new(big.Int).Sub(kp.P, big.NewInt(1))
+	qMinus1 := new(big.Int).Sub(kp.Q, big.NewInt(1))
+
+	kp.Dp = new(big.Int).Mod(kp.D, pMinus1)
+	kp.Dq = new(big.Int).Mod(kp.D, qMinus1)
+	kp.Qinv = qInv
+}
+
+// exp computes c^d mod n, splitting it into two half-size exponentiations
+// recombined with Garner's formula when the CRT values are present.
+func (kp *RSAKeyPair) exp(c *big.Int) *big.Int {
+	if kp.Dp == nil || kp.Dq == nil || kp.Qinv == nil {
+		return new(big.Int).Exp(c, kp.D, kp.N)
+	}
+
+	// m1 = c^dP mod p, m2 = c^dQ mod q
+	m1 := new(big.Int).Exp(c, kp.Dp, kp.P)
+	m2 := new(big.Int).Exp(c, kp.Dq, kp.Q)
+
+	// h = qInv * (m1 - m2) mod p
+	h := m1.Sub(m1, m2)
+	h.Mul(h, kp.Qinv)
+	h.Mod(h, kp.P)
+
+	// m = m2 + h * q
+	h.Mul(h, kp.Q)
+	return h.Add(h, m2)
+}
+
+// blindingFactor returns a random r invertible modulo N, and its inverse.
+func (kp *RSAKeyPair) blindingFactor() (r, rInv *big.Int, err error) {
+	for i := 0; i < 10; i++ {
+		r, err = rand.Int(rand.Reader, kp.N)
+		if err != nil {
+			return nil, nil, err
+		}
+		if r.Sign() == 0 {
+			continue
+		}
+
+		rInv = new(big.Int).ModInverse(r, kp.N)
+		if rInv != nil {
+			return r, rInv, nil
+		}
+	}
+
+	return nil, nil, errors.New("could not find a blinding factor")
+}
@@ -0,0 +1,90 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func randomCiphertext(t testing.TB, keyPair *RSAKeyPair) (*big.Int, *big.Int) {
+	t.Helper()
+
+	m, err := rand.Int(rand.Reader, keyPair.N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, keyPair.encrypt(m)
+}
+
+func TestDecryptCRTMatchesPlainExp(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+	if keyPair.Dp == nil || keyPair.Dq == nil || keyPair.Qinv == nil {
+		t.Fatal("generated key has no CRT values")
+	}
+
+	for i := 0; i < 50; i++ {
+		m, c := randomCiphertext(t, keyPair)
+
+		crt, err := keyPair.decrypt(c)
+		if err != nil {
+			t.Fatalf("decrypt: %v", err)
+		}
+		plain := new(big.Int).Exp(c, keyPair.D, keyPair.N)
+
+		if crt.Cmp(plain) != 0 {
+			t.Fatalf("CRT and plain decryption differ for m = %s", m)
+		}
+		if crt.Cmp(m) != 0 {
+			t.Fatalf("decrypt(encrypt(m)) = %s, want %s", crt, m)
+		}
+	}
+}
+
+func TestDecryptLegacyPrivateKey(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+
+	legacy, err := DecodePrivateKey(fmt.Sprintf("%s,%s", keyPair.N, keyPair.D))
+	if err != nil {
+		t.Fatalf("DecodePrivateKey: %v", err)
+	}
+	if legacy.E != nil || legacy.Dp != nil {
+		t.Fatal("legacy key should decode without E or CRT values")
+	}
+
+	message := []byte("decrypted without the CRT")
+	ciphertext, err := keyPair.Encrypt(message)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	decrypted, err := legacy.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(decrypted, message) {
+		t.Fatalf("Decrypt = %q, want %q", decrypted, message)
+	}
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	for _, bits := range []int{2048, 4096} {
+		keyPair := generateTestKey(b, bits)
+		_, c := randomCiphertext(b, keyPair)
+
+		b.Run(fmt.Sprintf("%d/CRT+blinding", bits), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := keyPair.decrypt(c); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("%d/Exp", bits), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				new(big.Int).Exp(c, keyPair.D, keyPair.N)
+			}
+		})
+	}
+}
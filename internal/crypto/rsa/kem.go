@@ -0,0 +1,101 @@
+package rsa
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"math/big"
+)
+
+const (
+	kemInfo      = "crypto-grpc RSA-KEM AES-256-GCM"
+	kemKeySize   = 32
+	kemNonceSize = 12
+)
+
+// EncryptHybrid encrypts a message of any length. A random z < N is
+// encapsulated as c0 = z^e mod N (RSA-KEM), an AES-256 key is derived from z
+// with HKDF-SHA-256, and the message is sealed with AES-GCM:
+//
+//	c0 (modulus length) || nonce (12 bytes) || AES-GCM ciphertext and tag
+//
+// c0 is authenticated as additional data.
+func (kp *RSAKeyPair) EncryptHybrid(message []byte) ([]byte, error) {
+	k := kp.size()
+
+	z, err := rand.Int(rand.Reader, kp.N)
+	if err != nil {
+		return nil, err
+	}
+
+	c0 := kp.encrypt(z).FillBytes(make([]byte, k))
+
+	aead, err := kemAEAD(z.FillBytes(make([]byte, k)), c0)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, k+kemNonceSize, k+kemNonceSize+len(message)+aead.Overhead())
+	copy(out, c0)
+
+	nonce := out[k:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(out, nonce, message, c0), nil
+}
+
+// DecryptHybrid reverses EncryptHybrid.
+func (kp *RSAKeyPair) DecryptHybrid(ciphertext []byte) ([]byte, error) {
+	k := kp.size()
+
+	if len(ciphertext) < k+kemNonceSize {
+		return nil, errDecryption
+	}
+
+	c0 := ciphertext[:k]
+	nonce := ciphertext[k : k+kemNonceSize]
+	sealed := ciphertext[k+kemNonceSize:]
+
+	c := new(big.Int).SetBytes(c0)
+	if c.Cmp(kp.N) >= 0 {
+		return nil, errDecryption
+	}
+
+	z, err := kp.decrypt(c)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := kemAEAD(z.FillBytes(make([]byte, k)), c0)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := aead.Open(nil, nonce, sealed, c0)
+	if err != nil {
+		return nil, errDecryption
+	}
+
+	return message, nil
+}
+
+// kemAEAD derives the AES-GCM key from the encapsulated secret, binding it
+// to the encapsulation c0.
+func kemAEAD(secret, c0 []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, secret, c0, kemInfo, kemKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,72 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestHybridRoundTrip(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+
+	long := make([]byte, 10000)
+	if _, err := rand.Read(long); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, message := range [][]byte{nil, []byte("short"), long} {
+		ciphertext, err := keyPair.EncryptHybrid(message)
+		if err != nil {
+			t.Fatalf("EncryptHybrid: %v", err)
+		}
+		if len(ciphertext) != keyPair.size()+kemNonceSize+len(message)+16 {
+			t.Fatalf("ciphertext length %d for a %d-byte message", len(ciphertext), len(message))
+		}
+
+		decrypted, err := keyPair.DecryptHybrid(ciphertext)
+		if err != nil {
+			t.Fatalf("DecryptHybrid: %v", err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("DecryptHybrid(EncryptHybrid(m)) differs for a %d-byte message", len(message))
+		}
+	}
+}
+
+func TestHybridRejectsTampering(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+	other := generateTestKey(t, 2048)
+
+	ciphertext, err := keyPair.EncryptHybrid([]byte("attack at dawn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := keyPair.size()
+
+	flip := func(i int) []byte {
+		tampered := bytes.Clone(ciphertext)
+		tampered[i] ^= 0x01
+		return tampered
+	}
+
+	tests := []struct {
+		name       string
+		keyPair    *RSAKeyPair
+		ciphertext []byte
+	}{
+		{"flipped c0", keyPair, flip(k / 2)},
+		{"flipped nonce", keyPair, flip(k)},
+		{"flipped body", keyPair, flip(len(ciphertext) - 20)},
+		{"flipped tag", keyPair, flip(len(ciphertext) - 1)},
+		{"truncated", keyPair, ciphertext[:k+kemNonceSize-1]},
+		{"other key", other, ciphertext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.keyPair.DecryptHybrid(tt.ciphertext); err == nil {
+				t.Fatal("DecryptHybrid succeeded")
+			}
+		})
+	}
+}
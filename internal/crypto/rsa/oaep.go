@@ -0,0 +1,131 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+)
+
+var errDecryption = errors.New("decryption error")
+
+// EncryptOAEP encrypts message with RSAES-OAEP (RFC 8017, section 7.1) using
+// SHA-256 and MGF1-SHA-256. The label is optional and must match on decryption.
+func (kp *RSAKeyPair) EncryptOAEP(message, label []byte) ([]byte, error) {
+	hash := sha256.New()
+	k := kp.size()
+	hLen := hash.Size()
+
+	if k < 2*hLen+2 {
+		return nil, errors.New("key too small for OAEP")
+	}
+	if len(message) > k-2*hLen-2 {
+		return nil, errors.New("message too large for the key size")
+	}
+
+	hash.Write(label)
+	lHash := hash.Sum(nil)
+	hash.Reset()
+
+	// EM = 0x00 || maskedSeed || maskedDB
+	em := make([]byte, k)
+	seed := em[1 : 1+hLen]
+	db := em[1+hLen:]
+
+	// DB = lHash || PS || 0x01 || M
+	copy(db, lHash)
+	db[len(db)-len(message)-1] = 0x01
+	copy(db[len(db)-len(message):], message)
+
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return nil, err
+	}
+
+	mgf1XOR(db, hash, seed)
+	mgf1XOR(seed, hash, db)
+
+	c := kp.encrypt(new(big.Int).SetBytes(em))
+
+	return c.FillBytes(make([]byte, k)), nil
+}
+
+// DecryptOAEP reverses EncryptOAEP. Every failure is reported as the same
+// error so the result does not tell an attacker which check failed.
+func (kp *RSAKeyPair) DecryptOAEP(ciphertext, label []byte) ([]byte, error) {
+	hash := sha256.New()
+	k := kp.size()
+	hLen := hash.Size()
+
+	if len(ciphertext) != k || k < 2*hLen+2 {
+		return nil, errDecryption
+	}
+
+	c := new(big.Int).SetBytes(ciphertext)
+	if c.Cmp(kp.N) >= 0 {
+		return nil, errDecryption
+	}
+
+	m, err := kp.decrypt(c)
+	if err != nil {
+		return nil, err
+	}
+	em := m.FillBytes(make([]byte, k))
+
+	hash.Write(label)
+	lHash := hash.Sum(nil)
+	hash.Reset()
+
+	firstByteIsZero := subtle.ConstantTimeByteEq(em[0], 0)
+	seed := em[1 : 1+hLen]
+	db := em[1+hLen:]
+
+	mgf1XOR(seed, hash, db)
+	mgf1XOR(db, hash, seed)
+
+	lHashGood := subtle.ConstantTimeCompare(db[:hLen], lHash)
+
+	// Scan PS || 0x01 || M without branching on secret data, remembering the
+	// position of the first 0x01 and whether anything else came before it.
+	var lookingForIndex, index, invalid int
+	lookingForIndex = 1
+	rest := db[hLen:]
+
+	for i := 0; i < len(rest); i++ {
+		equals0 := subtle.ConstantTimeByteEq(rest[i], 0)
+		equals1 := subtle.ConstantTimeByteEq(rest[i], 1)
+		index = subtle.ConstantTimeSelect(lookingForIndex&equals1, i, index)
+		lookingForIndex = subtle.ConstantTimeSelect(equals1, 0, lookingForIndex)
+		invalid = subtle.ConstantTimeSelect(lookingForIndex&^equals0, 1, invalid)
+	}
+
+	if firstByteIsZero&lHashGood&^invalid&^lookingForIndex != 1 {
+		return nil, errDecryption
+	}
+
+	return rest[index+1:], nil
+}
+
+// mgf1XOR XORs out with MGF1(seed) as defined in RFC 8017, appendix B.2.1.
+func mgf1XOR(out []byte, hash hash.Hash, seed []byte) {
+	var counter [4]byte
+	var digest []byte
+
+	done := 0
+	for done < len(out) {
+		hash.Write(seed)
+		hash.Write(counter[:])
+		digest = hash.Sum(digest[:0])
+		hash.Reset()
+
+		for i := 0; i < len(digest) && done < len(out); i++ {
+			out[done] ^= digest[i]
+			done++
+		}
+
+		binary.BigEndian.PutUint32(counter[:], binary.BigEndian.Uint32(counter[:])+1)
+	}
+}
@@ -0,0 +1,101 @@
+package rsa
+
+import (
+	"bytes"
+	"testing"
+)
+
+func generateTestKey(t testing.TB, bits int) *RSAKeyPair {
+	t.Helper()
+
+	keyPair, err := GenerateRSAKeyPair(bits)
+	if err != nil {
+		t.Fatalf("GenerateRSAKeyPair(%d): %v", bits, err)
+	}
+	return keyPair
+}
+
+func TestOAEPRoundTrip(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+	maxLen := keyPair.size() - 2*32 - 2
+
+	for _, message := range [][]byte{
+		nil,
+		[]byte("hello"),
+		{0, 0, 1},
+		bytes.Repeat([]byte{0xff}, maxLen),
+	} {
+		for _, label := range [][]byte{nil, []byte("label")} {
+			ciphertext, err := keyPair.EncryptOAEP(message, label)
+			if err != nil {
+				t.Fatalf("EncryptOAEP(%x): %v", message, err)
+			}
+
+			decrypted, err := keyPair.DecryptOAEP(ciphertext, label)
+			if err != nil {
+				t.Fatalf("DecryptOAEP of %x: %v", message, err)
+			}
+			if !bytes.Equal(decrypted, message) {
+				t.Fatalf("DecryptOAEP(EncryptOAEP(%x)) = %x", message, decrypted)
+			}
+		}
+	}
+}
+
+func TestOAEPIsRandomized(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+
+	first, err := keyPair.EncryptOAEP([]byte("same message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := keyPair.EncryptOAEP([]byte("same message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(first, second) {
+		t.Fatal("two encryptions of the same message are equal")
+	}
+}
+
+func TestOAEPRejectsTampering(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+
+	ciphertext, err := keyPair.EncryptOAEP([]byte("attack at dawn"), []byte("label"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flipped := bytes.Clone(ciphertext)
+	flipped[len(flipped)/2] ^= 0x01
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+		label      []byte
+	}{
+		{"flipped bit", flipped, []byte("label")},
+		{"wrong label", ciphertext, []byte("other")},
+		{"missing label", ciphertext, nil},
+		{"truncated", ciphertext[1:], []byte("label")},
+		{"too long", append(bytes.Clone(ciphertext), 0), []byte("label")},
+		{"not below N", bytes.Repeat([]byte{0xff}, len(ciphertext)), []byte("label")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := keyPair.DecryptOAEP(tt.ciphertext, tt.label); err == nil {
+				t.Fatal("DecryptOAEP succeeded")
+			}
+		})
+	}
+}
+
+func TestOAEPRejectsLongMessage(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+	maxLen := keyPair.size() - 2*32 - 2
+
+	if _, err := keyPair.EncryptOAEP(make([]byte, maxLen+1), nil); err == nil {
+		t.Fatal("EncryptOAEP accepted a message longer than the key allows")
+	}
+}
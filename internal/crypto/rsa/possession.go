@@ -0,0 +1,35 @@
+package rsa
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// possessionDomain separates challenge answers from message signatures, so
+// a signature on a message can never answer a challenge or vice versa.
+const possessionDomain = "crypto-go RSA proof of possession"
+
+// AnswerChallenge proves possession of the private key by signing, with
+// RSA-PSS, a nonce the server issued to userID.
+func (kp *RSAKeyPair) AnswerChallenge(userID string, nonce []byte) ([]byte, error) {
+	return kp.SignPSS(possessionMessage(userID, nonce))
+}
+
+// VerifyChallenge checks an answer made by AnswerChallenge against the
+// public key being registered.
+func (kp *RSAKeyPair) VerifyChallenge(userID string, nonce, answer []byte) error {
+	if len(answer) == 0 {
+		return errors.New("challenge answer required")
+	}
+
+	return kp.VerifyPSS(possessionMessage(userID, nonce), answer)
+}
+
+// possessionMessage is the domain, the length-prefixed user ID and the
+// nonce.
+func possessionMessage(userID string, nonce []byte) []byte {
+	message := []byte(possessionDomain)
+	message = binary.BigEndian.AppendUint32(message, uint32(len(userID)))
+	message = append(message, userID...)
+	return append(message, nonce...)
+}
@@ -0,0 +1,48 @@
+package rsa
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestChallengeAnswer(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+	other := generateTestKey(t, 2048)
+	nonce := bytes.Repeat([]byte{7}, 32)
+
+	answer, err := keyPair.AnswerChallenge("alice", nonce)
+	if err != nil {
+		t.Fatalf("AnswerChallenge: %v", err)
+	}
+	if err := keyPair.VerifyChallenge("alice", nonce, answer); err != nil {
+		t.Fatalf("VerifyChallenge: %v", err)
+	}
+
+	signature, err := keyPair.SignPSS(nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		keyPair *RSAKeyPair
+		userID  string
+		nonce   []byte
+		answer  []byte
+	}{
+		{"other user", keyPair, "bob", nonce, answer},
+		{"user ID prefix", keyPair, "alic", append([]byte("e"), nonce...), answer},
+		{"other nonce", keyPair, "alice", bytes.Repeat([]byte{8}, 32), answer},
+		{"other key", other, "alice", nonce, answer},
+		{"plain signature of the nonce", keyPair, "alice", nonce, signature},
+		{"no answer", keyPair, "alice", nonce, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.keyPair.VerifyChallenge(tt.userID, tt.nonce, tt.answer); err == nil {
+				t.Fatal("VerifyChallenge succeeded")
+			}
+		})
+	}
+}
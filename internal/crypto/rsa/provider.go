@@ -2,11 +2,35 @@ package rsa
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/luizgbraga/crypto-go/internal/crypto"
 )
 
+// EncryptionMode selects how RSAProvider pads a message. It is written as the
+// first byte of every ciphertext so Decrypt knows which path to take.
+type EncryptionMode byte
+
+const (
+	ModeTextbook EncryptionMode = 0x00
+	ModeOAEP     EncryptionMode = 0x01
+	ModeHybrid   EncryptionMode = 0x02
+)
+
+func (m EncryptionMode) String() string {
+	switch m {
+	case ModeTextbook:
+		return "Textbook"
+	case ModeOAEP:
+		return "OAEP"
+	case ModeHybrid:
+		return "Hybrid"
+	default:
+		return fmt.Sprintf("EncryptionMode(%d)", byte(m))
+	}
+}
+
 type RSAProvider struct {
 	keyStore crypto.KeyStore
 	userID   string
@@ -21,11 +45,27 @@ func NewRSAProvider(keyStore crypto.KeyStore, userID string) *RSAProvider {
 	}
 }
 
-func (p *RSAProvider) StorePublicKey(userID string, publicKeyData []byte) error {
+var _ crypto.Provider = (*RSAProvider)(nil)
+var _ crypto.ChallengeResponder = (*RSAProvider)(nil)
+
+// DefaultKeySize is the modulus size used by GenerateKey.
+const DefaultKeySize = 2048
+
+func (p *RSAProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodePublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
 	return p.keyStore.StorePublicKey(userID, crypto.RSA, publicKeyData)
 }
 
+// Encrypt encrypts the message for the recipient using RSA-KEM and AES-GCM,
+// so its length is not limited by the recipient's modulus.
 func (p *RSAProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	return p.EncryptWithMode(message, recipientID, ModeHybrid)
+}
+
+func (p *RSAProvider) EncryptWithMode(message []byte, recipientID string, mode EncryptionMode) ([]byte, error) {
 	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.RSA)
 	if err != nil {
 		return nil, err
@@ -36,44 +76,73 @@ func (p *RSAProvider) Encrypt(message []byte, recipientID string) ([]byte, error
 		return nil, err
 	}
 
-	return recipientKey.Encrypt(message)
+	var ciphertext []byte
+	switch mode {
+	case ModeTextbook:
+		ciphertext, err = recipientKey.Encrypt(message)
+	case ModeOAEP:
+		ciphertext, err = recipientKey.EncryptOAEP(message, nil)
+	case ModeHybrid:
+		ciphertext, err = recipientKey.EncryptHybrid(message)
+	default:
+		return nil, fmt.Errorf("unsupported encryption mode: %s", mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{byte(mode)}, ciphertext...), nil
 }
 
 func (p *RSAProvider) Decrypt(ciphertext []byte) ([]byte, error) {
-	if p.keyPair == nil {
-		privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.RSA)
-		if err != nil {
-			return nil, errors.New("private key not available")
-		}
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
 
-		keyPair, err := DecodePrivateKey(string(privateKeyBytes))
-		if err != nil {
-			return nil, err
-		}
+	if len(ciphertext) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
 
-		p.keyPair = keyPair
+	mode, body := EncryptionMode(ciphertext[0]), ciphertext[1:]
+	switch mode {
+	case ModeTextbook:
+		return p.keyPair.Decrypt(body)
+	case ModeOAEP:
+		return p.keyPair.DecryptOAEP(body, nil)
+	case ModeHybrid:
+		return p.keyPair.DecryptHybrid(body)
+	default:
+		return nil, fmt.Errorf("unsupported encryption mode: %s", mode)
 	}
+}
 
-	return p.keyPair.Decrypt(ciphertext)
+func (p *RSAProvider) PublicKey() ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	_, publicKeyStr, err := p.keyPair.EncodeToString()
+	return []byte(publicKeyStr), err
 }
 
-func (p *RSAProvider) GetPublicKey() ([]byte, error) {
-	if p.keyPair == nil {
-		privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.RSA)
-		if err != nil {
-			return nil, errors.New("key pair not available")
-		}
+// loadKeyPair decodes the user's private key from the key store on first use.
+func (p *RSAProvider) loadKeyPair() error {
+	if p.keyPair != nil {
+		return nil
+	}
 
-		keyPair, err := DecodePrivateKey(string(privateKeyBytes))
-		if err != nil {
-			return nil, err
-		}
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.RSA)
+	if err != nil {
+		return errors.New("private key not available")
+	}
 
-		p.keyPair = keyPair
+	keyPair, err := DecodePrivateKey(string(privateKeyBytes))
+	if err != nil {
+		return err
 	}
 
-	_, publicKeyStr, err := p.keyPair.EncodeToString()
-	return []byte(publicKeyStr), err
+	p.keyPair = keyPair
+	return nil
 }
 
 func (p *RSAProvider) StoreKeyPair(primeP, primeQ, dValue big.Int) error {
@@ -82,6 +151,24 @@ func (p *RSAProvider) StoreKeyPair(primeP, primeQ, dValue big.Int) error {
 		return err
 	}
 
+	return p.storeKeyPair(keyPair)
+}
+
+// GenerateKey creates a key pair with a DefaultKeySize-bit modulus.
+func (p *RSAProvider) GenerateKey() error {
+	return p.GenerateKeyPair(DefaultKeySize)
+}
+
+func (p *RSAProvider) GenerateKeyPair(bits int) error {
+	keyPair, err := GenerateRSAKeyPair(bits)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *RSAProvider) storeKeyPair(keyPair *RSAKeyPair) error {
 	p.keyPair = keyPair
 
 	privateKeyStr, publicKeyStr, err := keyPair.EncodeToString()
@@ -110,3 +197,52 @@ func (p *RSAProvider) GetPossibleDValues(primeP, primeQ big.Int, count int) ([]s
 
 	return result, nil
 }
+
+// AnswerChallenge answers the server's registration challenge with the
+// user's private key.
+func (p *RSAProvider) AnswerChallenge(userID string, nonce []byte) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	return p.keyPair.AnswerChallenge(userID, nonce)
+}
+
+// Sign signs the message with the user's private key using the given
+// signature algorithm, either crypto.RSAPSS or crypto.RSAPKCS1v15.
+func (p *RSAProvider) Sign(message []byte, scheme crypto.Algorithm) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	switch scheme {
+	case crypto.RSAPSS:
+		return p.keyPair.SignPSS(message)
+	case crypto.RSAPKCS1v15:
+		return p.keyPair.SignPKCS1v15(message)
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", scheme)
+	}
+}
+
+// Verify checks the signature against the RSA public key stored for signerID.
+func (p *RSAProvider) Verify(message, signature []byte, signerID string, scheme crypto.Algorithm) error {
+	signerKeyBytes, err := p.keyStore.GetPublicKey(signerID, crypto.RSA)
+	if err != nil {
+		return err
+	}
+
+	signerKey, err := DecodePublicKey(string(signerKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	switch scheme {
+	case crypto.RSAPSS:
+		return signerKey.VerifyPSS(message, signature)
+	case crypto.RSAPKCS1v15:
+		return signerKey.VerifyPKCS1v15(message, signature)
+	default:
+		return fmt.Errorf("unsupported signature algorithm: %s", scheme)
+	}
+}
@@ -1,12 +1,20 @@
 package rsa
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"strings"
 )
 
+// PublicExponent is the exponent used for generated keys (F4 = 2^16 + 1).
+const PublicExponent = 65537
+
+// SupportedKeySizes lists the modulus sizes, in bits, accepted by GenerateRSAKeyPair.
+var SupportedKeySizes = []int{1024, 2048, 3072, 4096}
+
 type RSAKeyPair struct {
 	N *big.Int
 	E *big.Int
@@ -14,37 +22,115 @@ type RSAKeyPair struct {
 	D *big.Int
 	P *big.Int
 	Q *big.Int
+
+	// CRT values, set by Precompute when P and Q are known.
+	Dp   *big.Int
+	Dq   *big.Int
+	Qinv *big.Int
 }
 
 func CreateRSAKeyPair(p, q, d *big.Int) (*RSAKeyPair, error) {
 	if p == nil || q == nil || d == nil {
 		return nil, errors.New("p, q, and d must not be nil")
 	}
+	if p.Cmp(q) == 0 {
+		return nil, errors.New("p and q must be distinct")
+	}
 
 	n := new(big.Int).Mul(p, q)
 
-	pMinus1 := new(big.Int).Sub(p, big.NewInt(1))
-	qMinus1 := new(big.Int).Sub(q, big.NewInt(1))
-	phi := new(big.Int).Mul(pMinus1, qMinus1)
+	phi := totient(p, q)
 
 	e := new(big.Int).ModInverse(d, phi)
 	if e == nil {
 		return nil, errors.New("invalid d value: no modular inverse exists")
 	}
 
-	return &RSAKeyPair{
+	keyPair := &RSAKeyPair{
 		N: n,
 		E: e,
 		D: d,
 		P: p,
 		Q: q,
-	}, nil
+	}
+	keyPair.Precompute()
+
+	return keyPair, nil
 }
 
-func FindPossibleDValues(p, q *big.Int, count int) ([]*big.Int, error) {
+// GenerateRSAKeyPair creates a key pair with a modulus of the given size from
+// random primes, using PublicExponent as E and deriving D from it.
+func GenerateRSAKeyPair(bits int) (*RSAKeyPair, error) {
+	if !slices.Contains(SupportedKeySizes, bits) {
+		return nil, fmt.Errorf("unsupported key size %d: must be one of %v", bits, SupportedKeySizes)
+	}
+
+	e := big.NewInt(PublicExponent)
+
+	for {
+		p, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(rand.Reader, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkPrimeDistance(p, q, bits); err != nil {
+			continue
+		}
+
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+
+		phi := totient(p, q)
+		d := new(big.Int).ModInverse(e, phi)
+		if d == nil {
+			continue
+		}
+
+		keyPair := &RSAKeyPair{
+			N: n,
+			E: e,
+			D: d,
+			P: p,
+			Q: q,
+		}
+		keyPair.Precompute()
+
+		return keyPair, nil
+	}
+}
+
+// checkPrimeDistance rejects primes that are equal or so close together that
+// N can be factored with Fermat's method: |p - q| must exceed 2^(bits/2 - 100).
+func checkPrimeDistance(p, q *big.Int, bits int) error {
+	if p.Cmp(q) == 0 {
+		return errors.New("p and q must be distinct")
+	}
+
+	diff := new(big.Int).Sub(p, q)
+	diff.Abs(diff)
+
+	minDiff := new(big.Int).Lsh(big.NewInt(1), uint(bits/2-100))
+	if diff.Cmp(minDiff) <= 0 {
+		return errors.New("p and q are too close together")
+	}
+
+	return nil
+}
+
+func totient(p, q *big.Int) *big.Int {
 	pMinus1 := new(big.Int).Sub(p, big.NewInt(1))
 	qMinus1 := new(big.Int).Sub(q, big.NewInt(1))
-	phi := new(big.Int).Mul(pMinus1, qMinus1)
+	return new(big.Int).Mul(pMinus1, qMinus1)
+}
+
+func FindPossibleDValues(p, q *big.Int, count int) ([]*big.Int, error) {
+	phi := totient(p, q)
 
 	possibleDs := make([]*big.Int, 0, count)
 
@@ -66,6 +152,8 @@ func FindPossibleDValues(p, q *big.Int, count int) ([]*big.Int, error) {
 	return possibleDs, nil
 }
 
+// Encrypt applies textbook RSA to the message. It is deterministic and
+// malleable, and is kept for teaching; use EncryptOAEP for real messages.
 func (kp *RSAKeyPair) Encrypt(message []byte) ([]byte, error) {
 	m := new(big.Int).SetBytes(message)
 
@@ -73,52 +161,131 @@ func (kp *RSAKeyPair) Encrypt(message []byte) ([]byte, error) {
 		return nil, errors.New("message too large for the key size")
 	}
 
-	// c = m^e mod n
-	c := new(big.Int).Exp(m, kp.E, kp.N)
-
-	return c.Bytes(), nil
+	return kp.encrypt(m).Bytes(), nil
 }
 
+// Decrypt reverses Encrypt. Leading zero bytes of the original message are lost.
 func (kp *RSAKeyPair) Decrypt(ciphertext []byte) ([]byte, error) {
 	c := new(big.Int).SetBytes(ciphertext)
 
-	// m = c^d mod n
-	m := new(big.Int).Exp(c, kp.D, kp.N)
+	if c.Cmp(kp.N) >= 0 {
+		return nil, errors.New("ciphertext too large for the key size")
+	}
+
+	m, err := kp.decrypt(c)
+	if err != nil {
+		return nil, err
+	}
 
 	return m.Bytes(), nil
 }
 
+// encrypt is the RSA public-key primitive: c = m^e mod n.
+func (kp *RSAKeyPair) encrypt(m *big.Int) *big.Int {
+	return new(big.Int).Exp(m, kp.E, kp.N)
+}
+
+// decrypt is the RSA private-key primitive: m = c^d mod n. It uses the CRT
+// values when they are available and blinds the input when E is known.
+func (kp *RSAKeyPair) decrypt(c *big.Int) (*big.Int, error) {
+	if kp.E == nil {
+		return kp.exp(c), nil
+	}
+
+	r, rInv, err := kp.blindingFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// c' = c * r^e mod n, so m' = m * r mod n
+	blinded := new(big.Int).Mul(c, kp.encrypt(r))
+	blinded.Mod(blinded, kp.N)
+
+	m := kp.exp(blinded)
+	m.Mul(m, rInv)
+	m.Mod(m, kp.N)
+
+	return m, nil
+}
+
+// size returns the length of the modulus in bytes.
+func (kp *RSAKeyPair) size() int {
+	return (kp.N.BitLen() + 7) / 8
+}
+
+// EncodeToString encodes the public key as "N,E". The private key is
+// "N,E,D,P,Q,Dp,Dq,Qinv" so decryption can use the CRT after decoding.
 func (kp *RSAKeyPair) EncodeToString() (privateKey, publicKey string, err error) {
+	if kp.Dp == nil {
+		kp.Precompute()
+	}
+	if kp.Dp == nil {
+		return "", "", errors.New("p and q are required to encode the private key")
+	}
+
 	publicKey = fmt.Sprintf("%s,%s", kp.N.String(), kp.E.String())
-	privateKey = fmt.Sprintf("%s,%s", kp.N.String(), kp.D.String())
+	privateKey = fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%s",
+		kp.N.String(), kp.E.String(), kp.D.String(),
+		kp.P.String(), kp.Q.String(),
+		kp.Dp.String(), kp.Dq.String(), kp.Qinv.String())
 
 	return privateKey, publicKey, nil
 }
 
+// DecodePrivateKey accepts the full encoding written by EncodeToString as
+// well as the older "N,D" form, which decrypts without the CRT.
 func DecodePrivateKey(data string) (*RSAKeyPair, error) {
-	var nStr, dStr string
-	_, err := fmt.Sscanf(data, "%s,%s", &nStr, &dStr)
-	if err != nil {
-		return nil, errors.New("invalid private key format")
-	}
+	keys := strings.Split(data, ",")
 
-	n := new(big.Int)
-	d := new(big.Int)
+	switch len(keys) {
+	case 2:
+		values, err := parseValues(keys, "N", "D")
+		if err != nil {
+			return nil, err
+		}
 
-	if _, ok := n.SetString(nStr, 10); !ok {
-		return nil, errors.New("invalid N value")
-	}
-	if _, ok := d.SetString(dStr, 10); !ok {
-		return nil, errors.New("invalid D value")
+		return &RSAKeyPair{
+			N: values[0],
+			E: nil,
+			D: values[1],
+			P: nil,
+			Q: nil,
+		}, nil
+	case 8:
+		values, err := parseValues(keys, "N", "E", "D", "P", "Q", "Dp", "Dq", "Qinv")
+		if err != nil {
+			return nil, err
+		}
+
+		keyPair := &RSAKeyPair{
+			N:    values[0],
+			E:    values[1],
+			D:    values[2],
+			P:    values[3],
+			Q:    values[4],
+			Dp:   values[5],
+			Dq:   values[6],
+			Qinv: values[7],
+		}
+		if new(big.Int).Mul(keyPair.P, keyPair.Q).Cmp(keyPair.N) != 0 {
+			return nil, errors.New("invalid private key: N is not P*Q")
+		}
+
+		return keyPair, nil
+	default:
+		return nil, errors.New("invalid private key format")
 	}
+}
 
-	return &RSAKeyPair{
-		N: n,
-		E: nil,
-		D: d,
-		P: nil,
-		Q: nil,
-	}, nil
+func parseValues(fields []string, names ...string) ([]*big.Int, error) {
+	values := make([]*big.Int, len(names))
+	for i, name := range names {
+		values[i] = new(big.Int)
+		if _, ok := values[i].SetString(fields[i], 10); !ok {
+			return nil, fmt.Errorf("invalid %s value", name)
+		}
+	}
+	return values, nil
 }
 
 func DecodePublicKey(data string) (*RSAKeyPair, error) {
@@ -137,6 +304,9 @@ func DecodePublicKey(data string) (*RSAKeyPair, error) {
 	if _, ok := e.SetString(eStr, 10); !ok {
 		return nil, errors.New("invalid E value")
 	}
+	if n.Cmp(big.NewInt(1)) <= 0 || e.Cmp(big.NewInt(1)) <= 0 || e.Cmp(n) >= 0 {
+		return nil, errors.New("invalid public key: N and E out of range")
+	}
 
 	return &RSAKeyPair{
 		N: n,
@@ -0,0 +1,227 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var errVerification = errors.New("verification error")
+
+// sha256DigestInfoPrefix is the DER encoding of the DigestInfo header for
+// SHA-256, which precedes the hash in a PKCS #1 v1.5 signature.
+var sha256DigestInfoPrefix = []byte{
+	0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01,
+	0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20,
+}
+
+// SignPSS signs the SHA-256 hash of message with RSASSA-PSS (RFC 8017,
+// section 8.1), using MGF1-SHA-256 and a salt as long as the hash.
+func (kp *RSAKeyPair) SignPSS(message []byte) ([]byte, error) {
+	if kp.D == nil {
+		return nil, errors.New("private key required to sign")
+	}
+
+	mHash := sha256.Sum256(message)
+	emBits := kp.N.BitLen() - 1
+
+	salt := make([]byte, len(mHash))
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	em, err := emsaPSSEncode(mHash[:], emBits, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := kp.decrypt(new(big.Int).SetBytes(em))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FillBytes(make([]byte, kp.size())), nil
+}
+
+// VerifyPSS checks a signature produced by SignPSS.
+func (kp *RSAKeyPair) VerifyPSS(message, signature []byte) error {
+	m, err := kp.openSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	emBits := kp.N.BitLen() - 1
+	emLen := (emBits + 7) / 8
+	if m.BitLen() > emLen*8 {
+		return errVerification
+	}
+
+	mHash := sha256.Sum256(message)
+
+	return emsaPSSVerify(mHash[:], m.FillBytes(make([]byte, emLen)), emBits)
+}
+
+// SignPKCS1v15 signs the SHA-256 hash of message with RSASSA-PKCS1-v1_5
+// (RFC 8017, section 8.2).
+func (kp *RSAKeyPair) SignPKCS1v15(message []byte) ([]byte, error) {
+	if kp.D == nil {
+		return nil, errors.New("private key required to sign")
+	}
+
+	em, err := kp.emsaPKCS1v15Encode(message)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := kp.decrypt(new(big.Int).SetBytes(em))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FillBytes(make([]byte, kp.size())), nil
+}
+
+// VerifyPKCS1v15 checks a signature produced by SignPKCS1v15.
+func (kp *RSAKeyPair) VerifyPKCS1v15(message, signature []byte) error {
+	m, err := kp.openSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	expected, err := kp.emsaPKCS1v15Encode(message)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(m.FillBytes(make([]byte, kp.size())), expected) != 1 {
+		return errVerification
+	}
+
+	return nil
+}
+
+// openSignature applies the public-key primitive to a signature of the
+// right length, returning the encoded message representative.
+func (kp *RSAKeyPair) openSignature(signature []byte) (*big.Int, error) {
+	if kp.E == nil {
+		return nil, errors.New("public key required to verify")
+	}
+	if len(signature) != kp.size() {
+		return nil, errVerification
+	}
+
+	s := new(big.Int).SetBytes(signature)
+	if s.Cmp(kp.N) >= 0 {
+		return nil, errVerification
+	}
+
+	return kp.encrypt(s), nil
+}
+
+// emsaPKCS1v15Encode builds EM = 0x00 || 0x01 || PS || 0x00 || DigestInfo.
+func (kp *RSAKeyPair) emsaPKCS1v15Encode(message []byte) ([]byte, error) {
+	hashed := sha256.Sum256(message)
+	tLen := len(sha256DigestInfoPrefix) + len(hashed)
+	k := kp.size()
+
+	if k < tLen+11 {
+		return nil, errors.New("key too small for PKCS #1 v1.5 signature")
+	}
+
+	em := make([]byte, k)
+	em[1] = 0x01
+	for i := 2; i < k-tLen-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-tLen:], sha256DigestInfoPrefix)
+	copy(em[k-len(hashed):], hashed[:])
+
+	return em, nil
+}
+
+// emsaPSSEncode implements EMSA-PSS-ENCODE (RFC 8017, section 9.1.1).
+func emsaPSSEncode(mHash []byte, emBits int, salt []byte) ([]byte, error) {
+	hash := sha256.New()
+	hLen := hash.Size()
+	sLen := len(salt)
+	emLen := (emBits + 7) / 8
+
+	if emLen < hLen+sLen+2 {
+		return nil, errors.New("key too small for PSS signature")
+	}
+
+	em := make([]byte, emLen)
+	db := em[:emLen-hLen-1]
+	h := em[emLen-hLen-1 : emLen-1]
+
+	// H = Hash(0x00 * 8 || mHash || salt)
+	var prefix [8]byte
+	hash.Write(prefix[:])
+	hash.Write(mHash)
+	hash.Write(salt)
+	h = hash.Sum(h[:0])
+	hash.Reset()
+
+	// DB = PS || 0x01 || salt
+	db[emLen-sLen-hLen-2] = 0x01
+	copy(db[emLen-sLen-hLen-1:], salt)
+
+	mgf1XOR(db, hash, h)
+	db[0] &= 0xff >> (8*emLen - emBits)
+	em[emLen-1] = 0xbc
+
+	return em, nil
+}
+
+// emsaPSSVerify implements EMSA-PSS-VERIFY (RFC 8017, section 9.1.2) with a
+// salt length equal to the hash length.
+func emsaPSSVerify(mHash, em []byte, emBits int) error {
+	hash := sha256.New()
+	hLen := hash.Size()
+	sLen := hLen
+	emLen := (emBits + 7) / 8
+
+	if emLen != len(em) || emLen < hLen+sLen+2 {
+		return errVerification
+	}
+	if em[emLen-1] != 0xbc {
+		return errVerification
+	}
+
+	db := em[:emLen-hLen-1]
+	h := em[emLen-hLen-1 : emLen-1]
+
+	bitMask := byte(0xff >> (8*emLen - emBits))
+	if db[0]&^bitMask != 0 {
+		return errVerification
+	}
+
+	mgf1XOR(db, hash, h)
+	db[0] &= bitMask
+
+	psLen := emLen - hLen - sLen - 2
+	for _, b := range db[:psLen] {
+		if b != 0x00 {
+			return errVerification
+		}
+	}
+	if db[psLen] != 0x01 {
+		return errVerification
+	}
+	salt := db[len(db)-sLen:]
+
+	var prefix [8]byte
+	hash.Write(prefix[:])
+	hash.Write(mHash)
+	hash.Write(salt)
+
+	if !bytes.Equal(hash.Sum(nil), h) {
+		return errVerification
+	}
+
+	return nil
+}
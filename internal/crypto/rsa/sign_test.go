@@ -0,0 +1,91 @@
+package rsa
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+	other := generateTestKey(t, 2048)
+
+	schemes := []struct {
+		name   string
+		sign   func(*RSAKeyPair, []byte) ([]byte, error)
+		verify func(*RSAKeyPair, []byte, []byte) error
+	}{
+		{"PSS", (*RSAKeyPair).SignPSS, (*RSAKeyPair).VerifyPSS},
+		{"PKCS1v15", (*RSAKeyPair).SignPKCS1v15, (*RSAKeyPair).VerifyPKCS1v15},
+	}
+
+	for _, scheme := range schemes {
+		t.Run(scheme.name, func(t *testing.T) {
+			message := []byte("pay bob 10 coins")
+
+			signature, err := scheme.sign(keyPair, message)
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			if err := scheme.verify(keyPair, message, signature); err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+
+			flipped := bytes.Clone(signature)
+			flipped[len(flipped)-1] ^= 0x01
+
+			for _, tt := range []struct {
+				name      string
+				keyPair   *RSAKeyPair
+				message   []byte
+				signature []byte
+			}{
+				{"other message", keyPair, []byte("pay bob 99 coins"), signature},
+				{"flipped bit", keyPair, message, flipped},
+				{"other key", other, message, signature},
+				{"truncated", keyPair, message, signature[1:]},
+				{"empty", keyPair, message, nil},
+			} {
+				if err := scheme.verify(tt.keyPair, tt.message, tt.signature); err == nil {
+					t.Errorf("%s: verify succeeded", tt.name)
+				}
+			}
+		})
+	}
+}
+
+func TestPSSIsRandomized(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+
+	first, err := keyPair.SignPSS([]byte("message"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := keyPair.SignPSS([]byte("message"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(first, second) {
+		t.Fatal("two PSS signatures of the same message are equal")
+	}
+}
+
+func TestSchemesDoNotVerifyEachOther(t *testing.T) {
+	keyPair := generateTestKey(t, 2048)
+	message := []byte("message")
+
+	pss, err := keyPair.SignPSS(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keyPair.VerifyPKCS1v15(message, pss); err == nil {
+		t.Error("a PSS signature verified as PKCS #1 v1.5")
+	}
+
+	pkcs1, err := keyPair.SignPKCS1v15(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keyPair.VerifyPSS(message, pkcs1); err == nil {
+		t.Error("a PKCS #1 v1.5 signature verified as PSS")
+	}
+}
@@ -0,0 +1,39 @@
+package rsa
+
+import "github.com/luizgbraga/crypto-go/internal/crypto"
+
+// PSSSigner exposes RSAProvider's RSA-PSS signatures as a crypto.Signer. It
+// shares the provider's key, which stays registered under crypto.RSA.
+type PSSSigner struct {
+	provider *RSAProvider
+}
+
+func NewPSSSigner(provider *RSAProvider) *PSSSigner {
+	return &PSSSigner{provider: provider}
+}
+
+var _ crypto.Signer = (*PSSSigner)(nil)
+
+func (s *PSSSigner) GenerateKey() error {
+	return s.provider.GenerateKey()
+}
+
+func (s *PSSSigner) PublicKey() ([]byte, error) {
+	return s.provider.PublicKey()
+}
+
+func (s *PSSSigner) ParsePublicKey(userID string, publicKeyData []byte) error {
+	return s.provider.ParsePublicKey(userID, publicKeyData)
+}
+
+func (s *PSSSigner) AnswerChallenge(userID string, nonce []byte) ([]byte, error) {
+	return s.provider.AnswerChallenge(userID, nonce)
+}
+
+func (s *PSSSigner) Sign(message []byte) ([]byte, error) {
+	return s.provider.Sign(message, crypto.RSAPSS)
+}
+
+func (s *PSSSigner) Verify(message, signature []byte, signerID string) error {
+	return s.provider.Verify(message, signature, signerID, crypto.RSAPSS)
+}
@@ -0,0 +1,198 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Prime is the field modulus, the Mersenne prime 2^521 - 1.
+var Prime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1))
+
+// ChunkSize is how many secret bytes each field element carries. 64-byte
+// chunks are always below Prime.
+const ChunkSize = 64
+
+// elementSize is the length of an encoded field element.
+const elementSize = (521 + 7) / 8
+
+// MaxShares is the largest number of shares Split creates; share numbers
+// are encoded in one byte.
+const MaxShares = 255
+
+// SetIDSize is the length of the random ID that ties a split's shares
+// together.
+const SetIDSize = 8
+
+// Share is one of the n shares of a secret. The secret is cut into
+// ChunkSize-byte chunks and each chunk c is the constant term of a random
+// polynomial f_c of degree Threshold-1; the share holds Y[c] = f_c(X).
+type Share struct {
+	SetID     []byte
+	X         int
+	Threshold int
+	Length    int
+	Y         []*big.Int
+}
+
+// Split splits the secret into n shares, any threshold of which rebuild it
+// and any fewer of which reveal nothing about it but its length.
+func Split(secret []byte, n, threshold int) ([]Share, error) {
+	if threshold < 2 {
+		return nil, errors.New("threshold must be at least 2")
+	}
+	if n < threshold || n > MaxShares {
+		return nil, fmt.Errorf("number of shares must be between the threshold and %d", MaxShares)
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("secret must not be empty")
+	}
+
+	setID := make([]byte, SetIDSize)
+	if _, err := rand.Read(setID); err != nil {
+		return nil, err
+	}
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{
+			SetID:     setID,
+			X:         i + 1,
+			Threshold: threshold,
+			Length:    len(secret),
+		}
+	}
+
+	for start := 0; start < len(secret); start += ChunkSize {
+		end := min(start+ChunkSize, len(secret))
+
+		coefficients := make([]*big.Int, threshold)
+		coefficients[0] = new(big.Int).SetBytes(secret[start:end])
+		for j := 1; j < threshold; j++ {
+			c, err := rand.Int(rand.Reader, Prime)
+			if err != nil {
+				return nil, err
+			}
+			coefficients[j] = c
+		}
+
+		for i := range shares {
+			shares[i].Y = append(shares[i].Y, evaluate(coefficients, big.NewInt(int64(shares[i].X))))
+		}
+	}
+
+	return shares, nil
+}
+
+// evaluate computes the polynomial at x with Horner's rule.
+func evaluate(coefficients []*big.Int, x *big.Int) *big.Int {
+	y := new(big.Int)
+	for j := len(coefficients) - 1; j >= 0; j-- {
+		y.Mul(y, x)
+		y.Add(y, coefficients[j])
+		y.Mod(y, Prime)
+	}
+	return y
+}
+
+// Combine rebuilds the secret from the first Threshold shares by Lagrange
+// interpolation at 0:
+//
+//	f(0) = Σᵢ yᵢ · Πⱼ≠ᵢ xⱼ / (xⱼ - xᵢ)
+//
+// Any further shares must lie on the same polynomial, so with more than
+// Threshold shares a corrupted one is reported instead of giving a wrong
+// secret.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares given")
+	}
+
+	first := shares[0]
+	if len(shares) < first.Threshold {
+		return nil, fmt.Errorf("need %d shares, got %d", first.Threshold, len(shares))
+	}
+
+	chunks := (first.Length + ChunkSize - 1) / ChunkSize
+	seen := make(map[int]bool)
+	for _, share := range shares {
+		if string(share.SetID) != string(first.SetID) {
+			return nil, errors.New("shares come from different splits")
+		}
+		if share.Threshold != first.Threshold || share.Length != first.Length || len(share.Y) != chunks {
+			return nil, errors.New("shares disagree on the threshold or secret length")
+		}
+		if share.X < 1 || share.X > MaxShares {
+			return nil, fmt.Errorf("invalid share number %d", share.X)
+		}
+		if seen[share.X] {
+			return nil, fmt.Errorf("share %d given twice", share.X)
+		}
+		seen[share.X] = true
+	}
+
+	basis, extra := shares[:first.Threshold], shares[first.Threshold:]
+	weights := lagrangeWeights(basis, 0)
+
+	extraWeights := make([][]*big.Int, len(extra))
+	for i, share := range extra {
+		extraWeights[i] = lagrangeWeights(basis, share.X)
+	}
+
+	secret := make([]byte, 0, chunks*ChunkSize)
+	for c := 0; c < chunks; c++ {
+		for i, share := range extra {
+			if interpolate(basis, extraWeights[i], c).Cmp(share.Y[c]) != 0 {
+				return nil, fmt.Errorf("share %d does not fit the others", share.X)
+			}
+		}
+
+		value := interpolate(basis, weights, c)
+
+		size := min(ChunkSize, first.Length-c*ChunkSize)
+		if (value.BitLen()+7)/8 > size {
+			return nil, errors.New("shares do not fit together")
+		}
+		secret = append(secret, value.FillBytes(make([]byte, size))...)
+	}
+
+	return secret, nil
+}
+
+// interpolate returns Σᵢ yᵢ · weightᵢ mod Prime over chunk c of the shares.
+func interpolate(shares []Share, weights []*big.Int, c int) *big.Int {
+	value := new(big.Int)
+	for i, share := range shares {
+		term := new(big.Int).Mul(share.Y[c], weights[i])
+		value.Add(value, term)
+	}
+	return value.Mod(value, Prime)
+}
+
+// lagrangeWeights returns Πⱼ≠ᵢ (xⱼ - x) / (xⱼ - xᵢ) mod Prime for each
+// share, the weights that evaluate the polynomial at x.
+func lagrangeWeights(shares []Share, x int) []*big.Int {
+	weights := make([]*big.Int, len(shares))
+	for i, share := range shares {
+		numerator, denominator := big.NewInt(1), big.NewInt(1)
+		xi := big.NewInt(int64(share.X))
+
+		for j, other := range shares {
+			if i == j {
+				continue
+			}
+			xj := big.NewInt(int64(other.X))
+
+			numerator.Mul(numerator, new(big.Int).Sub(xj, big.NewInt(int64(x))))
+			numerator.Mod(numerator, Prime)
+
+			denominator.Mul(denominator, new(big.Int).Sub(xj, xi))
+			denominator.Mod(denominator, Prime)
+		}
+
+		weights[i] = numerator.Mul(numerator, denominator.ModInverse(denominator, Prime))
+		weights[i].Mod(weights[i], Prime)
+	}
+	return weights
+}
@@ -0,0 +1,198 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// subsets calls f with every k-element subset of shares.
+func subsets(shares []Share, k int, f func([]Share)) {
+	var walk func(start int, chosen []Share)
+	walk = func(start int, chosen []Share) {
+		if len(chosen) == k {
+			f(append([]Share(nil), chosen...))
+			return
+		}
+		for i := start; i < len(shares); i++ {
+			walk(i+1, append(chosen, shares[i]))
+		}
+	}
+	walk(0, nil)
+}
+
+func randomSecret(t *testing.T, length int) []byte {
+	t.Helper()
+
+	secret := make([]byte, length)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatal(err)
+	}
+	return secret
+}
+
+func TestSplitCombineEverySubset(t *testing.T) {
+	for _, tt := range []struct{ threshold, n int }{
+		{2, 2}, {2, 3}, {3, 5}, {4, 7}, {5, 5},
+	} {
+		// Two and a half chunks, with a leading zero that must survive.
+		secret := randomSecret(t, 2*ChunkSize+ChunkSize/2)
+		secret[0] = 0
+
+		shares, err := Split(secret, tt.n, tt.threshold)
+		if err != nil {
+			t.Fatalf("Split(%d of %d): %v", tt.threshold, tt.n, err)
+		}
+
+		for k := tt.threshold; k <= tt.n; k++ {
+			subsets(shares, k, func(subset []Share) {
+				combined, err := Combine(subset)
+				if err != nil {
+					t.Fatalf("%d of %d: Combine of %d shares: %v", tt.threshold, tt.n, k, err)
+				}
+				if !bytes.Equal(combined, secret) {
+					t.Fatalf("%d of %d: Combine of %d shares gave the wrong secret", tt.threshold, tt.n, k)
+				}
+			})
+		}
+
+		subsets(shares, tt.threshold-1, func(subset []Share) {
+			if _, err := Combine(subset); err == nil {
+				t.Fatalf("%d of %d: Combine succeeded with %d shares", tt.threshold, tt.n, len(subset))
+			}
+		})
+	}
+}
+
+func TestSplitRejects(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		secret       []byte
+		n, threshold int
+	}{
+		{"threshold 1", []byte("s"), 3, 1},
+		{"n below threshold", []byte("s"), 2, 3},
+		{"n above MaxShares", []byte("s"), MaxShares + 1, 2},
+		{"empty secret", nil, 3, 2},
+	} {
+		if _, err := Split(tt.secret, tt.n, tt.threshold); err == nil {
+			t.Errorf("%s: Split succeeded", tt.name)
+		}
+	}
+}
+
+func TestCombineRejectsMismatchedShares(t *testing.T) {
+	secret := []byte("correct horse battery staple")
+	shares, err := Split(secret, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Split(secret, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		shares []Share
+	}{
+		{"none", nil},
+		{"same share twice", []Share{shares[0], shares[0]}},
+		{"different splits", []Share{shares[0], other[1]}},
+		{"share number 0", []Share{shares[0], withX(shares[1], 0)}},
+	} {
+		if _, err := Combine(tt.shares); err == nil {
+			t.Errorf("%s: Combine succeeded", tt.name)
+		}
+	}
+
+	// A changed Y value cannot be detected with exactly Threshold shares,
+	// but it must not yield the secret.
+	tampered := shares[1]
+	tampered.Y = []*big.Int{new(big.Int).Add(shares[1].Y[0], big.NewInt(1))}
+	if combined, err := Combine([]Share{shares[0], tampered}); err == nil && bytes.Equal(combined, secret) {
+		t.Error("a tampered share still gave the secret")
+	}
+
+	// With one share more than Threshold it is caught, wherever it is.
+	for _, set := range [][]Share{
+		{shares[0], shares[2], tampered},
+		{tampered, shares[0], shares[2]},
+	} {
+		if _, err := Combine(set); err == nil {
+			t.Errorf("Combine accepted a tampered share %d among %d", tampered.X, len(set))
+		}
+	}
+}
+
+func withX(share Share, x int) Share {
+	share.X = x
+	return share
+}
+
+func TestShareTextRoundTrip(t *testing.T) {
+	secret := randomSecret(t, 300)
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pasted string
+	for _, share := range shares[1:4] {
+		pasted += EncodeShare("RSA", share) + "\n"
+	}
+
+	label, decoded, err := DecodeShares(pasted)
+	if err != nil {
+		t.Fatalf("DecodeShares: %v", err)
+	}
+	if label != "RSA" || len(decoded) != 3 {
+		t.Fatalf("DecodeShares = %q with %d shares", label, len(decoded))
+	}
+
+	combined, err := Combine(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(combined, secret) {
+		t.Fatal("decoded shares gave the wrong secret")
+	}
+}
+
+func TestDecodeSharesRejects(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := EncodeShare("RSA", shares[0])
+
+	replace := func(old, new string) string {
+		return strings.Replace(block, old, new, 1)
+	}
+
+	// Change the first character of the payload.
+	lines := strings.Split(block, "\n")
+	payloadLine := lines[7]
+	changed := "A"
+	if payloadLine[0] == 'A' {
+		changed = "B"
+	}
+
+	for _, tt := range []struct {
+		name string
+		text string
+	}{
+		{"empty", ""},
+		{"no end line", block[:len(block)-len(endLine)-1]},
+		{"changed share number", replace("Share: 1", "Share: 2")},
+		{"changed label", replace("Label: RSA", "Label: DSA")},
+		{"mixed labels", block + EncodeShare("DSA", shares[1])},
+		{"changed payload", replace(payloadLine, changed+payloadLine[1:])},
+	} {
+		if _, _, err := DecodeShares(tt.text); err == nil {
+			t.Errorf("%s: DecodeShares succeeded", tt.name)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package shamir
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	beginLine = "-----BEGIN SHAMIR SHARE-----"
+	endLine   = "-----END SHAMIR SHARE-----"
+
+	// lineLength is where the base64 payload is wrapped.
+	lineLength = 64
+
+	// checksumSize is the number of SHA-256 bytes kept as the checksum.
+	checksumSize = 8
+)
+
+// EncodeShare writes a share as a text block that can be copied around:
+//
+//	-----BEGIN SHAMIR SHARE-----
+//	Label: RSA
+//	Set: 9f2c…
+//	Share: 2
+//	Threshold: 3
+//	Length: 1234
+//	Checksum: 5e1a…
+//	<base64 of the Y values, wrapped at 64 columns>
+//	-----END SHAMIR SHARE-----
+//
+// The block has no blank lines, so it can be pasted as one paragraph. The
+// label says what the secret is. The checksum covers the headers and the
+// payload, so typos and truncation are caught; it is not a signature and
+// does not stop deliberately forged shares.
+func EncodeShare(label string, share Share) string {
+	payload := make([]byte, 0, len(share.Y)*elementSize)
+	for _, y := range share.Y {
+		payload = append(payload, y.FillBytes(make([]byte, elementSize))...)
+	}
+
+	headers := shareHeaders(label, share)
+
+	var block strings.Builder
+	block.WriteString(beginLine + "\n")
+	block.WriteString(headers)
+	fmt.Fprintf(&block, "Checksum: %s\n", hex.EncodeToString(checksum(headers, payload)))
+
+	encoded := base64.StdEncoding.EncodeToString(payload)
+	for len(encoded) > lineLength {
+		block.WriteString(encoded[:lineLength] + "\n")
+		encoded = encoded[lineLength:]
+	}
+	block.WriteString(encoded + "\n")
+	block.WriteString(endLine + "\n")
+
+	return block.String()
+}
+
+func shareHeaders(label string, share Share) string {
+	return fmt.Sprintf("Label: %s\nSet: %s\nShare: %d\nThreshold: %d\nLength: %d\n",
+		label, hex.EncodeToString(share.SetID), share.X, share.Threshold, share.Length)
+}
+
+func checksum(headers string, payload []byte) []byte {
+	hash := sha256.New()
+	hash.Write([]byte(headers))
+	hash.Write(payload)
+	return hash.Sum(nil)[:checksumSize]
+}
+
+// DecodeShares parses every share block in text, such as several pasted
+// blocks, and checks each one's checksum. All blocks must have the same
+// label, which is returned with the shares.
+func DecodeShares(text string) (string, []Share, error) {
+	var label string
+	var shares []Share
+
+	rest := text
+	for {
+		start := strings.Index(rest, beginLine)
+		if start < 0 {
+			break
+		}
+		rest = rest[start+len(beginLine):]
+
+		end := strings.Index(rest, endLine)
+		if end < 0 {
+			return "", nil, fmt.Errorf("share %d has no end line", len(shares)+1)
+		}
+
+		blockLabel, share, err := decodeBlock(rest[:end])
+		if err != nil {
+			return "", nil, fmt.Errorf("share %d: %v", len(shares)+1, err)
+		}
+		rest = rest[end+len(endLine):]
+
+		if len(shares) > 0 && blockLabel != label {
+			return "", nil, fmt.Errorf("share %d is for %s, not %s", len(shares)+1, blockLabel, label)
+		}
+		label = blockLabel
+		shares = append(shares, share)
+	}
+
+	if len(shares) == 0 {
+		return "", nil, errors.New("no shares found")
+	}
+
+	return label, shares, nil
+}
+
+func decodeBlock(block string) (string, Share, error) {
+	fields := make(map[string]string)
+	var payloadLines []string
+
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if name, value, found := strings.Cut(line, ": "); found {
+			fields[name] = value
+		} else {
+			payloadLines = append(payloadLines, line)
+		}
+	}
+
+	var share Share
+	var err error
+
+	label := fields["Label"]
+	if label == "" {
+		return "", share, errors.New("missing Label")
+	}
+
+	share.SetID, err = hex.DecodeString(fields["Set"])
+	if err != nil || len(share.SetID) != SetIDSize {
+		return "", share, errors.New("invalid Set")
+	}
+
+	for name, value := range map[string]*int{"Share": &share.X, "Threshold": &share.Threshold, "Length": &share.Length} {
+		*value, err = strconv.Atoi(fields[name])
+		if err != nil || *value <= 0 {
+			return "", share, fmt.Errorf("invalid %s", name)
+		}
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(strings.Join(payloadLines, ""))
+	if err != nil {
+		return "", share, errors.New("invalid payload encoding")
+	}
+
+	want, err := hex.DecodeString(fields["Checksum"])
+	if err != nil || subtle.ConstantTimeCompare(want, checksum(shareHeaders(label, share), payload)) != 1 {
+		return "", share, errors.New("checksum mismatch: the share is corrupted")
+	}
+
+	if len(payload)%elementSize != 0 {
+		return "", share, errors.New("invalid payload length")
+	}
+	for start := 0; start < len(payload); start += elementSize {
+		y := new(big.Int).SetBytes(payload[start : start+elementSize])
+		if y.Cmp(Prime) >= 0 {
+			return "", share, errors.New("share value out of range")
+		}
+		share.Y = append(share.Y, y)
+	}
+
+	return label, share, nil
+}
@@ -0,0 +1,311 @@
+package threshold
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto/elgamal"
+)
+
+var one = big.NewInt(1)
+
+var errVerification = errors.New("verification error")
+
+// Group is a built-in ElGamal group whose generator has prime order
+// Q = (P-1)/2. Secrets, shares and proof values live in Z_Q.
+type Group struct {
+	Name string
+	P    *big.Int
+	G    *big.Int
+	Q    *big.Int
+}
+
+// LookupGroup returns one of the built-in ElGamal groups, checking that G
+// generates the subgroup of order Q.
+func LookupGroup(name string) (*Group, error) {
+	group, err := elgamal.LookupGroup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	q := new(big.Int).Sub(group.P, one)
+	q.Rsh(q, 1)
+
+	if new(big.Int).Exp(group.G, q, group.P).Cmp(one) != 0 {
+		return nil, fmt.Errorf("group %s: G does not have order (P-1)/2", group.Name)
+	}
+
+	return &Group{Name: group.Name, P: group.P, G: group.G, Q: q}, nil
+}
+
+// IsElement reports whether v is in the subgroup of order Q.
+func (g *Group) IsElement(v *big.Int) bool {
+	return v.Sign() > 0 && v.Cmp(g.P) < 0 && new(big.Int).Exp(v, g.Q, g.P).Cmp(one) == 0
+}
+
+func (g *Group) randomScalar() (*big.Int, error) {
+	return rand.Int(rand.Reader, g.Q)
+}
+
+// Dealing is one party's contribution to the distributed key generation
+// (Pedersen's DKG). The party picks a random polynomial f of degree t-1 over
+// Z_Q, publishes Commitments[k] = G^(coefficient k) and gives member j (from
+// 1) the secret Shares[j] = f(j). Its part of the group key is
+// Commitments[0] = G^f(0).
+type Dealing struct {
+	Commitments []*big.Int
+	Shares      map[int]*big.Int
+}
+
+// Deal creates a dealing for n members of whom any t can decrypt. With t = 1
+// every member would hold the whole key, so t must be at least 2.
+func Deal(group *Group, t, n int) (*Dealing, error) {
+	if t < 2 || t > n {
+		return nil, errors.New("threshold must be between 2 and the number of members")
+	}
+
+	coefficients := make([]*big.Int, t)
+	dealing := &Dealing{
+		Commitments: make([]*big.Int, t),
+		Shares:      make(map[int]*big.Int, n),
+	}
+
+	for k := range coefficients {
+		c, err := group.randomScalar()
+		if err != nil {
+			return nil, err
+		}
+		coefficients[k] = c
+		dealing.Commitments[k] = new(big.Int).Exp(group.G, c, group.P)
+	}
+
+	for j := 1; j <= n; j++ {
+		// Horner's rule mod Q
+		share := new(big.Int)
+		x := big.NewInt(int64(j))
+		for k := t - 1; k >= 0; k-- {
+			share.Mul(share, x)
+			share.Add(share, coefficients[k])
+			share.Mod(share, group.Q)
+		}
+		dealing.Shares[j] = share
+	}
+
+	return dealing, nil
+}
+
+// CommittedShare returns G^f(j) computed from a dealing's commitments alone:
+// Π_k Commitments[k]^(j^k).
+func CommittedShare(group *Group, commitments []*big.Int, j int) *big.Int {
+	result := big.NewInt(1)
+	power := big.NewInt(1)
+	x := big.NewInt(int64(j))
+
+	for _, c := range commitments {
+		result.Mul(result, new(big.Int).Exp(c, power, group.P))
+		result.Mod(result, group.P)
+
+		power.Mul(power, x)
+		power.Mod(power, group.Q)
+	}
+
+	return result
+}
+
+// VerifyShare checks a received share against the dealer's commitments:
+// G^share = Π_k Commitments[k]^(j^k).
+func VerifyShare(group *Group, commitments []*big.Int, j int, share *big.Int) error {
+	if share.Sign() < 0 || share.Cmp(group.Q) >= 0 {
+		return errors.New("share out of range")
+	}
+
+	if new(big.Int).Exp(group.G, share, group.P).Cmp(CommittedShare(group, commitments, j)) != 0 {
+		return errors.New("share does not match the dealer's commitments")
+	}
+	return nil
+}
+
+// CheckCommitments checks that a dealing's commitments are t elements of the
+// group.
+func CheckCommitments(group *Group, commitments []*big.Int, t int) error {
+	if len(commitments) != t {
+		return fmt.Errorf("expected %d commitments, got %d", t, len(commitments))
+	}
+	for _, c := range commitments {
+		if !group.IsElement(c) {
+			return errors.New("commitment is not a group element")
+		}
+	}
+	return nil
+}
+
+// PublicKey returns the group key Y, the product of every dealer's part
+// Commitments[0]. The matching secret Σ f_i(0) is never held by anyone.
+func PublicKey(group *Group, dealings [][]*big.Int) *big.Int {
+	y := big.NewInt(1)
+	for _, commitments := range dealings {
+		y.Mul(y, commitments[0])
+		y.Mod(y, group.P)
+	}
+	return y
+}
+
+// VerificationKey returns Y_j = G^(x_j) for member j's key share
+// x_j = Σ_i f_i(j), from the commitments of all dealings.
+func VerificationKey(group *Group, dealings [][]*big.Int, j int) *big.Int {
+	y := big.NewInt(1)
+	for _, commitments := range dealings {
+		y.Mul(y, CommittedShare(group, commitments, j))
+		y.Mod(y, group.P)
+	}
+	return y
+}
+
+// KeyShare adds up the shares a member received, one per dealing.
+func KeyShare(group *Group, shares []*big.Int) *big.Int {
+	x := new(big.Int)
+	for _, share := range shares {
+		x.Add(x, share)
+	}
+	return x.Mod(x, group.Q)
+}
+
+// PartialDecrypt returns d = a^x for the key share x together with a
+// Chaum–Pedersen proof that log_G(Y_j) = log_a(d), where Y_j = G^x.
+func PartialDecrypt(group *Group, x, a *big.Int) (*big.Int, []byte, error) {
+	if !group.IsElement(a) {
+		return nil, nil, errors.New("a is not a group element")
+	}
+
+	d := new(big.Int).Exp(a, x, group.P)
+	yj := new(big.Int).Exp(group.G, x, group.P)
+
+	proof, err := proveEqualLogs(group, x, a, yj, d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, proof, nil
+}
+
+// proveEqualLogs makes a non-interactive Chaum–Pedersen proof that
+// Y = G^x and D = A^x for the same x. With a random w:
+//
+//	t1 = G^w, t2 = A^w, c = H(G, A, Y, D, t1, t2) mod Q, z = w + c·x mod Q
+//
+// The proof is (c, z).
+func proveEqualLogs(group *Group, x, a, y, d *big.Int) ([]byte, error) {
+	w, err := group.randomScalar()
+	if err != nil {
+		return nil, err
+	}
+
+	t1 := new(big.Int).Exp(group.G, w, group.P)
+	t2 := new(big.Int).Exp(a, w, group.P)
+	c := challenge(group, a, y, d, t1, t2)
+
+	z := new(big.Int).Mul(c, x)
+	z.Add(z, w)
+	z.Mod(z, group.Q)
+
+	size := group.scalarSize()
+	proof := make([]byte, 2*size)
+	c.FillBytes(proof[:size])
+	z.FillBytes(proof[size:])
+	return proof, nil
+}
+
+// VerifyPartial checks a partial decryption d of a against member j's
+// verification key Y_j. It recomputes t1 = G^z · Y_j^(-c) and
+// t2 = a^z · d^(-c) and checks that they hash to c.
+func VerifyPartial(group *Group, yj, a, d *big.Int, proof []byte) error {
+	size := group.scalarSize()
+	if len(proof) != 2*size || !group.IsElement(a) || !group.IsElement(d) {
+		return errVerification
+	}
+
+	c := new(big.Int).SetBytes(proof[:size])
+	z := new(big.Int).SetBytes(proof[size:])
+	if c.Cmp(group.Q) >= 0 || z.Cmp(group.Q) >= 0 {
+		return errVerification
+	}
+
+	negC := new(big.Int).Sub(group.Q, c)
+
+	t1 := new(big.Int).Exp(group.G, z, group.P)
+	t1.Mul(t1, new(big.Int).Exp(yj, negC, group.P))
+	t1.Mod(t1, group.P)
+
+	t2 := new(big.Int).Exp(a, z, group.P)
+	t2.Mul(t2, new(big.Int).Exp(d, negC, group.P))
+	t2.Mod(t2, group.P)
+
+	if challenge(group, a, yj, d, t1, t2).Cmp(c) != 0 {
+		return errVerification
+	}
+	return nil
+}
+
+func challenge(group *Group, values ...*big.Int) *big.Int {
+	size := (group.P.BitLen() + 7) / 8
+
+	hash := sha256.New()
+	hash.Write(group.G.FillBytes(make([]byte, size)))
+	for _, v := range values {
+		hash.Write(v.FillBytes(make([]byte, size)))
+	}
+
+	c := new(big.Int).SetBytes(hash.Sum(nil))
+	return c.Mod(c, group.Q)
+}
+
+func (g *Group) scalarSize() int {
+	return (g.Q.BitLen() + 7) / 8
+}
+
+// Combine recovers a^x from t partial decryptions, keyed by member number,
+// with Lagrange coefficients at 0 computed mod Q, and returns the plaintext
+// b / a^x. The partials must already have been verified.
+func Combine(group *Group, b *big.Int, partials map[int]*big.Int) ([]byte, error) {
+	if len(partials) == 0 {
+		return nil, errors.New("no partial decryptions")
+	}
+
+	ax := big.NewInt(1)
+	for i, d := range partials {
+		numerator, denominator := big.NewInt(1), big.NewInt(1)
+		for j := range partials {
+			if i == j {
+				continue
+			}
+			numerator.Mul(numerator, big.NewInt(int64(j)))
+			numerator.Mod(numerator, group.Q)
+
+			denominator.Mul(denominator, big.NewInt(int64(j-i)))
+			denominator.Mod(denominator, group.Q)
+		}
+
+		lambda := numerator.Mul(numerator, denominator.ModInverse(denominator, group.Q))
+		lambda.Mod(lambda, group.Q)
+
+		ax.Mul(ax, new(big.Int).Exp(d, lambda, group.P))
+		ax.Mod(ax, group.P)
+	}
+
+	axInv := new(big.Int).ModInverse(ax, group.P)
+	if axInv == nil {
+		return nil, errors.New("partial decryptions do not combine")
+	}
+
+	m := axInv.Mul(axInv, b)
+	return m.Mod(m, group.P).Bytes(), nil
+}
+
+// EncodePublicKey writes the group key in the ElGamal public key format, so
+// senders can encrypt to a mailbox with the ordinary ElGamal code.
+func EncodePublicKey(group *Group, y *big.Int) string {
+	return fmt.Sprintf("%s,%s,%s,%s", y.String(), group.P.String(), group.G.String(), group.Name)
+}
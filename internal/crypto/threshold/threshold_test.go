@@ -0,0 +1,209 @@
+package threshold
+
+import (
+	"bytes"
+	"math/big"
+	mrand "math/rand/v2"
+	"testing"
+)
+
+// dkg runs the key generation for n members with threshold t and returns
+// the group key, each member's key share (by member number) and every
+// dealing's commitments.
+func dkg(t *testing.T, group *Group, threshold, n int) (*big.Int, map[int]*big.Int, [][]*big.Int) {
+	t.Helper()
+
+	var commitments [][]*big.Int
+	received := make(map[int][]*big.Int)
+
+	for dealer := 0; dealer < n; dealer++ {
+		dealing, err := Deal(group, threshold, n)
+		if err != nil {
+			t.Fatalf("Deal: %v", err)
+		}
+		if err := CheckCommitments(group, dealing.Commitments, threshold); err != nil {
+			t.Fatalf("CheckCommitments: %v", err)
+		}
+
+		for j := 1; j <= n; j++ {
+			if err := VerifyShare(group, dealing.Commitments, j, dealing.Shares[j]); err != nil {
+				t.Fatalf("VerifyShare(dealer %d, member %d): %v", dealer, j, err)
+			}
+			received[j] = append(received[j], dealing.Shares[j])
+		}
+		commitments = append(commitments, dealing.Commitments)
+	}
+
+	keyShares := make(map[int]*big.Int)
+	for j := 1; j <= n; j++ {
+		keyShares[j] = KeyShare(group, received[j])
+
+		yj := new(big.Int).Exp(group.G, keyShares[j], group.P)
+		if yj.Cmp(VerificationKey(group, commitments, j)) != 0 {
+			t.Fatalf("member %d's key share does not match its verification key", j)
+		}
+	}
+
+	return PublicKey(group, commitments), keyShares, commitments
+}
+
+func testGroup(t *testing.T) *Group {
+	t.Helper()
+
+	group, err := LookupGroup("modp2048")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return group
+}
+
+// encrypt returns a = G^k and b = m · Y^k.
+func encrypt(t *testing.T, group *Group, y *big.Int, message []byte) (*big.Int, *big.Int) {
+	t.Helper()
+
+	k, err := group.randomScalar()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := new(big.Int).Exp(group.G, k, group.P)
+	b := new(big.Int).Exp(y, k, group.P)
+	b.Mul(b, new(big.Int).SetBytes(message))
+	return a, b.Mod(b, group.P)
+}
+
+func TestDKGAndCombine(t *testing.T) {
+	group := testGroup(t)
+	const threshold, n = 3, 5
+
+	y, keyShares, commitments := dkg(t, group, threshold, n)
+	message := []byte("meet at noon")
+	a, b := encrypt(t, group, y, message)
+
+	partials := make(map[int]*big.Int)
+	for j := 1; j <= n; j++ {
+		d, proof, err := PartialDecrypt(group, keyShares[j], a)
+		if err != nil {
+			t.Fatalf("PartialDecrypt(%d): %v", j, err)
+		}
+		if err := VerifyPartial(group, VerificationKey(group, commitments, j), a, d, proof); err != nil {
+			t.Fatalf("VerifyPartial(%d): %v", j, err)
+		}
+		partials[j] = d
+	}
+
+	// Every t-subset decrypts.
+	for i := 1; i <= n; i++ {
+		for j := i + 1; j <= n; j++ {
+			for k := j + 1; k <= n; k++ {
+				subset := map[int]*big.Int{i: partials[i], j: partials[j], k: partials[k]}
+				decrypted, err := Combine(group, b, subset)
+				if err != nil {
+					t.Fatalf("Combine(%d, %d, %d): %v", i, j, k, err)
+				}
+				if !bytes.Equal(decrypted, message) {
+					t.Fatalf("Combine(%d, %d, %d) = %q", i, j, k, decrypted)
+				}
+			}
+		}
+	}
+
+	// So does a random t-subset, and fewer than t do not.
+	members := mrand.Perm(n)
+	subset := make(map[int]*big.Int)
+	for _, m := range members[:threshold] {
+		subset[m+1] = partials[m+1]
+	}
+	decrypted, err := Combine(group, b, subset)
+	if err != nil || !bytes.Equal(decrypted, message) {
+		t.Fatalf("Combine of members %v = %q, %v", members[:threshold], decrypted, err)
+	}
+
+	delete(subset, members[0]+1)
+	if decrypted, err := Combine(group, b, subset); err == nil && bytes.Equal(decrypted, message) {
+		t.Fatal("fewer than t partial decryptions gave the message")
+	}
+}
+
+func TestVerifyPartialRejects(t *testing.T) {
+	group := testGroup(t)
+	y, keyShares, commitments := dkg(t, group, 2, 3)
+	a, _ := encrypt(t, group, y, []byte("m"))
+
+	y1 := VerificationKey(group, commitments, 1)
+	y2 := VerificationKey(group, commitments, 2)
+
+	d, proof, err := PartialDecrypt(group, keyShares[1], a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A wrong d, even a group element, must fail.
+	wrongD := new(big.Int).Mul(d, group.G)
+	wrongD.Mod(wrongD, group.P)
+
+	// d computed with another member's share.
+	otherD, otherProof, err := PartialDecrypt(group, keyShares[2], a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flipped := bytes.Clone(proof)
+	flipped[len(flipped)-1] ^= 0x01
+
+	for _, tt := range []struct {
+		name  string
+		yj    *big.Int
+		d     *big.Int
+		proof []byte
+	}{
+		{"wrong d", y1, wrongD, proof},
+		{"other member's d", y1, otherD, proof},
+		{"other member's d and proof", y1, otherD, otherProof},
+		{"other member's key", y2, d, proof},
+		{"flipped proof", y1, d, flipped},
+		{"truncated proof", y1, d, proof[1:]},
+		{"d not in the group", y1, new(big.Int).Sub(group.P, one), proof},
+	} {
+		if err := VerifyPartial(group, tt.yj, a, tt.d, tt.proof); err == nil {
+			t.Errorf("%s: VerifyPartial succeeded", tt.name)
+		}
+	}
+}
+
+func TestVerifyShareRejects(t *testing.T) {
+	group := testGroup(t)
+
+	dealing, err := Deal(group, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrong := new(big.Int).Add(dealing.Shares[1], one)
+	if err := VerifyShare(group, dealing.Commitments, 1, wrong); err == nil {
+		t.Error("VerifyShare accepted a wrong share")
+	}
+	if err := VerifyShare(group, dealing.Commitments, 2, dealing.Shares[1]); err == nil {
+		t.Error("VerifyShare accepted another member's share")
+	}
+	if err := VerifyShare(group, dealing.Commitments, 1, group.Q); err == nil {
+		t.Error("VerifyShare accepted a share out of range")
+	}
+
+	if err := CheckCommitments(group, dealing.Commitments[:1], 2); err == nil {
+		t.Error("CheckCommitments accepted too few commitments")
+	}
+	bad := []*big.Int{dealing.Commitments[0], new(big.Int).Sub(group.P, one)}
+	if err := CheckCommitments(group, bad, 2); err == nil {
+		t.Error("CheckCommitments accepted P-1, which is not in the subgroup")
+	}
+}
+
+func TestDealRejects(t *testing.T) {
+	group := testGroup(t)
+
+	for _, tt := range []struct{ threshold, n int }{{0, 3}, {1, 3}, {4, 3}} {
+		if _, err := Deal(group, tt.threshold, tt.n); err == nil {
+			t.Errorf("Deal(%d of %d) succeeded", tt.threshold, tt.n)
+		}
+	}
+}
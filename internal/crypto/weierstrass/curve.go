@@ -0,0 +1,234 @@
+// Package weierstrass implements textbook elliptic-curve arithmetic with
+// math/big over user-supplied short Weierstrass curves
+//
+//	y² = x³ + ax + b (mod p)
+//
+// It is meant for working through examples by hand and is not constant
+// time; use the ecc package for real messages.
+package weierstrass
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	zero  = big.NewInt(0)
+	one   = big.NewInt(1)
+	two   = big.NewInt(2)
+	three = big.NewInt(3)
+)
+
+// Curve is y² = x³ + ax + b over the prime field of order P.
+type Curve struct {
+	P big.Int
+	A big.Int
+	B big.Int
+}
+
+// Point is an affine point. The point at infinity, the group identity, has
+// Infinity set and nil coordinates.
+type Point struct {
+	X, Y     *big.Int
+	Infinity bool
+}
+
+// NewCurve checks the parameters and returns the curve. P must be a prime
+// greater than 3 and the curve must be non-singular: 4a³ + 27b² ≠ 0 mod P.
+func NewCurve(p, a, b *big.Int) (*Curve, error) {
+	if p.Cmp(three) <= 0 || !p.ProbablyPrime(20) {
+		return nil, errors.New("P must be a prime greater than 3")
+	}
+
+	c := &Curve{}
+	c.P.Set(p)
+	c.A.Mod(a, p)
+	c.B.Mod(b, p)
+
+	if c.Discriminant().Sign() == 0 {
+		return nil, errors.New("curve is singular: 4a³ + 27b² = 0 mod P")
+	}
+
+	return c, nil
+}
+
+// Discriminant returns 4a³ + 27b² mod P.
+func (c *Curve) Discriminant() *big.Int {
+	a3 := new(big.Int).Exp(&c.A, three, &c.P)
+	a3.Mul(a3, big.NewInt(4))
+
+	b2 := new(big.Int).Mul(&c.B, &c.B)
+	b2.Mul(b2, big.NewInt(27))
+
+	return a3.Add(a3, b2).Mod(a3, &c.P)
+}
+
+func (c *Curve) String() string {
+	return fmt.Sprintf("y² = x³ + %sx + %s mod %s", &c.A, &c.B, &c.P)
+}
+
+// Infinity returns the point at infinity.
+func Infinity() Point {
+	return Point{Infinity: true}
+}
+
+// NewPoint returns the affine point (x, y) without checking it.
+func NewPoint(x, y *big.Int) Point {
+	return Point{X: new(big.Int).Set(x), Y: new(big.Int).Set(y)}
+}
+
+func (pt Point) String() string {
+	if pt.Infinity {
+		return "O"
+	}
+	return fmt.Sprintf("(%s, %s)", pt.X, pt.Y)
+}
+
+// Equal reports whether two points are the same.
+func (pt Point) Equal(other Point) bool {
+	if pt.Infinity || other.Infinity {
+		return pt.Infinity == other.Infinity
+	}
+	return pt.X.Cmp(other.X) == 0 && pt.Y.Cmp(other.Y) == 0
+}
+
+// rhs returns x³ + ax + b mod P.
+func (c *Curve) rhs(x *big.Int) *big.Int {
+	r := new(big.Int).Exp(x, three, &c.P)
+	ax := new(big.Int).Mul(&c.A, x)
+	r.Add(r, ax)
+	r.Add(r, &c.B)
+	return r.Mod(r, &c.P)
+}
+
+// IsOnCurve reports whether the point satisfies the curve equation with
+// coordinates in [0, P). The point at infinity is always on the curve.
+func (c *Curve) IsOnCurve(pt Point) bool {
+	if pt.Infinity {
+		return true
+	}
+	if pt.X == nil || pt.Y == nil {
+		return false
+	}
+	if pt.X.Sign() < 0 || pt.X.Cmp(&c.P) >= 0 || pt.Y.Sign() < 0 || pt.Y.Cmp(&c.P) >= 0 {
+		return false
+	}
+
+	y2 := new(big.Int).Mul(pt.Y, pt.Y)
+	y2.Mod(y2, &c.P)
+	return y2.Cmp(c.rhs(pt.X)) == 0
+}
+
+// Neg returns -pt = (x, -y).
+func (c *Curve) Neg(pt Point) Point {
+	if pt.Infinity {
+		return pt
+	}
+
+	y := new(big.Int).Neg(pt.Y)
+	return Point{X: new(big.Int).Set(pt.X), Y: y.Mod(y, &c.P)}
+}
+
+// Add returns p1 + p2 using the affine chord rule:
+//
+//	λ = (y2 - y1) / (x2 - x1)
+//	x3 = λ² - x1 - x2
+//	y3 = λ(x1 - x3) - y1
+func (c *Curve) Add(p1, p2 Point) Point {
+	if p1.Infinity {
+		return p2
+	}
+	if p2.Infinity {
+		return p1
+	}
+
+	if p1.X.Cmp(p2.X) == 0 {
+		if p1.Y.Cmp(p2.Y) == 0 {
+			return c.Double(p1)
+		}
+		// p2 = -p1
+		return Infinity()
+	}
+
+	num := new(big.Int).Sub(p2.Y, p1.Y)
+	den := new(big.Int).Sub(p2.X, p1.X)
+	return c.finish(p1, p2.X, c.divide(num, den))
+}
+
+// Double returns 2pt using the affine tangent rule:
+//
+//	λ = (3x² + a) / 2y
+//	x3 = λ² - 2x
+//	y3 = λ(x - x3) - y
+func (c *Curve) Double(pt Point) Point {
+	if pt.Infinity || pt.Y.Sign() == 0 {
+		return Infinity()
+	}
+
+	num := new(big.Int).Mul(pt.X, pt.X)
+	num.Mul(num, three)
+	num.Add(num, &c.A)
+	den := new(big.Int).Mul(pt.Y, two)
+	return c.finish(pt, pt.X, c.divide(num, den))
+}
+
+// divide returns num / den mod P. Callers never pass den = 0 mod P.
+func (c *Curve) divide(num, den *big.Int) *big.Int {
+	den = new(big.Int).Mod(den, &c.P)
+	inv := new(big.Int).ModInverse(den, &c.P)
+
+	r := new(big.Int).Mul(num, inv)
+	return r.Mod(r, &c.P)
+}
+
+// finish computes x3 = λ² - x1 - x2 and y3 = λ(x1 - x3) - y1.
+func (c *Curve) finish(p1 Point, x2, lambda *big.Int) Point {
+	x3 := new(big.Int).Mul(lambda, lambda)
+	x3.Sub(x3, p1.X)
+	x3.Sub(x3, x2)
+	x3.Mod(x3, &c.P)
+
+	y3 := new(big.Int).Sub(p1.X, x3)
+	y3.Mul(y3, lambda)
+	y3.Sub(y3, p1.Y)
+	y3.Mod(y3, &c.P)
+
+	return Point{X: x3, Y: y3}
+}
+
+// ScalarMult returns k·pt using affine double-and-add, scanning k from the
+// most significant bit. A negative k multiplies -pt.
+func (c *Curve) ScalarMult(pt Point, k *big.Int) Point {
+	if k.Sign() < 0 {
+		return c.ScalarMult(c.Neg(pt), new(big.Int).Neg(k))
+	}
+
+	result := Infinity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = c.Double(result)
+		if k.Bit(i) == 1 {
+			result = c.Add(result, pt)
+		}
+	}
+
+	return result
+}
+
+// Decompress returns the point with the given x and the y whose parity
+// matches odd, if x³ + ax + b is a square mod P.
+func (c *Curve) Decompress(x *big.Int, odd bool) (Point, error) {
+	if x.Sign() < 0 || x.Cmp(&c.P) >= 0 {
+		return Point{}, errors.New("x must be in [0, P)")
+	}
+
+	y := new(big.Int).ModSqrt(c.rhs(x), &c.P)
+	if y == nil {
+		return Point{}, fmt.Errorf("no point with x = %s", x)
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(&c.P, y).Mod(y, &c.P)
+	}
+
+	return Point{X: new(big.Int).Set(x), Y: y}, nil
+}
@@ -0,0 +1,148 @@
+package weierstrass
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// smallCurve is y² = x³ + 2x + 3 over F_97.
+func smallCurve(t *testing.T) *Curve {
+	t.Helper()
+
+	curve, err := NewCurve(big.NewInt(97), big.NewInt(2), big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return curve
+}
+
+// points lists every affine point of the curve by brute force.
+func points(curve *Curve) []Point {
+	var all []Point
+	p := curve.P.Int64()
+	for x := int64(0); x < p; x++ {
+		for y := int64(0); y < p; y++ {
+			pt := NewPoint(big.NewInt(x), big.NewInt(y))
+			if curve.IsOnCurve(pt) {
+				all = append(all, pt)
+			}
+		}
+	}
+	return all
+}
+
+func TestNewCurveRejects(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		p, a, b int64
+	}{
+		{"composite P", 91, 2, 3},
+		{"P = 3", 3, 1, 1},
+		{"singular", 97, 0, 0},
+		{"singular with a = -3", 97, -3, 2},
+	} {
+		if _, err := NewCurve(big.NewInt(tt.p), big.NewInt(tt.a), big.NewInt(tt.b)); err == nil {
+			t.Errorf("%s: NewCurve succeeded", tt.name)
+		}
+	}
+}
+
+func TestCountPoints(t *testing.T) {
+	curve := smallCurve(t)
+
+	count, err := curve.CountPoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len(points(curve)) + 1); count.Int64() != want {
+		t.Fatalf("CountPoints = %s, want %d", count, want)
+	}
+}
+
+func TestGroupLaw(t *testing.T) {
+	curve := smallCurve(t)
+	all := points(curve)
+
+	for _, p1 := range all {
+		if !curve.Add(p1, curve.Neg(p1)).Infinity {
+			t.Fatalf("%s + -%s is not O", p1, p1)
+		}
+		if !curve.Add(p1, Infinity()).Equal(p1) {
+			t.Fatalf("%s + O != %s", p1, p1)
+		}
+		if !curve.Double(p1).Equal(curve.Add(p1, p1)) {
+			t.Fatalf("Double(%s) != %s + %s", p1, p1, p1)
+		}
+
+		for _, p2 := range all[:10] {
+			sum := curve.Add(p1, p2)
+			if !curve.IsOnCurve(sum) {
+				t.Fatalf("%s + %s = %s is not on the curve", p1, p2, sum)
+			}
+			if !sum.Equal(curve.Add(p2, p1)) {
+				t.Fatalf("addition of %s and %s is not commutative", p1, p2)
+			}
+
+			p3 := all[len(all)-1]
+			if !curve.Add(sum, p3).Equal(curve.Add(p1, curve.Add(p2, p3))) {
+				t.Fatalf("addition of %s, %s and %s is not associative", p1, p2, p3)
+			}
+		}
+	}
+}
+
+func TestScalarMult(t *testing.T) {
+	curve := smallCurve(t)
+
+	for _, pt := range points(curve) {
+		order, err := curve.PointOrder(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !curve.ScalarMult(pt, order).Infinity {
+			t.Fatalf("order(%s)·%s is not O", pt, pt)
+		}
+
+		sum := Infinity()
+		for k := int64(0); k <= order.Int64()+1; k++ {
+			if k > 0 && k < order.Int64() && sum.Infinity {
+				t.Fatalf("%d·%s is O before its order %s", k, pt, order)
+			}
+
+			affine := curve.ScalarMult(pt, big.NewInt(k))
+			jacobian := curve.ScalarMultJacobian(pt, big.NewInt(k))
+			if !affine.Equal(sum) || !jacobian.Equal(sum) {
+				t.Fatalf("%d·%s: affine %s, Jacobian %s, want %s", k, pt, affine, jacobian, sum)
+			}
+			sum = curve.Add(sum, pt)
+		}
+	}
+}
+
+func TestScalarMultMatchesP256(t *testing.T) {
+	curve, g, n := P256()
+	params := elliptic.P256().Params()
+
+	for i := 0; i < 20; i++ {
+		k, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		x, y := params.ScalarBaseMult(k.Bytes())
+		want := NewPoint(x, y)
+
+		if got := curve.ScalarMultJacobian(g, k); !got.Equal(want) {
+			t.Fatalf("ScalarMultJacobian(G, %s) = %s, want %s", k, got, want)
+		}
+		if got := curve.ScalarMult(g, k); !got.Equal(want) {
+			t.Fatalf("ScalarMult(G, %s) = %s, want %s", k, got, want)
+		}
+	}
+
+	if !curve.ScalarMultJacobian(g, n).Infinity {
+		t.Fatal("N·G is not O on P-256")
+	}
+}
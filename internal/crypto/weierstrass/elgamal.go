@@ -0,0 +1,346 @@
+package weierstrass
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// EmbeddingFactor is the Koblitz parameter K: a chunk m is embedded as the
+// first x = mK + j, 0 <= j < K, that lies on the curve. About half of all x
+// do, so each attempt fails with probability about 2^-K.
+const EmbeddingFactor = 64
+
+const embeddingBits = 6 // bits of EmbeddingFactor
+
+// KeyPair is an EC-ElGamal key over Curve with base point G of order N.
+// D is zero in public keys.
+type KeyPair struct {
+	Curve Curve
+	G     Point
+	N     big.Int
+	Q     Point
+	D     big.Int
+}
+
+// P256 returns the NIST P-256 parameters, used by GenerateKeyPair. P-256 is
+// a short Weierstrass curve with a = -3.
+func P256() (*Curve, Point, *big.Int) {
+	params := elliptic.P256().Params()
+
+	curve, err := NewCurve(params.P, big.NewInt(-3), params.B)
+	if err != nil {
+		panic(err)
+	}
+
+	return curve, NewPoint(params.Gx, params.Gy), new(big.Int).Set(params.N)
+}
+
+// GenerateKeyPair creates a key pair with a random secret over P-256.
+func GenerateKeyPair() (*KeyPair, error) {
+	curve, g, n := P256()
+	return CreateKeyPair(curve, g, n, nil)
+}
+
+// CreateKeyPair builds a key pair from user-supplied parameters. A nil n is
+// computed with PointOrder, which only works on small curves; a nil d draws
+// a random secret in [1, N-1].
+func CreateKeyPair(curve *Curve, g Point, n, d *big.Int) (*KeyPair, error) {
+	if g.Infinity || !curve.IsOnCurve(g) {
+		return nil, fmt.Errorf("G = %s is not a point on the curve", g)
+	}
+
+	if n == nil {
+		order, err := curve.PointOrder(g)
+		if err != nil {
+			return nil, fmt.Errorf("computing the order of G: %v", err)
+		}
+		n = order
+	}
+	if err := checkOrder(curve, g, n); err != nil {
+		return nil, err
+	}
+
+	if d == nil {
+		secret, err := randomScalar(n)
+		if err != nil {
+			return nil, err
+		}
+		d = secret
+	}
+	if d.Sign() <= 0 || d.Cmp(n) >= 0 {
+		return nil, errors.New("D must be between 1 and N-1")
+	}
+
+	kp := &KeyPair{Curve: *curve, G: g}
+	kp.N.Set(n)
+	kp.D.Set(d)
+	kp.Q = curve.ScalarMultJacobian(g, d)
+
+	return kp, nil
+}
+
+// checkOrder requires N > 2 and N·G = O.
+func checkOrder(curve *Curve, g Point, n *big.Int) error {
+	if n.Cmp(two) <= 0 {
+		return errors.New("N must be greater than 2")
+	}
+	if !curve.ScalarMultJacobian(g, n).Infinity {
+		return fmt.Errorf("N·G is not the point at infinity, so N is not the order of G")
+	}
+	return nil
+}
+
+// randomScalar returns a uniform value in [1, N-1].
+func randomScalar(n *big.Int) (*big.Int, error) {
+	k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, one))
+	if err != nil {
+		return nil, err
+	}
+	return k.Add(k, one), nil
+}
+
+// EncryptPoint encrypts the point M with ephemeral k as
+// (C1, C2) = (k·G, M + k·Q).
+func EncryptPoint(kp *KeyPair, m Point, k *big.Int) (c1, c2 Point) {
+	c1 = kp.Curve.ScalarMultJacobian(kp.G, k)
+	c2 = kp.Curve.Add(m, kp.Curve.ScalarMultJacobian(kp.Q, k))
+	return c1, c2
+}
+
+// DecryptPoint recovers M = C2 - D·C1.
+func DecryptPoint(kp *KeyPair, c1, c2 Point) Point {
+	shared := kp.Curve.ScalarMultJacobian(c1, &kp.D)
+	return kp.Curve.Add(c2, kp.Curve.Neg(shared))
+}
+
+// Embed maps m to a curve point with Koblitz's method. m·K + K must be below
+// P.
+func (c *Curve) Embed(m *big.Int) (Point, error) {
+	x := new(big.Int).Mul(m, big.NewInt(EmbeddingFactor))
+	limit := new(big.Int).Add(x, big.NewInt(EmbeddingFactor))
+	if m.Sign() < 0 || limit.Cmp(&c.P) > 0 {
+		return Point{}, errors.New("value too large to embed on this curve")
+	}
+
+	for ; x.Cmp(limit) < 0; x.Add(x, one) {
+		if pt, err := c.Decompress(x, false); err == nil {
+			return pt, nil
+		}
+	}
+
+	return Point{}, fmt.Errorf("no point found for %s after %d attempts", m, EmbeddingFactor)
+}
+
+// Extract reverses Embed: m = floor(x / K).
+func (c *Curve) Extract(pt Point) (*big.Int, error) {
+	if pt.Infinity {
+		return nil, errors.New("the point at infinity does not encode a value")
+	}
+	return new(big.Int).Div(pt.X, big.NewInt(EmbeddingFactor)), nil
+}
+
+// ChunkSize returns how many message bytes fit in one embedded point.
+func (c *Curve) ChunkSize() int {
+	return (c.P.BitLen() - 1 - embeddingBits) / 8
+}
+
+// CiphertextVersion is the current ciphertext format:
+//
+//	version (1 byte) || width (2 bytes) || length (4 bytes) || chunks
+//
+// Each chunk is C1.x || C1.y || C2.x || C2.y, each left-padded to width, the
+// byte length of P. length is the plaintext length; the last chunk is
+// zero-padded.
+const CiphertextVersion = 0x01
+
+const ciphertextHeaderSize = 7
+
+// Encrypt splits the message into ChunkSize-byte chunks, embeds each one and
+// encrypts it with a fresh random k.
+func Encrypt(kp *KeyPair, message []byte) ([]byte, error) {
+	chunkSize := kp.Curve.ChunkSize()
+	if chunkSize < 1 {
+		return nil, fmt.Errorf("P must have at least %d bits to carry a message", 8+embeddingBits+1)
+	}
+
+	width := (kp.Curve.P.BitLen() + 7) / 8
+	if width > 0xffff || uint64(len(message)) > 0xffffffff {
+		return nil, errors.New("message or curve too large to encode")
+	}
+
+	chunks := (len(message) + chunkSize - 1) / chunkSize
+	out := make([]byte, ciphertextHeaderSize, ciphertextHeaderSize+chunks*4*width)
+	out[0] = CiphertextVersion
+	binary.BigEndian.PutUint16(out[1:3], uint16(width))
+	binary.BigEndian.PutUint32(out[3:7], uint32(len(message)))
+
+	for i := 0; i < len(message); i += chunkSize {
+		chunk := make([]byte, chunkSize)
+		copy(chunk, message[i:])
+
+		m, err := kp.Curve.Embed(new(big.Int).SetBytes(chunk))
+		if err != nil {
+			return nil, err
+		}
+
+		c1, c2, err := encryptChunk(kp, m)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range []*big.Int{c1.X, c1.Y, c2.X, c2.Y} {
+			out = append(out, v.FillBytes(make([]byte, width))...)
+		}
+	}
+
+	return out, nil
+}
+
+// encryptChunk draws k until neither C1 nor C2 is the point at infinity,
+// which the fixed-width format cannot represent.
+func encryptChunk(kp *KeyPair, m Point) (c1, c2 Point, err error) {
+	for {
+		k, err := randomScalar(&kp.N)
+		if err != nil {
+			return Point{}, Point{}, err
+		}
+
+		c1, c2 = EncryptPoint(kp, m, k)
+		if !c1.Infinity && !c2.Infinity {
+			return c1, c2, nil
+		}
+	}
+}
+
+// Decrypt reverses Encrypt.
+func Decrypt(kp *KeyPair, data []byte) ([]byte, error) {
+	if len(data) < ciphertextHeaderSize {
+		return nil, errors.New("ciphertext too short")
+	}
+	if data[0] != CiphertextVersion {
+		return nil, fmt.Errorf("unsupported ciphertext version %d", data[0])
+	}
+
+	width := int(binary.BigEndian.Uint16(data[1:3]))
+	length := int(binary.BigEndian.Uint32(data[3:7]))
+	chunkSize := kp.Curve.ChunkSize()
+	if width != (kp.Curve.P.BitLen()+7)/8 || chunkSize < 1 {
+		return nil, errors.New("ciphertext does not match the key's curve")
+	}
+
+	body := data[ciphertextHeaderSize:]
+	chunks := (length + chunkSize - 1) / chunkSize
+	if len(body) != chunks*4*width {
+		return nil, errors.New("ciphertext length does not match its header")
+	}
+
+	message := make([]byte, 0, chunks*chunkSize)
+	for i := 0; i < chunks; i++ {
+		values := make([]*big.Int, 4)
+		for j := range values {
+			offset := (4*i + j) * width
+			values[j] = new(big.Int).SetBytes(body[offset : offset+width])
+		}
+
+		c1, c2 := Point{X: values[0], Y: values[1]}, Point{X: values[2], Y: values[3]}
+		if !kp.Curve.IsOnCurve(c1) || !kp.Curve.IsOnCurve(c2) {
+			return nil, errors.New("ciphertext point is not on the curve")
+		}
+
+		m, err := kp.Curve.Extract(DecryptPoint(kp, c1, c2))
+		if err != nil {
+			return nil, err
+		}
+		if m.BitLen() > 8*chunkSize {
+			return nil, errors.New("decrypted chunk out of range")
+		}
+
+		message = append(message, m.FillBytes(make([]byte, chunkSize))...)
+	}
+
+	return message[:length], nil
+}
+
+// EncodeToString returns the text encodings of the key pair:
+//
+//	private: P,A,B,Gx,Gy,N,D
+//	public:  P,A,B,Gx,Gy,N,Qx,Qy
+func (kp *KeyPair) EncodeToString() (privateKey, publicKey string, err error) {
+	if kp.Q.Infinity {
+		return "", "", errors.New("public point is the point at infinity")
+	}
+
+	params := fmt.Sprintf("%s,%s,%s,%s,%s,%s",
+		&kp.Curve.P, &kp.Curve.A, &kp.Curve.B, kp.G.X, kp.G.Y, &kp.N)
+
+	privateKey = fmt.Sprintf("%s,%s", params, &kp.D)
+	publicKey = fmt.Sprintf("%s,%s,%s", params, kp.Q.X, kp.Q.Y)
+
+	return privateKey, publicKey, nil
+}
+
+func DecodePrivateKey(data string) (*KeyPair, error) {
+	values, err := decodeValues(strings.Split(data, ","), "P", "A", "B", "Gx", "Gy", "N", "D")
+	if err != nil {
+		return nil, errors.New("invalid private key format: " + err.Error())
+	}
+
+	curve, err := NewCurve(values[0], values[1], values[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateKeyPair(curve, NewPoint(values[3], values[4]), values[5], values[6])
+}
+
+// DecodePublicKey parses and checks a public key: the curve must be
+// non-singular, G and Q must be on it, and N·G must be O.
+func DecodePublicKey(data string) (*KeyPair, error) {
+	values, err := decodeValues(strings.Split(data, ","), "P", "A", "B", "Gx", "Gy", "N", "Qx", "Qy")
+	if err != nil {
+		return nil, errors.New("invalid public key format: " + err.Error())
+	}
+
+	curve, err := NewCurve(values[0], values[1], values[2])
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewPoint(values[3], values[4])
+	if !curve.IsOnCurve(g) {
+		return nil, errors.New("G is not on the curve")
+	}
+	if err := checkOrder(curve, g, values[5]); err != nil {
+		return nil, err
+	}
+
+	q := NewPoint(values[6], values[7])
+	if !curve.IsOnCurve(q) {
+		return nil, errors.New("Q is not on the curve")
+	}
+
+	kp := &KeyPair{Curve: *curve, G: g, Q: q}
+	kp.N.Set(values[5])
+	return kp, nil
+}
+
+func decodeValues(fields []string, names ...string) ([]*big.Int, error) {
+	if len(fields) != len(names) {
+		return nil, fmt.Errorf("expected %d fields", len(names))
+	}
+
+	values := make([]*big.Int, len(names))
+	for i, name := range names {
+		values[i] = new(big.Int)
+		if _, ok := values[i].SetString(fields[i], 10); !ok {
+			return nil, fmt.Errorf("invalid %s value", name)
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,156 @@
+package weierstrass
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+)
+
+func TestEncryptDecryptP256(t *testing.T) {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunkSize := keyPair.Curve.ChunkSize()
+	for _, length := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 1000} {
+		message := make([]byte, length)
+		if _, err := rand.Read(message); err != nil {
+			t.Fatal(err)
+		}
+
+		ciphertext, err := Encrypt(keyPair, message)
+		if err != nil {
+			t.Fatalf("Encrypt of %d bytes: %v", length, err)
+		}
+
+		decrypted, err := Decrypt(keyPair, ciphertext)
+		if err != nil {
+			t.Fatalf("Decrypt of %d bytes: %v", length, err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("Decrypt(Encrypt(m)) differs for %d bytes", length)
+		}
+	}
+}
+
+func TestEncryptDecryptSmallCurve(t *testing.T) {
+	// 65521 is prime and leaves room for one byte per point.
+	curve, err := NewCurve(big.NewInt(65521), big.NewInt(5), big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keyPair *KeyPair
+	for x := int64(1); keyPair == nil; x++ {
+		g, err := curve.Decompress(big.NewInt(x), false)
+		if err != nil {
+			continue
+		}
+		order, err := curve.PointOrder(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.Int64() < 1000 {
+			continue
+		}
+		if keyPair, err = CreateKeyPair(curve, g, order, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	message := []byte("a small curve")
+	ciphertext, err := Encrypt(keyPair, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := Decrypt(keyPair, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, message) {
+		t.Fatalf("Decrypt = %q, want %q", decrypted, message)
+	}
+}
+
+func TestDecryptRejectsTampering(t *testing.T) {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ciphertext, err := Encrypt(keyPair, []byte("attack at dawn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modified := func(change func([]byte) []byte) []byte {
+		return change(bytes.Clone(ciphertext))
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"version", modified(func(d []byte) []byte { d[0]++; return d })},
+		{"width", modified(func(d []byte) []byte { d[2]++; return d })},
+		{"length", modified(func(d []byte) []byte { d[6] += 32; return d })},
+		{"truncated", ciphertext[:len(ciphertext)-1]},
+		{"C1 off the curve", modified(func(d []byte) []byte { d[ciphertextHeaderSize+40] ^= 1; return d })},
+		{"C2 off the curve", modified(func(d []byte) []byte { d[len(d)-1] ^= 1; return d })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decrypt(keyPair, tt.data); err == nil {
+				t.Fatal("Decrypt succeeded")
+			}
+		})
+	}
+}
+
+func TestCreateKeyPairRejects(t *testing.T) {
+	curve, g, n := P256()
+
+	if _, err := CreateKeyPair(curve, NewPoint(g.X, new(big.Int).Add(g.Y, one)), n, nil); err == nil {
+		t.Error("CreateKeyPair accepted G off the curve")
+	}
+	if _, err := CreateKeyPair(curve, g, new(big.Int).Sub(n, one), nil); err == nil {
+		t.Error("CreateKeyPair accepted a wrong order")
+	}
+	if _, err := CreateKeyPair(curve, g, n, n); err == nil {
+		t.Error("CreateKeyPair accepted D = N")
+	}
+}
+
+func TestProviderRoundTrip(t *testing.T) {
+	alice := NewECElGamalProvider(keystore.NewClientKeyStore("alice"), "alice")
+	bob := NewECElGamalProvider(keystore.NewClientKeyStore("bob"), "bob")
+	if err := bob.GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := bob.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := alice.ParsePublicKey("bob", publicKey); err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+
+	ciphertext, err := alice.Encrypt([]byte("hi bob"), "bob")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	decrypted, err := bob.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if string(decrypted) != "hi bob" {
+		t.Fatalf("Decrypt = %q", decrypted)
+	}
+}
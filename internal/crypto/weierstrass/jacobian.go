@@ -0,0 +1,150 @@
+package weierstrass
+
+import "math/big"
+
+// JacobianPoint represents the affine point (X/Z², Y/Z³). Working in these
+// coordinates replaces the modular inversion in every affine addition with a
+// handful of multiplications; a single inversion converts back at the end.
+// Z = 0 is the point at infinity.
+type JacobianPoint struct {
+	X, Y, Z *big.Int
+}
+
+// ToJacobian lifts an affine point with Z = 1.
+func (c *Curve) ToJacobian(pt Point) JacobianPoint {
+	if pt.Infinity {
+		return JacobianPoint{X: big.NewInt(1), Y: big.NewInt(1), Z: big.NewInt(0)}
+	}
+	return JacobianPoint{X: new(big.Int).Set(pt.X), Y: new(big.Int).Set(pt.Y), Z: big.NewInt(1)}
+}
+
+// ToAffine returns (X/Z², Y/Z³).
+func (c *Curve) ToAffine(jp JacobianPoint) Point {
+	if jp.Z.Sign() == 0 {
+		return Infinity()
+	}
+
+	zInv := new(big.Int).ModInverse(jp.Z, &c.P)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+	zInv2.Mod(zInv2, &c.P)
+	zInv3 := new(big.Int).Mul(zInv2, zInv)
+
+	x := new(big.Int).Mul(jp.X, zInv2)
+	y := new(big.Int).Mul(jp.Y, zInv3)
+	return Point{X: x.Mod(x, &c.P), Y: y.Mod(y, &c.P)}
+}
+
+// DoubleJacobian returns 2·jp:
+//
+//	S = 4XY², M = 3X² + aZ⁴
+//	X' = M² - 2S, Y' = M(S - X') - 8Y⁴, Z' = 2YZ
+func (c *Curve) DoubleJacobian(jp JacobianPoint) JacobianPoint {
+	if jp.Z.Sign() == 0 || jp.Y.Sign() == 0 {
+		return c.ToJacobian(Infinity())
+	}
+
+	p := &c.P
+	y2 := c.mul(jp.Y, jp.Y)
+
+	s := c.mul(jp.X, y2)
+	s.Lsh(s, 2).Mod(s, p)
+
+	z2 := c.mul(jp.Z, jp.Z)
+	m := c.mul(jp.X, jp.X)
+	m.Mul(m, three)
+	m.Add(m, c.mul(&c.A, c.mul(z2, z2)))
+	m.Mod(m, p)
+
+	x := c.mul(m, m)
+	x.Sub(x, new(big.Int).Lsh(s, 1))
+	x.Mod(x, p)
+
+	y4 := c.mul(y2, y2)
+	y := new(big.Int).Sub(s, x)
+	y.Mul(y, m)
+	y.Sub(y, y4.Lsh(y4, 3))
+	y.Mod(y, p)
+
+	z := c.mul(jp.Y, jp.Z)
+	z.Lsh(z, 1).Mod(z, p)
+
+	return JacobianPoint{X: x, Y: y, Z: z}
+}
+
+// AddJacobian returns j1 + j2:
+//
+//	U1 = X1Z2², U2 = X2Z1², S1 = Y1Z2³, S2 = Y2Z1³
+//	H = U2 - U1, R = S2 - S1
+//	X3 = R² - H³ - 2U1H², Y3 = R(U1H² - X3) - S1H³, Z3 = HZ1Z2
+func (c *Curve) AddJacobian(j1, j2 JacobianPoint) JacobianPoint {
+	if j1.Z.Sign() == 0 {
+		return j2
+	}
+	if j2.Z.Sign() == 0 {
+		return j1
+	}
+
+	p := &c.P
+	z1z1 := c.mul(j1.Z, j1.Z)
+	z2z2 := c.mul(j2.Z, j2.Z)
+
+	u1 := c.mul(j1.X, z2z2)
+	u2 := c.mul(j2.X, z1z1)
+	s1 := c.mul(j1.Y, c.mul(j2.Z, z2z2))
+	s2 := c.mul(j2.Y, c.mul(j1.Z, z1z1))
+
+	if u1.Cmp(u2) == 0 {
+		if s1.Cmp(s2) != 0 {
+			return c.ToJacobian(Infinity())
+		}
+		return c.DoubleJacobian(j1)
+	}
+
+	h := new(big.Int).Sub(u2, u1)
+	h.Mod(h, p)
+	r := new(big.Int).Sub(s2, s1)
+	r.Mod(r, p)
+
+	h2 := c.mul(h, h)
+	h3 := c.mul(h2, h)
+	u1h2 := c.mul(u1, h2)
+
+	x := c.mul(r, r)
+	x.Sub(x, h3)
+	x.Sub(x, new(big.Int).Lsh(u1h2, 1))
+	x.Mod(x, p)
+
+	y := new(big.Int).Sub(u1h2, x)
+	y.Mul(y, r)
+	y.Sub(y, c.mul(s1, h3))
+	y.Mod(y, p)
+
+	z := c.mul(h, c.mul(j1.Z, j2.Z))
+
+	return JacobianPoint{X: x, Y: y, Z: z}
+}
+
+// ScalarMultJacobian returns k·pt like ScalarMult, but does the
+// double-and-add in Jacobian coordinates.
+func (c *Curve) ScalarMultJacobian(pt Point, k *big.Int) Point {
+	if k.Sign() < 0 {
+		return c.ScalarMultJacobian(c.Neg(pt), new(big.Int).Neg(k))
+	}
+
+	base := c.ToJacobian(pt)
+	result := c.ToJacobian(Infinity())
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = c.DoubleJacobian(result)
+		if k.Bit(i) == 1 {
+			result = c.AddJacobian(result, base)
+		}
+	}
+
+	return c.ToAffine(result)
+}
+
+// mul returns a·b mod P.
+func (c *Curve) mul(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+	return r.Mod(r, &c.P)
+}
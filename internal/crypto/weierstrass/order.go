@@ -0,0 +1,84 @@
+package weierstrass
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// MaxCountBits bounds the prime size for which CountPoints walks every x
+// coordinate.
+const MaxCountBits = 20
+
+// CountPoints returns the number of points on the curve, including the point
+// at infinity. Each x with x³ + ax + b a non-zero square gives two points
+// and each root gives one, so
+//
+//	#E = P + 1 + Σ (x³ + ax + b | P)
+//
+// summed over every x, where ( | ) is the Legendre symbol. It only works for
+// curves with P below 2^MaxCountBits.
+func (c *Curve) CountPoints() (*big.Int, error) {
+	if c.P.BitLen() > MaxCountBits {
+		return nil, fmt.Errorf("counting points needs P below 2^%d", MaxCountBits)
+	}
+
+	count := new(big.Int).Add(&c.P, one)
+	for x := big.NewInt(0); x.Cmp(&c.P) < 0; x.Add(x, one) {
+		count.Add(count, big.NewInt(int64(big.Jacobi(c.rhs(x), &c.P))))
+	}
+
+	return count, nil
+}
+
+// PointOrder returns the smallest n > 0 with n·pt = O, for curves small
+// enough for CountPoints. The order divides #E, so it starts from #E and
+// strips prime factors q while (n/q)·pt is still O.
+func (c *Curve) PointOrder(pt Point) (*big.Int, error) {
+	if !c.IsOnCurve(pt) {
+		return nil, fmt.Errorf("point %s is not on the curve", pt)
+	}
+
+	order, err := c.CountPoints()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, q := range primeFactors(order) {
+		for {
+			quotient, modulus := new(big.Int).QuoRem(order, q, new(big.Int))
+			if modulus.Sign() != 0 || !c.ScalarMult(pt, quotient).Infinity {
+				break
+			}
+			order = quotient
+		}
+	}
+
+	return order, nil
+}
+
+// primeFactors returns the distinct prime factors of n by trial division.
+func primeFactors(n *big.Int) []*big.Int {
+	var factors []*big.Int
+
+	remainder := new(big.Int).Set(n)
+	quotient, modulus := new(big.Int), new(big.Int)
+	for d := big.NewInt(2); new(big.Int).Mul(d, d).Cmp(remainder) <= 0; d.Add(d, one) {
+		found := false
+		for {
+			quotient.QuoRem(remainder, d, modulus)
+			if modulus.Sign() != 0 {
+				break
+			}
+			remainder.Set(quotient)
+			found = true
+		}
+		if found {
+			factors = append(factors, new(big.Int).Set(d))
+		}
+	}
+	if remainder.Cmp(one) > 0 {
+		factors = append(factors, remainder)
+	}
+
+	return factors
+}
@@ -0,0 +1,121 @@
+package weierstrass
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+)
+
+// ECElGamalProvider encrypts with EC-ElGamal over a user-supplied curve.
+type ECElGamalProvider struct {
+	keyStore crypto.KeyStore
+	userID   string
+	keyPair  *KeyPair
+}
+
+func NewECElGamalProvider(keyStore crypto.KeyStore, userID string) *ECElGamalProvider {
+	return &ECElGamalProvider{
+		keyStore: keyStore,
+		userID:   userID,
+		keyPair:  nil,
+	}
+}
+
+var _ crypto.Provider = (*ECElGamalProvider)(nil)
+
+// GenerateKey creates a key pair over P-256.
+func (p *ECElGamalProvider) GenerateKey() error {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+// StoreKeyPair creates a key pair from manual parameters; see CreateKeyPair
+// for the meaning of a nil n or d.
+func (p *ECElGamalProvider) StoreKeyPair(curve *Curve, g Point, n, d *big.Int) error {
+	keyPair, err := CreateKeyPair(curve, g, n, d)
+	if err != nil {
+		return err
+	}
+
+	return p.storeKeyPair(keyPair)
+}
+
+func (p *ECElGamalProvider) storeKeyPair(keyPair *KeyPair) error {
+	privateKeyStr, publicKeyStr, err := keyPair.EncodeToString()
+	if err != nil {
+		return err
+	}
+
+	p.keyPair = keyPair
+
+	err = p.keyStore.StorePrivateKey(crypto.ECElGamal, []byte(privateKeyStr))
+	if err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(p.userID, crypto.ECElGamal, []byte(publicKeyStr))
+}
+
+func (p *ECElGamalProvider) ParsePublicKey(userID string, publicKeyData []byte) error {
+	if _, err := DecodePublicKey(string(publicKeyData)); err != nil {
+		return err
+	}
+
+	return p.keyStore.StorePublicKey(userID, crypto.ECElGamal, publicKeyData)
+}
+
+func (p *ECElGamalProvider) Encrypt(message []byte, recipientID string) ([]byte, error) {
+	recipientKeyBytes, err := p.keyStore.GetPublicKey(recipientID, crypto.ECElGamal)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientKey, err := DecodePublicKey(string(recipientKeyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return Encrypt(recipientKey, message)
+}
+
+func (p *ECElGamalProvider) Decrypt(ciphertext []byte) ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	return Decrypt(p.keyPair, ciphertext)
+}
+
+func (p *ECElGamalProvider) PublicKey() ([]byte, error) {
+	if err := p.loadKeyPair(); err != nil {
+		return nil, err
+	}
+
+	_, publicKeyStr, err := p.keyPair.EncodeToString()
+	return []byte(publicKeyStr), err
+}
+
+// loadKeyPair decodes the user's private key from the key store on first use.
+func (p *ECElGamalProvider) loadKeyPair() error {
+	if p.keyPair != nil {
+		return nil
+	}
+
+	privateKeyBytes, err := p.keyStore.GetPrivateKey(crypto.ECElGamal)
+	if err != nil {
+		return fmt.Errorf("private key not available: %v", err)
+	}
+
+	keyPair, err := DecodePrivateKey(string(privateKeyBytes))
+	if err != nil {
+		return err
+	}
+
+	p.keyPair = keyPair
+	return nil
+}
@@ -13,10 +13,16 @@ import (
 
 type CryptoServiceServer struct {
 	pb.UnimplementedCryptoServiceServer
-	users    map[string]*User
-	keyStore *keystore.ServerKeyStore
-	messages map[string][]*Message
-	mutex    sync.Mutex
+	users      map[string]*User
+	keyStore   *keystore.ServerKeyStore
+	messages   map[string][]*Message
+	polls      map[string]*Poll
+	mailboxes  map[string]*Mailbox
+	challenges map[string]*KeyChallenge
+	mutex      sync.Mutex
+
+	nextPollID    int
+	nextMailboxID int
 }
 
 type User struct {
@@ -27,18 +33,23 @@ type User struct {
 }
 
 type Message struct {
-	SenderID         string
-	RecipientID      string
-	EncryptedMessage []byte
-	Algorithm        string
-	Timestamp        time.Time
+	SenderID           string
+	RecipientID        string
+	EncryptedMessage   []byte
+	Algorithm          string
+	Signature          []byte
+	SignatureAlgorithm string
+	Timestamp          time.Time
 }
 
 func NewCryptoServerServer() *CryptoServiceServer {
 	return &CryptoServiceServer{
-		users:    make(map[string]*User),
-		keyStore: keystore.NewServerKeyStore(),
-		messages: make(map[string][]*Message),
+		users:      make(map[string]*User),
+		keyStore:   keystore.NewServerKeyStore(),
+		messages:   make(map[string][]*Message),
+		polls:      make(map[string]*Poll),
+		mailboxes:  make(map[string]*Mailbox),
+		challenges: make(map[string]*KeyChallenge),
 	}
 }
 
@@ -95,6 +106,14 @@ func (s *CryptoServiceServer) RegisterPublicKey(ctx context.Context, req *pb.Reg
 	}
 
 	algorithm := crypto.Algorithm(req.Algorithm)
+	if err := s.verifyPossession(req.UserId, algorithm, req.KeyData, req.Proof); err != nil {
+		log.Printf("Rejected %s public key for user %s: %v", req.Algorithm, req.UserId, err)
+		return &pb.RegisterPublicKeyResponse{
+			Success: false,
+			Message: "Proof of possession failed: " + err.Error(),
+		}, nil
+	}
+
 	err := s.keyStore.StorePublicKey(req.UserId, algorithm, req.KeyData)
 	if err != nil {
 		return &pb.RegisterPublicKeyResponse{
@@ -156,11 +175,13 @@ func (s *CryptoServiceServer) SendMessage(ctx context.Context, req *pb.SendMessa
 	}
 
 	message := &Message{
-		SenderID:         req.SenderId,
-		RecipientID:      req.RecipientId,
-		EncryptedMessage: req.EncryptedMessage,
-		Algorithm:        req.Algorithm,
-		Timestamp:        time.Now(),
+		SenderID:           req.SenderId,
+		RecipientID:        req.RecipientId,
+		EncryptedMessage:   req.EncryptedMessage,
+		Algorithm:          req.Algorithm,
+		Signature:          req.Signature,
+		SignatureAlgorithm: req.SignatureAlgorithm,
+		Timestamp:          time.Now(),
 	}
 
 	if _, exists := s.messages[req.RecipientId]; !exists {
@@ -194,10 +215,12 @@ func (s *CryptoServiceServer) GetMessages(ctx context.Context, req *pb.GetMessag
 	protoMessages := make([]*pb.Message, 0, len(userMessages))
 	for _, msg := range userMessages {
 		protoMessages = append(protoMessages, &pb.Message{
-			SenderId:         msg.SenderID,
-			EncryptedMessage: msg.EncryptedMessage,
-			Algorithm:        msg.Algorithm,
-			Timestamp:        msg.Timestamp.Unix(),
+			SenderId:           msg.SenderID,
+			EncryptedMessage:   msg.EncryptedMessage,
+			Algorithm:          msg.Algorithm,
+			Timestamp:          msg.Timestamp.Unix(),
+			Signature:          msg.Signature,
+			SignatureAlgorithm: msg.SignatureAlgorithm,
 		})
 	}
 
@@ -0,0 +1,702 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto/elgamal"
+	"github.com/luizgbraga/crypto-go/internal/crypto/threshold"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+)
+
+// Mailbox is a shared inbox whose ElGamal key is split among its members.
+// Every member deals a share of the key to the others, then checks the
+// shares dealt to it. A member that finds a bad share complains, and the
+// dealing is dropped from Dealers until the dealer answers by revealing the
+// share, which the server checks against the dealing's commitments. Once
+// every member has acknowledged its shares, the key is the product of the
+// parts of the Dealers and any Threshold members can decrypt a message
+// together. The server only relays dealings and partial decryptions,
+// checking the proofs that come with them.
+type Mailbox struct {
+	ID           string
+	CreatorID    string
+	Members      []string
+	Threshold    int
+	Group        *threshold.Group
+	Dealers      []string
+	Dealings     map[string]*MailboxDealing
+	Disputed     map[string]*MailboxDealing
+	Complaints   []*MailboxComplaint
+	Acknowledged map[string]bool
+	PublicKey    string
+	Messages     []*MailboxMessage
+	CreatedAt    time.Time
+
+	// verificationKeys[j] is G^(x_j) for member j+1, set with PublicKey.
+	verificationKeys []*big.Int
+}
+
+// MailboxDealing is one member's dealing, with each member's share
+// encrypted to that member's ECIES-X25519 key. DealerCopy holds all the
+// shares encrypted to the dealer, so it can answer complaints later.
+type MailboxDealing struct {
+	Commitments     []*big.Int
+	EncryptedShares map[string][]byte
+	DealerCopy      []byte
+
+	// RevealedShares are shares the dealer made public to answer a
+	// complaint, keyed by member.
+	RevealedShares map[string]*big.Int
+}
+
+// MailboxComplaint says MemberID found the share DealerID dealt to it
+// invalid. It stands until the dealer reveals a share that checks out.
+type MailboxComplaint struct {
+	DealerID string
+	MemberID string
+}
+
+type MailboxMessage struct {
+	ID               int
+	SenderID         string
+	EncryptedMessage []byte
+	A                *big.Int
+	Partials         []*PartialDecryption
+	Timestamp        time.Time
+}
+
+type PartialDecryption struct {
+	MemberID string
+	Partial  []byte
+	Proof    []byte
+}
+
+// memberIndex returns the member's number in the key sharing, counting from
+// 1, or 0 if the user is not a member.
+func (m *Mailbox) memberIndex(userID string) int {
+	for i, member := range m.Members {
+		if member == userID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// allDealt reports whether every member has dealt, whether or not its
+// dealing is disputed.
+func (m *Mailbox) allDealt() bool {
+	return len(m.Dealings)+len(m.Disputed) == len(m.Members)
+}
+
+func (m *Mailbox) hasComplaint(dealerID, memberID string) bool {
+	for _, complaint := range m.Complaints {
+		if complaint.DealerID == dealerID && complaint.MemberID == memberID {
+			return true
+		}
+	}
+	return false
+}
+
+// dispute records a complaint and drops the dealing from Dealers. The
+// dealer's acknowledgement no longer counts, so the key waits for it to
+// answer or to acknowledge without its dealing.
+func (m *Mailbox) dispute(dealerID, memberID string) {
+	if !m.hasComplaint(dealerID, memberID) {
+		m.Complaints = append(m.Complaints, &MailboxComplaint{DealerID: dealerID, MemberID: memberID})
+	}
+
+	if dealing, qualified := m.Dealings[dealerID]; qualified {
+		m.Disputed[dealerID] = dealing
+		delete(m.Dealings, dealerID)
+		m.Dealers = slices.DeleteFunc(m.Dealers, func(dealer string) bool { return dealer == dealerID })
+	}
+
+	delete(m.Acknowledged, dealerID)
+}
+
+// reinstate puts a disputed dealing with no complaints left back in
+// Dealers. Members checked their shares without it, so every member has to
+// acknowledge again.
+func (m *Mailbox) reinstate(dealerID string) {
+	m.Dealings[dealerID] = m.Disputed[dealerID]
+	delete(m.Disputed, dealerID)
+	m.Dealers = append(m.Dealers, dealerID)
+
+	clear(m.Acknowledged)
+}
+
+func (s *CryptoServiceServer) CreateMailbox(ctx context.Context, req *pb.CreateMailboxRequest) (*pb.CreateMailboxResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.users[req.CreatorId]; !exists {
+		return &pb.CreateMailboxResponse{
+			Success: false,
+			Message: "Creator not found",
+		}, nil
+	}
+
+	members := []string{req.CreatorId}
+	seen := map[string]bool{req.CreatorId: true}
+	for _, memberID := range req.MemberIds {
+		if seen[memberID] {
+			continue
+		}
+		if _, exists := s.users[memberID]; !exists {
+			return &pb.CreateMailboxResponse{
+				Success: false,
+				Message: fmt.Sprintf("Member %s not found", memberID),
+			}, nil
+		}
+		seen[memberID] = true
+		members = append(members, memberID)
+	}
+
+	if len(members) < 2 {
+		return &pb.CreateMailboxResponse{
+			Success: false,
+			Message: "A mailbox needs at least two members",
+		}, nil
+	}
+
+	// A threshold of 1 would give every member the whole key.
+	if req.Threshold < 2 || int(req.Threshold) > len(members) {
+		return &pb.CreateMailboxResponse{
+			Success: false,
+			Message: fmt.Sprintf("Threshold must be between 2 and %d", len(members)),
+		}, nil
+	}
+
+	group, err := threshold.LookupGroup(req.Group)
+	if err != nil {
+		return &pb.CreateMailboxResponse{
+			Success: false,
+			Message: "Invalid group: " + err.Error(),
+		}, nil
+	}
+
+	s.nextMailboxID++
+	mailbox := &Mailbox{
+		ID:           fmt.Sprintf("mailbox-%d", s.nextMailboxID),
+		CreatorID:    req.CreatorId,
+		Members:      members,
+		Threshold:    int(req.Threshold),
+		Group:        group,
+		Dealings:     make(map[string]*MailboxDealing),
+		Disputed:     make(map[string]*MailboxDealing),
+		Acknowledged: make(map[string]bool),
+		CreatedAt:    time.Now(),
+	}
+	s.mailboxes[mailbox.ID] = mailbox
+
+	log.Printf("Mailbox %s created by %s (%d of %d, group: %s)", mailbox.ID, req.CreatorId, mailbox.Threshold, len(members), group.Name)
+	return &pb.CreateMailboxResponse{
+		Success:   true,
+		Message:   "Mailbox created successfully",
+		MailboxId: mailbox.ID,
+	}, nil
+}
+
+func (s *CryptoServiceServer) GetMailboxes(ctx context.Context, req *pb.EmptyRequest) (*pb.MailboxList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mailboxList := &pb.MailboxList{}
+	for _, mailbox := range s.mailboxes {
+		var acknowledged []string
+		for _, member := range mailbox.Members {
+			if mailbox.Acknowledged[member] {
+				acknowledged = append(acknowledged, member)
+			}
+		}
+
+		complaints := make([]*pb.Complaint, len(mailbox.Complaints))
+		for i, complaint := range mailbox.Complaints {
+			complaints[i] = &pb.Complaint{DealerId: complaint.DealerID, MemberId: complaint.MemberID}
+		}
+
+		mailboxList.Mailboxes = append(mailboxList.Mailboxes, &pb.Mailbox{
+			MailboxId:       mailbox.ID,
+			MemberIds:       mailbox.Members,
+			Threshold:       int32(mailbox.Threshold),
+			Group:           mailbox.Group.Name,
+			DealerIds:       mailbox.Dealers,
+			PublicKey:       []byte(mailbox.PublicKey),
+			AcknowledgedIds: acknowledged,
+			Complaints:      complaints,
+		})
+	}
+
+	return mailboxList, nil
+}
+
+func (s *CryptoServiceServer) SubmitDealing(ctx context.Context, req *pb.SubmitDealingRequest) (*pb.SubmitDealingResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mailbox, exists := s.mailboxes[req.MailboxId]
+	if !exists {
+		return &pb.SubmitDealingResponse{
+			Success: false,
+			Message: "Mailbox not found",
+		}, nil
+	}
+
+	if mailbox.memberIndex(req.DealerId) == 0 {
+		return &pb.SubmitDealingResponse{
+			Success: false,
+			Message: "Only members can deal",
+		}, nil
+	}
+
+	_, dealt := mailbox.Dealings[req.DealerId]
+	_, disputed := mailbox.Disputed[req.DealerId]
+	if dealt || disputed {
+		return &pb.SubmitDealingResponse{
+			Success: false,
+			Message: "Already dealt in this mailbox",
+		}, nil
+	}
+
+	commitments := make([]*big.Int, len(req.Commitments))
+	for i, c := range req.Commitments {
+		commitments[i] = new(big.Int).SetBytes(c)
+	}
+
+	if err := threshold.CheckCommitments(mailbox.Group, commitments, mailbox.Threshold); err != nil {
+		return &pb.SubmitDealingResponse{
+			Success: false,
+			Message: "Invalid dealing: " + err.Error(),
+		}, nil
+	}
+
+	// The shares are encrypted, so the server can only check that every
+	// member gets one; members check their own against the commitments.
+	if len(req.EncryptedShares) != len(mailbox.Members) {
+		return &pb.SubmitDealingResponse{
+			Success: false,
+			Message: "Invalid dealing: expected one share per member",
+		}, nil
+	}
+	for _, member := range mailbox.Members {
+		if len(req.EncryptedShares[member]) == 0 {
+			return &pb.SubmitDealingResponse{
+				Success: false,
+				Message: fmt.Sprintf("Invalid dealing: no share for %s", member),
+			}, nil
+		}
+	}
+	if len(req.DealerCopy) == 0 {
+		return &pb.SubmitDealingResponse{
+			Success: false,
+			Message: "Invalid dealing: no copy of the shares for the dealer",
+		}, nil
+	}
+
+	mailbox.Dealings[req.DealerId] = &MailboxDealing{
+		Commitments:     commitments,
+		EncryptedShares: req.EncryptedShares,
+		DealerCopy:      req.DealerCopy,
+		RevealedShares:  make(map[string]*big.Int),
+	}
+	mailbox.Dealers = append(mailbox.Dealers, req.DealerId)
+	log.Printf("Dealing submitted to %s by %s", mailbox.ID, req.DealerId)
+
+	return &pb.SubmitDealingResponse{
+		Success: true,
+		Message: "Dealing submitted successfully",
+	}, nil
+}
+
+// CheckDealings records a member's verdict on the shares dealt to it:
+// complaints against the dealers whose shares did not match their
+// commitments, or an acknowledgement if there are none. The key is set
+// once every member has acknowledged.
+func (s *CryptoServiceServer) CheckDealings(ctx context.Context, req *pb.CheckDealingsRequest) (*pb.CheckDealingsResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mailbox, exists := s.mailboxes[req.MailboxId]
+	if !exists {
+		return &pb.CheckDealingsResponse{
+			Success: false,
+			Message: "Mailbox not found",
+		}, nil
+	}
+
+	if mailbox.memberIndex(req.MemberId) == 0 {
+		return &pb.CheckDealingsResponse{
+			Success: false,
+			Message: "Not a member of this mailbox",
+		}, nil
+	}
+
+	if mailbox.PublicKey != "" {
+		return &pb.CheckDealingsResponse{
+			Success: false,
+			Message: "Mailbox key is already set",
+		}, nil
+	}
+
+	if !mailbox.allDealt() {
+		return &pb.CheckDealingsResponse{
+			Success: false,
+			Message: "Waiting for every member to deal",
+		}, nil
+	}
+
+	// Check every complaint before recording any of them.
+	for _, dealerID := range req.Complaints {
+		var dealing *MailboxDealing
+		if qualified, ok := mailbox.Dealings[dealerID]; ok {
+			dealing = qualified
+		} else if disputed, ok := mailbox.Disputed[dealerID]; ok {
+			dealing = disputed
+		} else {
+			return &pb.CheckDealingsResponse{
+				Success: false,
+				Message: fmt.Sprintf("No dealing from %s", dealerID),
+			}, nil
+		}
+
+		if dealerID == req.MemberId {
+			return &pb.CheckDealingsResponse{
+				Success: false,
+				Message: "Cannot complain about your own dealing",
+			}, nil
+		}
+		if dealing.RevealedShares[req.MemberId] != nil {
+			return &pb.CheckDealingsResponse{
+				Success: false,
+				Message: fmt.Sprintf("%s has revealed your share and it matches the commitments", dealerID),
+			}, nil
+		}
+	}
+
+	for _, dealerID := range req.Complaints {
+		mailbox.dispute(dealerID, req.MemberId)
+		log.Printf("%s complained about the dealing of %s in %s", req.MemberId, dealerID, mailbox.ID)
+	}
+
+	if len(req.Complaints) > 0 {
+		delete(mailbox.Acknowledged, req.MemberId)
+		return &pb.CheckDealingsResponse{
+			Success: true,
+			Message: "Complaints recorded: the dealers must reveal your shares",
+		}, nil
+	}
+
+	mailbox.Acknowledged[req.MemberId] = true
+	log.Printf("%s acknowledged the dealings of %s", req.MemberId, mailbox.ID)
+
+	if len(mailbox.Acknowledged) == len(mailbox.Members) && len(mailbox.Dealers) > 0 {
+		mailbox.setPublicKey()
+		log.Printf("Mailbox %s key is ready (%d dealers)", mailbox.ID, len(mailbox.Dealers))
+	}
+
+	return &pb.CheckDealingsResponse{
+		Success: true,
+		Message: "Shares acknowledged",
+	}, nil
+}
+
+// AnswerComplaint lets a dealer answer a complaint by revealing the share it
+// dealt to the member. A share that matches the commitments settles the
+// complaint, and the dealing is back in Dealers once none is left; any
+// other share leaves the complaint standing.
+func (s *CryptoServiceServer) AnswerComplaint(ctx context.Context, req *pb.AnswerComplaintRequest) (*pb.AnswerComplaintResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mailbox, exists := s.mailboxes[req.MailboxId]
+	if !exists {
+		return &pb.AnswerComplaintResponse{
+			Success: false,
+			Message: "Mailbox not found",
+		}, nil
+	}
+
+	if mailbox.PublicKey != "" {
+		return &pb.AnswerComplaintResponse{
+			Success: false,
+			Message: "Mailbox key is already set",
+		}, nil
+	}
+
+	dealing, disputed := mailbox.Disputed[req.DealerId]
+	if !disputed || !mailbox.hasComplaint(req.DealerId, req.MemberId) {
+		return &pb.AnswerComplaintResponse{
+			Success: false,
+			Message: "No such complaint",
+		}, nil
+	}
+
+	share := new(big.Int).SetBytes(req.Share)
+	err := threshold.VerifyShare(mailbox.Group, dealing.Commitments, mailbox.memberIndex(req.MemberId), share)
+	if err != nil {
+		return &pb.AnswerComplaintResponse{
+			Success: false,
+			Message: "Complaint stands: " + err.Error(),
+		}, nil
+	}
+
+	dealing.RevealedShares[req.MemberId] = share
+	mailbox.Complaints = slices.DeleteFunc(mailbox.Complaints, func(complaint *MailboxComplaint) bool {
+		return complaint.DealerID == req.DealerId && complaint.MemberID == req.MemberId
+	})
+	log.Printf("%s answered the complaint of %s in %s", req.DealerId, req.MemberId, mailbox.ID)
+
+	if !slices.ContainsFunc(mailbox.Complaints, func(complaint *MailboxComplaint) bool {
+		return complaint.DealerID == req.DealerId
+	}) {
+		mailbox.reinstate(req.DealerId)
+	}
+
+	return &pb.AnswerComplaintResponse{
+		Success: true,
+		Message: "Complaint answered",
+	}, nil
+}
+
+// setPublicKey computes the mailbox key and each member's verification key
+// from the dealings in Dealers.
+func (m *Mailbox) setPublicKey() {
+	dealings := m.commitments()
+
+	m.PublicKey = threshold.EncodePublicKey(m.Group, threshold.PublicKey(m.Group, dealings))
+	m.verificationKeys = make([]*big.Int, len(m.Members))
+	for i := range m.Members {
+		m.verificationKeys[i] = threshold.VerificationKey(m.Group, dealings, i+1)
+	}
+}
+
+// commitments lists the commitments of every dealing in dealer order.
+func (m *Mailbox) commitments() [][]*big.Int {
+	dealings := make([][]*big.Int, len(m.Dealers))
+	for i, dealer := range m.Dealers {
+		dealings[i] = m.Dealings[dealer].Commitments
+	}
+	return dealings
+}
+
+func (s *CryptoServiceServer) GetDealings(ctx context.Context, req *pb.GetDealingsRequest) (*pb.GetDealingsResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mailbox, exists := s.mailboxes[req.MailboxId]
+	if !exists {
+		return &pb.GetDealingsResponse{
+			Success: false,
+			Message: "Mailbox not found",
+		}, nil
+	}
+
+	if mailbox.memberIndex(req.MemberId) == 0 {
+		return &pb.GetDealingsResponse{
+			Success: false,
+			Message: "Not a member of this mailbox",
+		}, nil
+	}
+
+	dealings := make([]*pb.Dealing, 0, len(mailbox.Members))
+	addDealing := func(dealer string, dealing *MailboxDealing, disputed bool) {
+		commitments := make([][]byte, len(dealing.Commitments))
+		for i, c := range dealing.Commitments {
+			commitments[i] = c.Bytes()
+		}
+
+		var revealed []byte
+		if share := dealing.RevealedShares[req.MemberId]; share != nil {
+			revealed = share.Bytes()
+		}
+
+		var dealerCopy []byte
+		if dealer == req.MemberId {
+			dealerCopy = dealing.DealerCopy
+		}
+
+		dealings = append(dealings, &pb.Dealing{
+			DealerId:       dealer,
+			Commitments:    commitments,
+			EncryptedShare: dealing.EncryptedShares[req.MemberId],
+			RevealedShare:  revealed,
+			Disputed:       disputed,
+			DealerCopy:     dealerCopy,
+		})
+	}
+
+	for _, dealer := range mailbox.Dealers {
+		addDealing(dealer, mailbox.Dealings[dealer], false)
+	}
+	for _, dealer := range slices.Sorted(maps.Keys(mailbox.Disputed)) {
+		addDealing(dealer, mailbox.Disputed[dealer], true)
+	}
+
+	return &pb.GetDealingsResponse{
+		Success:  true,
+		Message:  "Dealings retrieved successfully",
+		Dealings: dealings,
+	}, nil
+}
+
+func (s *CryptoServiceServer) SendMailboxMessage(ctx context.Context, req *pb.SendMailboxMessageRequest) (*pb.SendMailboxMessageResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.users[req.SenderId]; !exists {
+		return &pb.SendMailboxMessageResponse{
+			Success: false,
+			Message: "Sender not found",
+		}, nil
+	}
+
+	mailbox, exists := s.mailboxes[req.MailboxId]
+	if !exists {
+		return &pb.SendMailboxMessageResponse{
+			Success: false,
+			Message: "Mailbox not found",
+		}, nil
+	}
+
+	if mailbox.PublicKey == "" {
+		return &pb.SendMailboxMessageResponse{
+			Success: false,
+			Message: "Mailbox key is not ready: waiting for dealings and checks",
+		}, nil
+	}
+
+	// Partial decryptions are only checked for a in the group, so reject
+	// anything else here rather than leave the message undecryptable.
+	a, _, err := elgamal.DecodeCiphertext(req.EncryptedMessage)
+	if err != nil || !mailbox.Group.IsElement(a) {
+		return &pb.SendMailboxMessageResponse{
+			Success: false,
+			Message: "Invalid ciphertext for this mailbox",
+		}, nil
+	}
+
+	mailbox.Messages = append(mailbox.Messages, &MailboxMessage{
+		ID:               len(mailbox.Messages) + 1,
+		SenderID:         req.SenderId,
+		EncryptedMessage: req.EncryptedMessage,
+		A:                a,
+		Timestamp:        time.Now(),
+	})
+
+	log.Printf("Message sent from %s to %s", req.SenderId, mailbox.ID)
+	return &pb.SendMailboxMessageResponse{
+		Success: true,
+		Message: "Message sent successfully",
+	}, nil
+}
+
+func (s *CryptoServiceServer) GetMailboxMessages(ctx context.Context, req *pb.GetMailboxMessagesRequest) (*pb.GetMailboxMessagesResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mailbox, exists := s.mailboxes[req.MailboxId]
+	if !exists {
+		return &pb.GetMailboxMessagesResponse{
+			Success: false,
+			Message: "Mailbox not found",
+		}, nil
+	}
+
+	if mailbox.memberIndex(req.MemberId) == 0 {
+		return &pb.GetMailboxMessagesResponse{
+			Success: false,
+			Message: "Not a member of this mailbox",
+		}, nil
+	}
+
+	messages := make([]*pb.MailboxMessage, 0, len(mailbox.Messages))
+	for _, msg := range mailbox.Messages {
+		partials := make([]*pb.PartialDecryption, 0, len(msg.Partials))
+		for _, partial := range msg.Partials {
+			partials = append(partials, &pb.PartialDecryption{
+				MemberId: partial.MemberID,
+				Partial:  partial.Partial,
+				Proof:    partial.Proof,
+			})
+		}
+
+		messages = append(messages, &pb.MailboxMessage{
+			MessageId:        int32(msg.ID),
+			SenderId:         msg.SenderID,
+			EncryptedMessage: msg.EncryptedMessage,
+			Timestamp:        msg.Timestamp.Unix(),
+			Partials:         partials,
+		})
+	}
+
+	return &pb.GetMailboxMessagesResponse{
+		Success:  true,
+		Message:  "Messages retrieved successfully",
+		Messages: messages,
+	}, nil
+}
+
+func (s *CryptoServiceServer) SubmitPartialDecryption(ctx context.Context, req *pb.SubmitPartialDecryptionRequest) (*pb.SubmitPartialDecryptionResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mailbox, exists := s.mailboxes[req.MailboxId]
+	if !exists {
+		return &pb.SubmitPartialDecryptionResponse{
+			Success: false,
+			Message: "Mailbox not found",
+		}, nil
+	}
+
+	index := mailbox.memberIndex(req.MemberId)
+	if index == 0 {
+		return &pb.SubmitPartialDecryptionResponse{
+			Success: false,
+			Message: "Not a member of this mailbox",
+		}, nil
+	}
+
+	if req.MessageId < 1 || int(req.MessageId) > len(mailbox.Messages) {
+		return &pb.SubmitPartialDecryptionResponse{
+			Success: false,
+			Message: "Message not found",
+		}, nil
+	}
+	msg := mailbox.Messages[req.MessageId-1]
+
+	for _, partial := range msg.Partials {
+		if partial.MemberID == req.MemberId {
+			return &pb.SubmitPartialDecryptionResponse{
+				Success: false,
+				Message: "Already submitted a partial decryption for this message",
+			}, nil
+		}
+	}
+
+	d := new(big.Int).SetBytes(req.Partial)
+	err := threshold.VerifyPartial(mailbox.Group, mailbox.verificationKeys[index-1], msg.A, d, req.Proof)
+	if err != nil {
+		return &pb.SubmitPartialDecryptionResponse{
+			Success: false,
+			Message: "Invalid partial decryption: " + err.Error(),
+		}, nil
+	}
+
+	msg.Partials = append(msg.Partials, &PartialDecryption{
+		MemberID: req.MemberId,
+		Partial:  req.Partial,
+		Proof:    req.Proof,
+	})
+
+	log.Printf("Partial decryption of %s message %d submitted by %s", mailbox.ID, msg.ID, req.MemberId)
+	return &pb.SubmitPartialDecryptionResponse{
+		Success: true,
+		Message: "Partial decryption submitted successfully",
+	}, nil
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto/threshold"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+)
+
+// mailboxMembers are the members of the test mailboxes, in member order.
+var mailboxMembers = []string{"alice", "bob", "carol"}
+
+// newMailbox returns a server with a 2-of-3 mailbox for mailboxMembers.
+func newMailbox(t *testing.T) (*CryptoServiceServer, *Mailbox) {
+	t.Helper()
+
+	s := NewCryptoServerServer()
+	for _, user := range mailboxMembers {
+		if _, err := s.RegisterUser(context.Background(), &pb.RegisterUserRequest{UserId: user, Name: user}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := s.CreateMailbox(context.Background(), &pb.CreateMailboxRequest{
+		CreatorId: "alice",
+		MemberIds: mailboxMembers[1:],
+		Threshold: 2,
+		Group:     "modp2048",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("CreateMailbox: %v, %v", resp, err)
+	}
+	return s, s.mailboxes[resp.MailboxId]
+}
+
+// dealAll submits a dealing from every member and returns them by dealer.
+// The server cannot read the encrypted shares, so the shares are sent in
+// the clear.
+func dealAll(t *testing.T, s *CryptoServiceServer, mailbox *Mailbox) map[string]*threshold.Dealing {
+	t.Helper()
+
+	dealings := make(map[string]*threshold.Dealing)
+	for _, dealer := range mailboxMembers {
+		dealing, err := threshold.Deal(mailbox.Group, mailbox.Threshold, len(mailboxMembers))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitments := make([][]byte, len(dealing.Commitments))
+		for i, c := range dealing.Commitments {
+			commitments[i] = c.Bytes()
+		}
+		shares := make(map[string][]byte)
+		for j, member := range mailboxMembers {
+			shares[member] = dealing.Shares[j+1].Bytes()
+		}
+
+		resp, _ := s.SubmitDealing(context.Background(), &pb.SubmitDealingRequest{
+			MailboxId:       mailbox.ID,
+			DealerId:        dealer,
+			Commitments:     commitments,
+			EncryptedShares: shares,
+			DealerCopy:      []byte("copy"),
+		})
+		if !resp.Success {
+			t.Fatalf("SubmitDealing(%s): %s", dealer, resp.Message)
+		}
+		dealings[dealer] = dealing
+	}
+	return dealings
+}
+
+func checkDealings(s *CryptoServiceServer, mailbox *Mailbox, member string, complaints ...string) *pb.CheckDealingsResponse {
+	resp, _ := s.CheckDealings(context.Background(), &pb.CheckDealingsRequest{
+		MailboxId:  mailbox.ID,
+		MemberId:   member,
+		Complaints: complaints,
+	})
+	return resp
+}
+
+func answerComplaint(s *CryptoServiceServer, mailbox *Mailbox, dealer, member string, share *big.Int) *pb.AnswerComplaintResponse {
+	resp, _ := s.AnswerComplaint(context.Background(), &pb.AnswerComplaintRequest{
+		MailboxId: mailbox.ID,
+		DealerId:  dealer,
+		MemberId:  member,
+		Share:     share.Bytes(),
+	})
+	return resp
+}
+
+// wantKey checks that the mailbox key and verification keys come from the
+// dealings of exactly the given dealers.
+func wantKey(t *testing.T, mailbox *Mailbox, dealings map[string]*threshold.Dealing, dealers ...string) {
+	t.Helper()
+
+	var commitments [][]*big.Int
+	for _, dealer := range dealers {
+		commitments = append(commitments, dealings[dealer].Commitments)
+	}
+
+	want := threshold.EncodePublicKey(mailbox.Group, threshold.PublicKey(mailbox.Group, commitments))
+	if mailbox.PublicKey != want {
+		t.Fatalf("mailbox key is not made from the dealings of %v", dealers)
+	}
+	for j := range mailboxMembers {
+		if mailbox.verificationKeys[j].Cmp(threshold.VerificationKey(mailbox.Group, commitments, j+1)) != 0 {
+			t.Fatalf("verification key of member %d is not made from the dealings of %v", j+1, dealers)
+		}
+	}
+}
+
+func TestCreateMailboxRejectsThreshold(t *testing.T) {
+	s := NewCryptoServerServer()
+	for _, user := range mailboxMembers {
+		s.RegisterUser(context.Background(), &pb.RegisterUserRequest{UserId: user, Name: user})
+	}
+
+	for _, invalid := range []int32{0, 1, 4} {
+		resp, _ := s.CreateMailbox(context.Background(), &pb.CreateMailboxRequest{
+			CreatorId: "alice",
+			MemberIds: mailboxMembers[1:],
+			Threshold: invalid,
+			Group:     "modp2048",
+		})
+		if resp.Success {
+			t.Errorf("CreateMailbox with threshold %d succeeded", invalid)
+		}
+	}
+}
+
+func TestMailboxKeyWaitsForAcknowledgements(t *testing.T) {
+	s, mailbox := newMailbox(t)
+
+	if resp := checkDealings(s, mailbox, "alice"); resp.Success {
+		t.Fatal("shares acknowledged before anyone dealt")
+	}
+
+	dealings := dealAll(t, s, mailbox)
+	for _, member := range mailboxMembers {
+		if mailbox.PublicKey != "" {
+			t.Fatalf("key set before %s acknowledged", member)
+		}
+		if resp := checkDealings(s, mailbox, member); !resp.Success {
+			t.Fatalf("CheckDealings(%s): %s", member, resp.Message)
+		}
+	}
+
+	wantKey(t, mailbox, dealings, mailboxMembers...)
+
+	if resp := checkDealings(s, mailbox, "carol", "bob"); resp.Success {
+		t.Error("a complaint was accepted after the key was set")
+	}
+}
+
+func TestMailboxComplaintDropsDealing(t *testing.T) {
+	s, mailbox := newMailbox(t)
+	dealings := dealAll(t, s, mailbox)
+
+	for _, complaints := range [][]string{{"carol"}, {"dave"}} {
+		if resp := checkDealings(s, mailbox, "carol", complaints...); resp.Success {
+			t.Errorf("carol's complaint about %v was accepted", complaints)
+		}
+	}
+
+	checkDealings(s, mailbox, "alice")
+	checkDealings(s, mailbox, "bob")
+	if resp := checkDealings(s, mailbox, "carol", "bob"); !resp.Success {
+		t.Fatalf("CheckDealings: %s", resp.Message)
+	}
+	if _, dealt := mailbox.Dealings["bob"]; dealt || len(mailbox.Dealers) != 2 {
+		t.Fatalf("bob's dealing is still among the dealers %v", mailbox.Dealers)
+	}
+	if mailbox.Acknowledged["bob"] {
+		t.Fatal("bob's acknowledgement still counts after the complaint")
+	}
+
+	wrong := new(big.Int).Add(dealings["bob"].Shares[3], big.NewInt(1))
+	if resp := answerComplaint(s, mailbox, "bob", "carol", wrong); resp.Success {
+		t.Fatal("a share that does not match the commitments answered the complaint")
+	}
+	if resp := answerComplaint(s, mailbox, "alice", "carol", dealings["alice"].Shares[3]); resp.Success {
+		t.Fatal("alice answered a complaint that was not made")
+	}
+
+	// Bob acknowledges without his dealing, and so does carol.
+	checkDealings(s, mailbox, "bob")
+	if resp := checkDealings(s, mailbox, "carol"); !resp.Success {
+		t.Fatalf("CheckDealings: %s", resp.Message)
+	}
+	wantKey(t, mailbox, dealings, "alice", "carol")
+}
+
+func TestMailboxAnsweredComplaintReinstatesDealing(t *testing.T) {
+	s, mailbox := newMailbox(t)
+	dealings := dealAll(t, s, mailbox)
+
+	checkDealings(s, mailbox, "bob")
+	if resp := checkDealings(s, mailbox, "carol", "alice"); !resp.Success {
+		t.Fatalf("CheckDealings: %s", resp.Message)
+	}
+
+	if resp := answerComplaint(s, mailbox, "alice", "carol", dealings["alice"].Shares[3]); !resp.Success {
+		t.Fatalf("AnswerComplaint: %s", resp.Message)
+	}
+	if _, dealt := mailbox.Dealings["alice"]; !dealt || len(mailbox.Complaints) != 0 {
+		t.Fatal("alice's dealing was not reinstated")
+	}
+	if len(mailbox.Acknowledged) != 0 {
+		t.Fatal("acknowledgements made without alice's dealing still count")
+	}
+
+	resp, _ := s.GetDealings(context.Background(), &pb.GetDealingsRequest{MailboxId: mailbox.ID, MemberId: "carol"})
+	for _, dealing := range resp.Dealings {
+		revealed := new(big.Int).SetBytes(dealing.RevealedShare)
+		if dealing.DealerId == "alice" && revealed.Cmp(dealings["alice"].Shares[3]) != 0 {
+			t.Fatal("carol cannot see the share alice revealed")
+		}
+	}
+
+	if resp := checkDealings(s, mailbox, "carol", "alice"); resp.Success {
+		t.Fatal("carol complained again about a revealed share")
+	}
+
+	for _, member := range mailboxMembers {
+		checkDealings(s, mailbox, member)
+	}
+	wantKey(t, mailbox, dealings, mailboxMembers...)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/crypto/elgamal"
+	"github.com/luizgbraga/crypto-go/internal/crypto/paillier"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+)
+
+// Poll collects encrypted votes under the owner's public key as it was when
+// the poll was created. The server adds them up homomorphically and never
+// sees an individual vote.
+//
+// Votes carry no proof that they encrypt 0 or 1, so a voter can cast any
+// value, such as 5 or -3, and skew the total without it being noticed.
+type Poll struct {
+	ID        string
+	OwnerID   string
+	Question  string
+	Algorithm crypto.Algorithm
+	PublicKey []byte
+	MinVotes  int
+	Closed    bool
+	Voters    map[string]bool
+	Votes     [][]byte
+	CreatedAt time.Time
+
+	// ciphertexts holds every vote cast, so a copy of another voter's
+	// ciphertext is refused.
+	ciphertexts map[string]bool
+}
+
+// minPollVotes is the fewest votes a poll is tallied over, so the total does
+// not give away how a single voter voted.
+const minPollVotes = 3
+
+// tallyFunc adds encoded ciphertexts under an encoded public key.
+type tallyFunc func(publicKey string, ciphertexts [][]byte) ([]byte, error)
+
+// tallyFuncs lists the additively homomorphic algorithms polls can use.
+var tallyFuncs = map[crypto.Algorithm]tallyFunc{
+	crypto.Paillier:           paillier.Sum,
+	crypto.ExponentialElGamal: elgamal.Sum,
+}
+
+func (s *CryptoServiceServer) CreatePoll(ctx context.Context, req *pb.CreatePollRequest) (*pb.CreatePollResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.users[req.OwnerId]; !exists {
+		return &pb.CreatePollResponse{
+			Success: false,
+			Message: "Owner not found",
+		}, nil
+	}
+
+	algorithm := crypto.Algorithm(req.Algorithm)
+	if _, supported := tallyFuncs[algorithm]; !supported {
+		return &pb.CreatePollResponse{
+			Success: false,
+			Message: fmt.Sprintf("Algorithm %s cannot tally votes", algorithm),
+		}, nil
+	}
+
+	keyAlgorithm := crypto.KeyAlgorithm(algorithm)
+	publicKey, err := s.keyStore.GetPublicKey(req.OwnerId, keyAlgorithm)
+	if err != nil {
+		return &pb.CreatePollResponse{
+			Success: false,
+			Message: fmt.Sprintf("Owner has no %s public key", keyAlgorithm),
+		}, nil
+	}
+
+	s.nextPollID++
+	poll := &Poll{
+		ID:          fmt.Sprintf("poll-%d", s.nextPollID),
+		OwnerID:     req.OwnerId,
+		Question:    req.Question,
+		Algorithm:   algorithm,
+		PublicKey:   publicKey,
+		MinVotes:    max(int(req.MinVotes), minPollVotes),
+		Voters:      make(map[string]bool),
+		CreatedAt:   time.Now(),
+		ciphertexts: make(map[string]bool),
+	}
+	s.polls[poll.ID] = poll
+
+	log.Printf("Poll %s created by %s (algorithm: %s)", poll.ID, req.OwnerId, algorithm)
+	return &pb.CreatePollResponse{
+		Success: true,
+		Message: "Poll created successfully",
+		PollId:  poll.ID,
+	}, nil
+}
+
+func (s *CryptoServiceServer) GetPolls(ctx context.Context, req *pb.EmptyRequest) (*pb.PollList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pollList := &pb.PollList{}
+	for _, poll := range s.polls {
+		pollList.Polls = append(pollList.Polls, &pb.Poll{
+			PollId:    poll.ID,
+			OwnerId:   poll.OwnerID,
+			Question:  poll.Question,
+			Algorithm: string(poll.Algorithm),
+			Votes:     int32(len(poll.Votes)),
+			Closed:    poll.Closed,
+			MinVotes:  int32(poll.MinVotes),
+			PublicKey: poll.PublicKey,
+		})
+	}
+
+	return pollList, nil
+}
+
+// SubmitVote adds an encrypted vote to an open poll. The vote must be a valid
+// ciphertext under the poll's key and not a copy of one already cast, but
+// nothing shows that it encrypts 0 or 1; see Poll.
+func (s *CryptoServiceServer) SubmitVote(ctx context.Context, req *pb.SubmitVoteRequest) (*pb.SubmitVoteResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.users[req.VoterId]; !exists {
+		return &pb.SubmitVoteResponse{
+			Success: false,
+			Message: "Voter not found",
+		}, nil
+	}
+
+	poll, exists := s.polls[req.PollId]
+	if !exists {
+		return &pb.SubmitVoteResponse{
+			Success: false,
+			Message: "Poll not found",
+		}, nil
+	}
+
+	if poll.Closed {
+		return &pb.SubmitVoteResponse{
+			Success: false,
+			Message: "Poll is closed",
+		}, nil
+	}
+
+	if poll.Voters[req.VoterId] {
+		return &pb.SubmitVoteResponse{
+			Success: false,
+			Message: "Already voted in this poll",
+		}, nil
+	}
+
+	// A copy of another vote would count it twice. A re-randomised copy
+	// still gets through; only a proof tied to the voter would stop it.
+	if poll.ciphertexts[string(req.EncryptedVote)] {
+		return &pb.SubmitVoteResponse{
+			Success: false,
+			Message: "Vote duplicates one already cast",
+		}, nil
+	}
+
+	// Adding the vote on its own checks that it is a valid ciphertext
+	// under the poll's key, so one bad vote cannot break the tally.
+	if _, err := tallyFuncs[poll.Algorithm](string(poll.PublicKey), [][]byte{req.EncryptedVote}); err != nil {
+		return &pb.SubmitVoteResponse{
+			Success: false,
+			Message: "Invalid vote: " + err.Error(),
+		}, nil
+	}
+
+	poll.Voters[req.VoterId] = true
+	poll.Votes = append(poll.Votes, req.EncryptedVote)
+	poll.ciphertexts[string(req.EncryptedVote)] = true
+
+	log.Printf("Vote submitted to %s", poll.ID)
+	return &pb.SubmitVoteResponse{
+		Success: true,
+		Message: "Vote submitted successfully",
+	}, nil
+}
+
+// ClosePoll stops a poll from taking more votes. Only the owner can close
+// it, and only a closed poll can be tallied.
+func (s *CryptoServiceServer) ClosePoll(ctx context.Context, req *pb.ClosePollRequest) (*pb.ClosePollResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	poll, exists := s.polls[req.PollId]
+	if !exists {
+		return &pb.ClosePollResponse{
+			Success: false,
+			Message: "Poll not found",
+		}, nil
+	}
+
+	if poll.OwnerID != req.OwnerId {
+		return &pb.ClosePollResponse{
+			Success: false,
+			Message: "Only the poll owner can close it",
+		}, nil
+	}
+
+	if poll.Closed {
+		return &pb.ClosePollResponse{
+			Success: false,
+			Message: "Poll is already closed",
+		}, nil
+	}
+
+	poll.Closed = true
+
+	log.Printf("Poll %s closed with %d votes", poll.ID, len(poll.Votes))
+	return &pb.ClosePollResponse{
+		Success: true,
+		Message: "Poll closed successfully",
+	}, nil
+}
+
+func (s *CryptoServiceServer) TallyPoll(ctx context.Context, req *pb.TallyPollRequest) (*pb.TallyPollResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	poll, exists := s.polls[req.PollId]
+	if !exists {
+		return &pb.TallyPollResponse{
+			Success: false,
+			Message: "Poll not found",
+		}, nil
+	}
+
+	if poll.OwnerID != req.OwnerId {
+		return &pb.TallyPollResponse{
+			Success: false,
+			Message: "Only the poll owner can tally it",
+		}, nil
+	}
+
+	if !poll.Closed {
+		return &pb.TallyPollResponse{
+			Success: false,
+			Message: "Close the poll before tallying it",
+		}, nil
+	}
+
+	if len(poll.Votes) < poll.MinVotes {
+		return &pb.TallyPollResponse{
+			Success: false,
+			Message: fmt.Sprintf("Poll has %d votes, needs at least %d", len(poll.Votes), poll.MinVotes),
+		}, nil
+	}
+
+	total, err := tallyFuncs[poll.Algorithm](string(poll.PublicKey), poll.Votes)
+	if err != nil {
+		return &pb.TallyPollResponse{
+			Success: false,
+			Message: "Failed to tally votes: " + err.Error(),
+		}, nil
+	}
+
+	log.Printf("Poll %s tallied over %d votes", poll.ID, len(poll.Votes))
+	return &pb.TallyPollResponse{
+		Success:        true,
+		Message:        "Poll tallied successfully",
+		EncryptedTotal: total,
+		Votes:          int32(len(poll.Votes)),
+	}, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/crypto/paillier"
+	"github.com/luizgbraga/crypto-go/internal/keystore"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+)
+
+// newPollServer returns a server with the given users registered and a
+// Paillier key for the first one, the poll owner.
+func newPollServer(t *testing.T, users ...string) (*CryptoServiceServer, *paillier.PaillierProvider) {
+	t.Helper()
+
+	s := NewCryptoServerServer()
+	for _, user := range users {
+		if _, err := s.RegisterUser(context.Background(), &pb.RegisterUserRequest{UserId: user, Name: user}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	owner := paillier.NewPaillierProvider(keystore.NewClientKeyStore(users[0]), users[0])
+	registerPaillierKey(t, s, owner, users[0])
+	return s, owner
+}
+
+func registerPaillierKey(t *testing.T, s *CryptoServiceServer, provider *paillier.PaillierProvider, userID string) {
+	t.Helper()
+
+	if err := provider.GenerateKeyPair(1024); err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := provider.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.RegisterPublicKey(context.Background(), &pb.RegisterPublicKeyRequest{
+		UserId:    userID,
+		Algorithm: string(crypto.Paillier),
+		KeyData:   publicKey,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("RegisterPublicKey: %v, %v", resp, err)
+	}
+}
+
+func createPoll(t *testing.T, s *CryptoServiceServer, ownerID string, minVotes int) *pb.Poll {
+	t.Helper()
+
+	resp, err := s.CreatePoll(context.Background(), &pb.CreatePollRequest{
+		OwnerId:   ownerID,
+		Question:  "Pizza?",
+		Algorithm: string(crypto.Paillier),
+		MinVotes:  int32(minVotes),
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("CreatePoll: %v, %v", resp, err)
+	}
+
+	polls, err := s.GetPolls(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, poll := range polls.Polls {
+		if poll.PollId == resp.PollId {
+			return poll
+		}
+	}
+	t.Fatalf("poll %s not listed", resp.PollId)
+	return nil
+}
+
+// encryptVote encrypts value under the poll's key as the voter would.
+func encryptVote(t *testing.T, poll *pb.Poll, voterID string, value int64) []byte {
+	t.Helper()
+
+	voter := paillier.NewPaillierProvider(keystore.NewClientKeyStore(voterID), voterID)
+	if err := voter.ParsePublicKey(poll.OwnerId, poll.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	vote, err := voter.EncryptValue(big.NewInt(value), poll.OwnerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vote
+}
+
+func submitVote(s *CryptoServiceServer, pollID, voterID string, vote []byte) *pb.SubmitVoteResponse {
+	resp, _ := s.SubmitVote(context.Background(), &pb.SubmitVoteRequest{
+		PollId:        pollID,
+		VoterId:       voterID,
+		EncryptedVote: vote,
+	})
+	return resp
+}
+
+func TestPollLifecycle(t *testing.T) {
+	s, owner := newPollServer(t, "alice", "bob", "carol", "dave", "erin")
+	poll := createPoll(t, s, "alice", 0)
+	if poll.MinVotes != minPollVotes {
+		t.Fatalf("MinVotes = %d, want %d", poll.MinVotes, minPollVotes)
+	}
+
+	// Votes stay under the key the poll was created with, even after the
+	// owner registers a new one.
+	registerPaillierKey(t, s, paillier.NewPaillierProvider(keystore.NewClientKeyStore("alice"), "alice"), "alice")
+
+	bobVote := encryptVote(t, poll, "bob", 1)
+	for voter, vote := range map[string][]byte{
+		"bob":   bobVote,
+		"carol": encryptVote(t, poll, "carol", 0),
+		"dave":  encryptVote(t, poll, "dave", 1),
+	} {
+		if resp := submitVote(s, poll.PollId, voter, vote); !resp.Success {
+			t.Fatalf("SubmitVote(%s): %s", voter, resp.Message)
+		}
+	}
+
+	if resp := submitVote(s, poll.PollId, "bob", encryptVote(t, poll, "bob", 1)); resp.Success {
+		t.Error("a second vote from bob was accepted")
+	}
+	if resp := submitVote(s, poll.PollId, "erin", bobVote); resp.Success {
+		t.Error("a copy of bob's vote was accepted")
+	}
+
+	tally, _ := s.TallyPoll(context.Background(), &pb.TallyPollRequest{PollId: poll.PollId, OwnerId: "alice"})
+	if tally.Success {
+		t.Error("an open poll was tallied")
+	}
+
+	closed, _ := s.ClosePoll(context.Background(), &pb.ClosePollRequest{PollId: poll.PollId, OwnerId: "bob"})
+	if closed.Success {
+		t.Error("bob closed alice's poll")
+	}
+	closed, _ = s.ClosePoll(context.Background(), &pb.ClosePollRequest{PollId: poll.PollId, OwnerId: "alice"})
+	if !closed.Success {
+		t.Fatalf("ClosePoll: %s", closed.Message)
+	}
+
+	if resp := submitVote(s, poll.PollId, "erin", encryptVote(t, poll, "erin", 1)); resp.Success {
+		t.Error("a vote was accepted after the poll closed")
+	}
+
+	tally, _ = s.TallyPoll(context.Background(), &pb.TallyPollRequest{PollId: poll.PollId, OwnerId: "bob"})
+	if tally.Success {
+		t.Error("bob tallied alice's poll")
+	}
+
+	tally, _ = s.TallyPoll(context.Background(), &pb.TallyPollRequest{PollId: poll.PollId, OwnerId: "alice"})
+	if !tally.Success {
+		t.Fatalf("TallyPoll: %s", tally.Message)
+	}
+	total, err := owner.DecryptValue(tally.EncryptedTotal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.Int64() != 2 || tally.Votes != 3 {
+		t.Fatalf("tally = %s of %d votes, want 2 of 3", total, tally.Votes)
+	}
+}
+
+func TestTallyPollNeedsMinimumVotes(t *testing.T) {
+	s, _ := newPollServer(t, "alice", "bob", "carol", "dave")
+	poll := createPoll(t, s, "alice", 4)
+
+	for _, voter := range []string{"bob", "carol", "dave"} {
+		if resp := submitVote(s, poll.PollId, voter, encryptVote(t, poll, voter, 1)); !resp.Success {
+			t.Fatalf("SubmitVote(%s): %s", voter, resp.Message)
+		}
+	}
+
+	if closed, _ := s.ClosePoll(context.Background(), &pb.ClosePollRequest{PollId: poll.PollId, OwnerId: "alice"}); !closed.Success {
+		t.Fatalf("ClosePoll: %s", closed.Message)
+	}
+
+	tally, _ := s.TallyPoll(context.Background(), &pb.TallyPollRequest{PollId: poll.PollId, OwnerId: "alice"})
+	if tally.Success {
+		t.Fatal("a poll with 3 of at least 4 votes was tallied")
+	}
+}
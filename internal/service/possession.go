@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/luizgbraga/crypto-go/internal/crypto"
+	"github.com/luizgbraga/crypto-go/internal/crypto/elgamal"
+	"github.com/luizgbraga/crypto-go/internal/crypto/rsa"
+	pb "github.com/luizgbraga/crypto-go/pkg/cryptogrpc"
+)
+
+// ChallengeLifetime is how long a registration challenge can be answered.
+const ChallengeLifetime = 2 * time.Minute
+
+// NonceSize is the length of a registration challenge nonce.
+const NonceSize = 32
+
+// KeyChallenge is a nonce issued to a user registering a key whose
+// possession is proved interactively. It can be answered once, before
+// ExpiresAt.
+type KeyChallenge struct {
+	Algorithm crypto.Algorithm
+	Nonce     []byte
+	ExpiresAt time.Time
+}
+
+func (s *CryptoServiceServer) GetKeyChallenge(ctx context.Context, req *pb.GetKeyChallengeRequest) (*pb.GetKeyChallengeResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.users[req.UserId]; !exists {
+		return &pb.GetKeyChallengeResponse{
+			Success: false,
+			Message: "User not found",
+		}, nil
+	}
+
+	nonce := make([]byte, NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return &pb.GetKeyChallengeResponse{
+			Success: false,
+			Message: "Failed to create challenge: " + err.Error(),
+		}, nil
+	}
+
+	// A new challenge replaces any earlier one for the user.
+	challenge := &KeyChallenge{
+		Algorithm: crypto.KeyAlgorithm(crypto.Algorithm(req.Algorithm)),
+		Nonce:     nonce,
+		ExpiresAt: time.Now().Add(ChallengeLifetime),
+	}
+	s.challenges[req.UserId] = challenge
+
+	log.Printf("Key challenge issued to user %s (algorithm: %s)", req.UserId, challenge.Algorithm)
+	return &pb.GetKeyChallengeResponse{
+		Success:   true,
+		Message:   "Challenge issued successfully",
+		Nonce:     nonce,
+		ExpiresAt: challenge.ExpiresAt.Unix(),
+	}, nil
+}
+
+// verifyPossession checks the registrant's proof that they hold the private
+// key of the public key they are registering, for the algorithms that
+// require one. Without it anyone could register someone else's key as
+// their own.
+func (s *CryptoServiceServer) verifyPossession(userID string, algorithm crypto.Algorithm, keyData, proof []byte) error {
+	switch crypto.KeyAlgorithm(algorithm) {
+	case crypto.ElGamal:
+		publicKey, err := elgamal.DecodePublicKey(string(keyData))
+		if err != nil {
+			return err
+		}
+		return publicKey.VerifyPossession(userID, proof)
+	case crypto.RSA:
+		nonce, err := s.takeChallenge(userID, crypto.RSA)
+		if err != nil {
+			return err
+		}
+
+		publicKey, err := rsa.DecodePublicKey(string(keyData))
+		if err != nil {
+			return err
+		}
+		return publicKey.VerifyChallenge(userID, nonce, proof)
+	default:
+		return nil
+	}
+}
+
+// takeChallenge returns the nonce of the user's outstanding challenge for
+// the algorithm. The challenge is used up whether or not the answer turns
+// out to be right.
+func (s *CryptoServiceServer) takeChallenge(userID string, algorithm crypto.Algorithm) ([]byte, error) {
+	challenge, exists := s.challenges[userID]
+	if !exists {
+		return nil, errors.New("no challenge issued: request one first")
+	}
+	delete(s.challenges, userID)
+
+	if challenge.Algorithm != algorithm {
+		return nil, errors.New("challenge was issued for " + string(challenge.Algorithm))
+	}
+	if time.Now().After(challenge.ExpiresAt) {
+		return nil, errors.New("challenge expired")
+	}
+
+	return challenge.Nonce, nil
+}
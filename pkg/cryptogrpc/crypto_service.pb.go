@@ -265,18 +265,139 @@ func (x *UserList) GetUsers() []*User {
 	return nil
 }
 
+type GetKeyChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyChallengeRequest) Reset() {
+	*x = GetKeyChallengeRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyChallengeRequest) ProtoMessage() {}
+
+func (x *GetKeyChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetKeyChallengeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetKeyChallengeRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+type GetKeyChallengeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Nonce         []byte                 `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyChallengeResponse) Reset() {
+	*x = GetKeyChallengeResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyChallengeResponse) ProtoMessage() {}
+
+func (x *GetKeyChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyChallengeResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetKeyChallengeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetKeyChallengeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetKeyChallengeResponse) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *GetKeyChallengeResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 type RegisterPublicKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Algorithm     string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
 	KeyData       []byte                 `protobuf:"bytes,3,opt,name=key_data,json=keyData,proto3" json:"key_data,omitempty"`
+	Proof         []byte                 `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterPublicKeyRequest) Reset() {
 	*x = RegisterPublicKeyRequest{}
-	mi := &file_proto_crypto_service_proto_msgTypes[5]
+	mi := &file_proto_crypto_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +409,7 @@ func (x *RegisterPublicKeyRequest) String() string {
 func (*RegisterPublicKeyRequest) ProtoMessage() {}
 
 func (x *RegisterPublicKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[5]
+	mi := &file_proto_crypto_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +422,7 @@ func (x *RegisterPublicKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterPublicKeyRequest.ProtoReflect.Descriptor instead.
 func (*RegisterPublicKeyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{5}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RegisterPublicKeyRequest) GetUserId() string {
@@ -325,6 +446,13 @@ func (x *RegisterPublicKeyRequest) GetKeyData() []byte {
 	return nil
 }
 
+func (x *RegisterPublicKeyRequest) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
 type RegisterPublicKeyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -335,7 +463,7 @@ type RegisterPublicKeyResponse struct {
 
 func (x *RegisterPublicKeyResponse) Reset() {
 	*x = RegisterPublicKeyResponse{}
-	mi := &file_proto_crypto_service_proto_msgTypes[6]
+	mi := &file_proto_crypto_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -347,7 +475,7 @@ func (x *RegisterPublicKeyResponse) String() string {
 func (*RegisterPublicKeyResponse) ProtoMessage() {}
 
 func (x *RegisterPublicKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[6]
+	mi := &file_proto_crypto_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +488,7 @@ func (x *RegisterPublicKeyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterPublicKeyResponse.ProtoReflect.Descriptor instead.
 func (*RegisterPublicKeyResponse) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{6}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RegisterPublicKeyResponse) GetSuccess() bool {
@@ -387,7 +515,7 @@ type GetPublicKeyRequest struct {
 
 func (x *GetPublicKeyRequest) Reset() {
 	*x = GetPublicKeyRequest{}
-	mi := &file_proto_crypto_service_proto_msgTypes[7]
+	mi := &file_proto_crypto_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -399,7 +527,7 @@ func (x *GetPublicKeyRequest) String() string {
 func (*GetPublicKeyRequest) ProtoMessage() {}
 
 func (x *GetPublicKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[7]
+	mi := &file_proto_crypto_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +540,7 @@ func (x *GetPublicKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPublicKeyRequest.ProtoReflect.Descriptor instead.
 func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{7}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPublicKeyRequest) GetUserId() string {
@@ -440,7 +568,7 @@ type GetPublicKeyResponse struct {
 
 func (x *GetPublicKeyResponse) Reset() {
 	*x = GetPublicKeyResponse{}
-	mi := &file_proto_crypto_service_proto_msgTypes[8]
+	mi := &file_proto_crypto_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -452,7 +580,7 @@ func (x *GetPublicKeyResponse) String() string {
 func (*GetPublicKeyResponse) ProtoMessage() {}
 
 func (x *GetPublicKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[8]
+	mi := &file_proto_crypto_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +593,7 @@ func (x *GetPublicKeyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPublicKeyResponse.ProtoReflect.Descriptor instead.
 func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{8}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetPublicKeyResponse) GetSuccess() bool {
@@ -490,18 +618,20 @@ func (x *GetPublicKeyResponse) GetKeyData() []byte {
 }
 
 type SendMessageRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	SenderId         string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	RecipientId      string                 `protobuf:"bytes,2,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
-	EncryptedMessage []byte                 `protobuf:"bytes,3,opt,name=encrypted_message,json=encryptedMessage,proto3" json:"encrypted_message,omitempty"`
-	Algorithm        string                 `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SenderId           string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	RecipientId        string                 `protobuf:"bytes,2,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	EncryptedMessage   []byte                 `protobuf:"bytes,3,opt,name=encrypted_message,json=encryptedMessage,proto3" json:"encrypted_message,omitempty"`
+	Algorithm          string                 `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Signature          []byte                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	SignatureAlgorithm string                 `protobuf:"bytes,6,opt,name=signature_algorithm,json=signatureAlgorithm,proto3" json:"signature_algorithm,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *SendMessageRequest) Reset() {
 	*x = SendMessageRequest{}
-	mi := &file_proto_crypto_service_proto_msgTypes[9]
+	mi := &file_proto_crypto_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -513,7 +643,7 @@ func (x *SendMessageRequest) String() string {
 func (*SendMessageRequest) ProtoMessage() {}
 
 func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[9]
+	mi := &file_proto_crypto_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,7 +656,7 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
 func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{9}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SendMessageRequest) GetSenderId() string {
@@ -557,6 +687,20 @@ func (x *SendMessageRequest) GetAlgorithm() string {
 	return ""
 }
 
+func (x *SendMessageRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetSignatureAlgorithm() string {
+	if x != nil {
+		return x.SignatureAlgorithm
+	}
+	return ""
+}
+
 type SendMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -567,7 +711,7 @@ type SendMessageResponse struct {
 
 func (x *SendMessageResponse) Reset() {
 	*x = SendMessageResponse{}
-	mi := &file_proto_crypto_service_proto_msgTypes[10]
+	mi := &file_proto_crypto_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -579,7 +723,7 @@ func (x *SendMessageResponse) String() string {
 func (*SendMessageResponse) ProtoMessage() {}
 
 func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[10]
+	mi := &file_proto_crypto_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,7 +736,7 @@ func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
 func (*SendMessageResponse) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{10}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SendMessageResponse) GetSuccess() bool {
@@ -610,18 +754,20 @@ func (x *SendMessageResponse) GetMessage() string {
 }
 
 type Message struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	SenderId         string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	EncryptedMessage []byte                 `protobuf:"bytes,2,opt,name=encrypted_message,json=encryptedMessage,proto3" json:"encrypted_message,omitempty"`
-	Algorithm        string                 `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
-	Timestamp        int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SenderId           string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	EncryptedMessage   []byte                 `protobuf:"bytes,2,opt,name=encrypted_message,json=encryptedMessage,proto3" json:"encrypted_message,omitempty"`
+	Algorithm          string                 `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Timestamp          int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Signature          []byte                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	SignatureAlgorithm string                 `protobuf:"bytes,6,opt,name=signature_algorithm,json=signatureAlgorithm,proto3" json:"signature_algorithm,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_proto_crypto_service_proto_msgTypes[11]
+	mi := &file_proto_crypto_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -633,7 +779,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[11]
+	mi := &file_proto_crypto_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,7 +792,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{11}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Message) GetSenderId() string {
@@ -677,6 +823,20 @@ func (x *Message) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *Message) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *Message) GetSignatureAlgorithm() string {
+	if x != nil {
+		return x.SignatureAlgorithm
+	}
+	return ""
+}
+
 type GetMessagesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -686,7 +846,7 @@ type GetMessagesRequest struct {
 
 func (x *GetMessagesRequest) Reset() {
 	*x = GetMessagesRequest{}
-	mi := &file_proto_crypto_service_proto_msgTypes[12]
+	mi := &file_proto_crypto_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -698,7 +858,7 @@ func (x *GetMessagesRequest) String() string {
 func (*GetMessagesRequest) ProtoMessage() {}
 
 func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[12]
+	mi := &file_proto_crypto_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,7 +871,7 @@ func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
 func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{12}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetMessagesRequest) GetUserId() string {
@@ -730,7 +890,7 @@ type GetMessagesResponse struct {
 
 func (x *GetMessagesResponse) Reset() {
 	*x = GetMessagesResponse{}
-	mi := &file_proto_crypto_service_proto_msgTypes[13]
+	mi := &file_proto_crypto_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -742,7 +902,7 @@ func (x *GetMessagesResponse) String() string {
 func (*GetMessagesResponse) ProtoMessage() {}
 
 func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crypto_service_proto_msgTypes[13]
+	mi := &file_proto_crypto_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -755,7 +915,7 @@ func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
 func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_crypto_service_proto_rawDescGZIP(), []int{13}
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetMessagesResponse) GetMessages() []*Message {
@@ -765,62 +925,2230 @@ func (x *GetMessagesResponse) GetMessages() []*Message {
 	return nil
 }
 
-var File_proto_crypto_service_proto protoreflect.FileDescriptor
+type CreatePollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Question      string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	MinVotes      int32                  `protobuf:"varint,4,opt,name=min_votes,json=minVotes,proto3" json:"min_votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_crypto_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1aproto/crypto_service.proto\x12\x06crypto\"\x0e\n" +
-	"\fEmptyRequest\"B\n" +
-	"\x13RegisterUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"J\n" +
-	"\x14RegisterUserResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"K\n" +
-	"\x04User\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
-	"\x06online\x18\x03 \x01(\bR\x06online\".\n" +
-	"\bUserList\x12\"\n" +
-	"\x05users\x18\x01 \x03(\v2\f.crypto.UserR\x05users\"l\n" +
-	"\x18RegisterPublicKeyRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
-	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12\x19\n" +
-	"\bkey_data\x18\x03 \x01(\fR\akeyData\"O\n" +
-	"\x19RegisterPublicKeyResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"L\n" +
-	"\x13GetPublicKeyRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
-	"\talgorithm\x18\x02 \x01(\tR\talgorithm\"e\n" +
-	"\x14GetPublicKeyResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
-	"\bkey_data\x18\x03 \x01(\fR\akeyData\"\x9f\x01\n" +
-	"\x12SendMessageRequest\x12\x1b\n" +
-	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12!\n" +
-	"\frecipient_id\x18\x02 \x01(\tR\vrecipientId\x12+\n" +
-	"\x11encrypted_message\x18\x03 \x01(\fR\x10encryptedMessage\x12\x1c\n" +
-	"\talgorithm\x18\x04 \x01(\tR\talgorithm\"I\n" +
-	"\x13SendMessageResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x8f\x01\n" +
-	"\aMessage\x12\x1b\n" +
-	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12+\n" +
-	"\x11encrypted_message\x18\x02 \x01(\fR\x10encryptedMessage\x12\x1c\n" +
-	"\talgorithm\x18\x03 \x01(\tR\talgorithm\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"-\n" +
-	"\x12GetMessagesRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
-	"\x13GetMessagesResponse\x12+\n" +
-	"\bmessages\x18\x01 \x03(\v2\x0f.crypto.MessageR\bmessages2\xc3\x03\n" +
-	"\rCryptoService\x12I\n" +
-	"\fRegisterUser\x12\x1b.crypto.RegisterUserRequest\x1a\x1c.crypto.RegisterUserResponse\x122\n" +
-	"\bGetUsers\x12\x14.crypto.EmptyRequest\x1a\x10.crypto.UserList\x12X\n" +
-	"\x11RegisterPublicKey\x12 .crypto.RegisterPublicKeyRequest\x1a!.crypto.RegisterPublicKeyResponse\x12I\n" +
-	"\fGetPublicKey\x12\x1b.crypto.GetPublicKeyRequest\x1a\x1c.crypto.GetPublicKeyResponse\x12F\n" +
-	"\vSendMessage\x12\x1a.crypto.SendMessageRequest\x1a\x1b.crypto.SendMessageResponse\x12F\n" +
-	"\vGetMessages\x12\x1a.crypto.GetMessagesRequest\x1a\x1b.crypto.GetMessagesResponseB0Z.github.com/luizgbraga/crypto-go/pkg/cryptogrpcb\x06proto3"
+func (x *CreatePollRequest) Reset() {
+	*x = CreatePollRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePollRequest) ProtoMessage() {}
+
+func (x *CreatePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePollRequest.ProtoReflect.Descriptor instead.
+func (*CreatePollRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreatePollRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *CreatePollRequest) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *CreatePollRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *CreatePollRequest) GetMinVotes() int32 {
+	if x != nil {
+		return x.MinVotes
+	}
+	return 0
+}
+
+type CreatePollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PollId        string                 `protobuf:"bytes,3,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePollResponse) Reset() {
+	*x = CreatePollResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePollResponse) ProtoMessage() {}
+
+func (x *CreatePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePollResponse.ProtoReflect.Descriptor instead.
+func (*CreatePollResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreatePollResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreatePollResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreatePollResponse) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+type Poll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PollId        string                 `protobuf:"bytes,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Question      string                 `protobuf:"bytes,3,opt,name=question,proto3" json:"question,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Votes         int32                  `protobuf:"varint,5,opt,name=votes,proto3" json:"votes,omitempty"`
+	Closed        bool                   `protobuf:"varint,6,opt,name=closed,proto3" json:"closed,omitempty"`
+	MinVotes      int32                  `protobuf:"varint,7,opt,name=min_votes,json=minVotes,proto3" json:"min_votes,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,8,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	mi := &file_proto_crypto_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Poll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Poll) ProtoMessage() {}
+
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Poll) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+func (x *Poll) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Poll) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Poll) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Poll) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+func (x *Poll) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Poll) GetMinVotes() int32 {
+	if x != nil {
+		return x.MinVotes
+	}
+	return 0
+}
+
+func (x *Poll) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type PollList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Polls         []*Poll                `protobuf:"bytes,1,rep,name=polls,proto3" json:"polls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollList) Reset() {
+	*x = PollList{}
+	mi := &file_proto_crypto_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollList) ProtoMessage() {}
+
+func (x *PollList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollList.ProtoReflect.Descriptor instead.
+func (*PollList) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PollList) GetPolls() []*Poll {
+	if x != nil {
+		return x.Polls
+	}
+	return nil
+}
+
+type SubmitVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PollId        string                 `protobuf:"bytes,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	VoterId       string                 `protobuf:"bytes,2,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
+	EncryptedVote []byte                 `protobuf:"bytes,3,opt,name=encrypted_vote,json=encryptedVote,proto3" json:"encrypted_vote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitVoteRequest) Reset() {
+	*x = SubmitVoteRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitVoteRequest) ProtoMessage() {}
+
+func (x *SubmitVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitVoteRequest.ProtoReflect.Descriptor instead.
+func (*SubmitVoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubmitVoteRequest) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+func (x *SubmitVoteRequest) GetVoterId() string {
+	if x != nil {
+		return x.VoterId
+	}
+	return ""
+}
+
+func (x *SubmitVoteRequest) GetEncryptedVote() []byte {
+	if x != nil {
+		return x.EncryptedVote
+	}
+	return nil
+}
+
+type SubmitVoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitVoteResponse) Reset() {
+	*x = SubmitVoteResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitVoteResponse) ProtoMessage() {}
+
+func (x *SubmitVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitVoteResponse.ProtoReflect.Descriptor instead.
+func (*SubmitVoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubmitVoteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubmitVoteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ClosePollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PollId        string                 `protobuf:"bytes,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClosePollRequest) Reset() {
+	*x = ClosePollRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosePollRequest) ProtoMessage() {}
+
+func (x *ClosePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosePollRequest.ProtoReflect.Descriptor instead.
+func (*ClosePollRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ClosePollRequest) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+func (x *ClosePollRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type ClosePollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClosePollResponse) Reset() {
+	*x = ClosePollResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosePollResponse) ProtoMessage() {}
+
+func (x *ClosePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosePollResponse.ProtoReflect.Descriptor instead.
+func (*ClosePollResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ClosePollResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ClosePollResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type TallyPollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PollId        string                 `protobuf:"bytes,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TallyPollRequest) Reset() {
+	*x = TallyPollRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TallyPollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TallyPollRequest) ProtoMessage() {}
+
+func (x *TallyPollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TallyPollRequest.ProtoReflect.Descriptor instead.
+func (*TallyPollRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TallyPollRequest) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+func (x *TallyPollRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type TallyPollResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EncryptedTotal []byte                 `protobuf:"bytes,3,opt,name=encrypted_total,json=encryptedTotal,proto3" json:"encrypted_total,omitempty"`
+	Votes          int32                  `protobuf:"varint,4,opt,name=votes,proto3" json:"votes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TallyPollResponse) Reset() {
+	*x = TallyPollResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TallyPollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TallyPollResponse) ProtoMessage() {}
+
+func (x *TallyPollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TallyPollResponse.ProtoReflect.Descriptor instead.
+func (*TallyPollResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TallyPollResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TallyPollResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TallyPollResponse) GetEncryptedTotal() []byte {
+	if x != nil {
+		return x.EncryptedTotal
+	}
+	return nil
+}
+
+func (x *TallyPollResponse) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+type CreateMailboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatorId     string                 `protobuf:"bytes,1,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	MemberIds     []string               `protobuf:"bytes,2,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
+	Threshold     int32                  `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Group         string                 `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMailboxRequest) Reset() {
+	*x = CreateMailboxRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMailboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMailboxRequest) ProtoMessage() {}
+
+func (x *CreateMailboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMailboxRequest.ProtoReflect.Descriptor instead.
+func (*CreateMailboxRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateMailboxRequest) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *CreateMailboxRequest) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *CreateMailboxRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *CreateMailboxRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type CreateMailboxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MailboxId     string                 `protobuf:"bytes,3,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMailboxResponse) Reset() {
+	*x = CreateMailboxResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMailboxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMailboxResponse) ProtoMessage() {}
+
+func (x *CreateMailboxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMailboxResponse.ProtoReflect.Descriptor instead.
+func (*CreateMailboxResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateMailboxResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateMailboxResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateMailboxResponse) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+type Mailbox struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId       string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	MemberIds       []string               `protobuf:"bytes,2,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
+	Threshold       int32                  `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Group           string                 `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+	DealerIds       []string               `protobuf:"bytes,5,rep,name=dealer_ids,json=dealerIds,proto3" json:"dealer_ids,omitempty"`
+	PublicKey       []byte                 `protobuf:"bytes,6,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	AcknowledgedIds []string               `protobuf:"bytes,7,rep,name=acknowledged_ids,json=acknowledgedIds,proto3" json:"acknowledged_ids,omitempty"`
+	Complaints      []*Complaint           `protobuf:"bytes,8,rep,name=complaints,proto3" json:"complaints,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Mailbox) Reset() {
+	*x = Mailbox{}
+	mi := &file_proto_crypto_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mailbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mailbox) ProtoMessage() {}
+
+func (x *Mailbox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mailbox.ProtoReflect.Descriptor instead.
+func (*Mailbox) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Mailbox) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *Mailbox) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *Mailbox) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Mailbox) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Mailbox) GetDealerIds() []string {
+	if x != nil {
+		return x.DealerIds
+	}
+	return nil
+}
+
+func (x *Mailbox) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *Mailbox) GetAcknowledgedIds() []string {
+	if x != nil {
+		return x.AcknowledgedIds
+	}
+	return nil
+}
+
+func (x *Mailbox) GetComplaints() []*Complaint {
+	if x != nil {
+		return x.Complaints
+	}
+	return nil
+}
+
+type Complaint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DealerId      string                 `protobuf:"bytes,1,opt,name=dealer_id,json=dealerId,proto3" json:"dealer_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Complaint) Reset() {
+	*x = Complaint{}
+	mi := &file_proto_crypto_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Complaint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Complaint) ProtoMessage() {}
+
+func (x *Complaint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Complaint.ProtoReflect.Descriptor instead.
+func (*Complaint) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Complaint) GetDealerId() string {
+	if x != nil {
+		return x.DealerId
+	}
+	return ""
+}
+
+func (x *Complaint) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+type MailboxList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mailboxes     []*Mailbox             `protobuf:"bytes,1,rep,name=mailboxes,proto3" json:"mailboxes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailboxList) Reset() {
+	*x = MailboxList{}
+	mi := &file_proto_crypto_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailboxList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailboxList) ProtoMessage() {}
+
+func (x *MailboxList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailboxList.ProtoReflect.Descriptor instead.
+func (*MailboxList) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MailboxList) GetMailboxes() []*Mailbox {
+	if x != nil {
+		return x.Mailboxes
+	}
+	return nil
+}
+
+type SubmitDealingRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId       string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	DealerId        string                 `protobuf:"bytes,2,opt,name=dealer_id,json=dealerId,proto3" json:"dealer_id,omitempty"`
+	Commitments     [][]byte               `protobuf:"bytes,3,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	EncryptedShares map[string][]byte      `protobuf:"bytes,4,rep,name=encrypted_shares,json=encryptedShares,proto3" json:"encrypted_shares,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DealerCopy      []byte                 `protobuf:"bytes,5,opt,name=dealer_copy,json=dealerCopy,proto3" json:"dealer_copy,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SubmitDealingRequest) Reset() {
+	*x = SubmitDealingRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitDealingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitDealingRequest) ProtoMessage() {}
+
+func (x *SubmitDealingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitDealingRequest.ProtoReflect.Descriptor instead.
+func (*SubmitDealingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SubmitDealingRequest) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *SubmitDealingRequest) GetDealerId() string {
+	if x != nil {
+		return x.DealerId
+	}
+	return ""
+}
+
+func (x *SubmitDealingRequest) GetCommitments() [][]byte {
+	if x != nil {
+		return x.Commitments
+	}
+	return nil
+}
+
+func (x *SubmitDealingRequest) GetEncryptedShares() map[string][]byte {
+	if x != nil {
+		return x.EncryptedShares
+	}
+	return nil
+}
+
+func (x *SubmitDealingRequest) GetDealerCopy() []byte {
+	if x != nil {
+		return x.DealerCopy
+	}
+	return nil
+}
+
+type SubmitDealingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitDealingResponse) Reset() {
+	*x = SubmitDealingResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitDealingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitDealingResponse) ProtoMessage() {}
+
+func (x *SubmitDealingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitDealingResponse.ProtoReflect.Descriptor instead.
+func (*SubmitDealingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SubmitDealingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubmitDealingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetDealingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId     string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDealingsRequest) Reset() {
+	*x = GetDealingsRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDealingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDealingsRequest) ProtoMessage() {}
+
+func (x *GetDealingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDealingsRequest.ProtoReflect.Descriptor instead.
+func (*GetDealingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDealingsRequest) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *GetDealingsRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+type Dealing struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DealerId       string                 `protobuf:"bytes,1,opt,name=dealer_id,json=dealerId,proto3" json:"dealer_id,omitempty"`
+	Commitments    [][]byte               `protobuf:"bytes,2,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	EncryptedShare []byte                 `protobuf:"bytes,3,opt,name=encrypted_share,json=encryptedShare,proto3" json:"encrypted_share,omitempty"`
+	RevealedShare  []byte                 `protobuf:"bytes,4,opt,name=revealed_share,json=revealedShare,proto3" json:"revealed_share,omitempty"`
+	Disputed       bool                   `protobuf:"varint,5,opt,name=disputed,proto3" json:"disputed,omitempty"`
+	DealerCopy     []byte                 `protobuf:"bytes,6,opt,name=dealer_copy,json=dealerCopy,proto3" json:"dealer_copy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Dealing) Reset() {
+	*x = Dealing{}
+	mi := &file_proto_crypto_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dealing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dealing) ProtoMessage() {}
+
+func (x *Dealing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dealing.ProtoReflect.Descriptor instead.
+func (*Dealing) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Dealing) GetDealerId() string {
+	if x != nil {
+		return x.DealerId
+	}
+	return ""
+}
+
+func (x *Dealing) GetCommitments() [][]byte {
+	if x != nil {
+		return x.Commitments
+	}
+	return nil
+}
+
+func (x *Dealing) GetEncryptedShare() []byte {
+	if x != nil {
+		return x.EncryptedShare
+	}
+	return nil
+}
+
+func (x *Dealing) GetRevealedShare() []byte {
+	if x != nil {
+		return x.RevealedShare
+	}
+	return nil
+}
+
+func (x *Dealing) GetDisputed() bool {
+	if x != nil {
+		return x.Disputed
+	}
+	return false
+}
+
+func (x *Dealing) GetDealerCopy() []byte {
+	if x != nil {
+		return x.DealerCopy
+	}
+	return nil
+}
+
+type GetDealingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Dealings      []*Dealing             `protobuf:"bytes,3,rep,name=dealings,proto3" json:"dealings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDealingsResponse) Reset() {
+	*x = GetDealingsResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDealingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDealingsResponse) ProtoMessage() {}
+
+func (x *GetDealingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDealingsResponse.ProtoReflect.Descriptor instead.
+func (*GetDealingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetDealingsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetDealingsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetDealingsResponse) GetDealings() []*Dealing {
+	if x != nil {
+		return x.Dealings
+	}
+	return nil
+}
+
+type CheckDealingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId     string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Complaints    []string               `protobuf:"bytes,3,rep,name=complaints,proto3" json:"complaints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDealingsRequest) Reset() {
+	*x = CheckDealingsRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDealingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDealingsRequest) ProtoMessage() {}
+
+func (x *CheckDealingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDealingsRequest.ProtoReflect.Descriptor instead.
+func (*CheckDealingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CheckDealingsRequest) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *CheckDealingsRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *CheckDealingsRequest) GetComplaints() []string {
+	if x != nil {
+		return x.Complaints
+	}
+	return nil
+}
+
+type CheckDealingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDealingsResponse) Reset() {
+	*x = CheckDealingsResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDealingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDealingsResponse) ProtoMessage() {}
+
+func (x *CheckDealingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDealingsResponse.ProtoReflect.Descriptor instead.
+func (*CheckDealingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CheckDealingsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CheckDealingsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AnswerComplaintRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId     string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	DealerId      string                 `protobuf:"bytes,2,opt,name=dealer_id,json=dealerId,proto3" json:"dealer_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Share         []byte                 `protobuf:"bytes,4,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerComplaintRequest) Reset() {
+	*x = AnswerComplaintRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerComplaintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerComplaintRequest) ProtoMessage() {}
+
+func (x *AnswerComplaintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerComplaintRequest.ProtoReflect.Descriptor instead.
+func (*AnswerComplaintRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AnswerComplaintRequest) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *AnswerComplaintRequest) GetDealerId() string {
+	if x != nil {
+		return x.DealerId
+	}
+	return ""
+}
+
+func (x *AnswerComplaintRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *AnswerComplaintRequest) GetShare() []byte {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type AnswerComplaintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerComplaintResponse) Reset() {
+	*x = AnswerComplaintResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerComplaintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerComplaintResponse) ProtoMessage() {}
+
+func (x *AnswerComplaintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerComplaintResponse.ProtoReflect.Descriptor instead.
+func (*AnswerComplaintResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AnswerComplaintResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AnswerComplaintResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SendMailboxMessageRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId        string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	SenderId         string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	EncryptedMessage []byte                 `protobuf:"bytes,3,opt,name=encrypted_message,json=encryptedMessage,proto3" json:"encrypted_message,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SendMailboxMessageRequest) Reset() {
+	*x = SendMailboxMessageRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMailboxMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMailboxMessageRequest) ProtoMessage() {}
+
+func (x *SendMailboxMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMailboxMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMailboxMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SendMailboxMessageRequest) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *SendMailboxMessageRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *SendMailboxMessageRequest) GetEncryptedMessage() []byte {
+	if x != nil {
+		return x.EncryptedMessage
+	}
+	return nil
+}
+
+type SendMailboxMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMailboxMessageResponse) Reset() {
+	*x = SendMailboxMessageResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMailboxMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMailboxMessageResponse) ProtoMessage() {}
+
+func (x *SendMailboxMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMailboxMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMailboxMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SendMailboxMessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SendMailboxMessageResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PartialDecryption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MemberId      string                 `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Partial       []byte                 `protobuf:"bytes,2,opt,name=partial,proto3" json:"partial,omitempty"`
+	Proof         []byte                 `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartialDecryption) Reset() {
+	*x = PartialDecryption{}
+	mi := &file_proto_crypto_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartialDecryption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartialDecryption) ProtoMessage() {}
+
+func (x *PartialDecryption) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartialDecryption.ProtoReflect.Descriptor instead.
+func (*PartialDecryption) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PartialDecryption) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *PartialDecryption) GetPartial() []byte {
+	if x != nil {
+		return x.Partial
+	}
+	return nil
+}
+
+func (x *PartialDecryption) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type MailboxMessage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MessageId        int32                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	SenderId         string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	EncryptedMessage []byte                 `protobuf:"bytes,3,opt,name=encrypted_message,json=encryptedMessage,proto3" json:"encrypted_message,omitempty"`
+	Timestamp        int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Partials         []*PartialDecryption   `protobuf:"bytes,5,rep,name=partials,proto3" json:"partials,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MailboxMessage) Reset() {
+	*x = MailboxMessage{}
+	mi := &file_proto_crypto_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailboxMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailboxMessage) ProtoMessage() {}
+
+func (x *MailboxMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailboxMessage.ProtoReflect.Descriptor instead.
+func (*MailboxMessage) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MailboxMessage) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MailboxMessage) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *MailboxMessage) GetEncryptedMessage() []byte {
+	if x != nil {
+		return x.EncryptedMessage
+	}
+	return nil
+}
+
+func (x *MailboxMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MailboxMessage) GetPartials() []*PartialDecryption {
+	if x != nil {
+		return x.Partials
+	}
+	return nil
+}
+
+type GetMailboxMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId     string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMailboxMessagesRequest) Reset() {
+	*x = GetMailboxMessagesRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMailboxMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMailboxMessagesRequest) ProtoMessage() {}
+
+func (x *GetMailboxMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMailboxMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetMailboxMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetMailboxMessagesRequest) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *GetMailboxMessagesRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+type GetMailboxMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Messages      []*MailboxMessage      `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMailboxMessagesResponse) Reset() {
+	*x = GetMailboxMessagesResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMailboxMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMailboxMessagesResponse) ProtoMessage() {}
+
+func (x *GetMailboxMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMailboxMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetMailboxMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetMailboxMessagesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetMailboxMessagesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetMailboxMessagesResponse) GetMessages() []*MailboxMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type SubmitPartialDecryptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailboxId     string                 `protobuf:"bytes,1,opt,name=mailbox_id,json=mailboxId,proto3" json:"mailbox_id,omitempty"`
+	MessageId     int32                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Partial       []byte                 `protobuf:"bytes,4,opt,name=partial,proto3" json:"partial,omitempty"`
+	Proof         []byte                 `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitPartialDecryptionRequest) Reset() {
+	*x = SubmitPartialDecryptionRequest{}
+	mi := &file_proto_crypto_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitPartialDecryptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPartialDecryptionRequest) ProtoMessage() {}
+
+func (x *SubmitPartialDecryptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPartialDecryptionRequest.ProtoReflect.Descriptor instead.
+func (*SubmitPartialDecryptionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SubmitPartialDecryptionRequest) GetMailboxId() string {
+	if x != nil {
+		return x.MailboxId
+	}
+	return ""
+}
+
+func (x *SubmitPartialDecryptionRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *SubmitPartialDecryptionRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *SubmitPartialDecryptionRequest) GetPartial() []byte {
+	if x != nil {
+		return x.Partial
+	}
+	return nil
+}
+
+func (x *SubmitPartialDecryptionRequest) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type SubmitPartialDecryptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitPartialDecryptionResponse) Reset() {
+	*x = SubmitPartialDecryptionResponse{}
+	mi := &file_proto_crypto_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitPartialDecryptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPartialDecryptionResponse) ProtoMessage() {}
+
+func (x *SubmitPartialDecryptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crypto_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPartialDecryptionResponse.ProtoReflect.Descriptor instead.
+func (*SubmitPartialDecryptionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crypto_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SubmitPartialDecryptionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubmitPartialDecryptionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_proto_crypto_service_proto protoreflect.FileDescriptor
+
+const file_proto_crypto_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/crypto_service.proto\x12\x06crypto\"\x0e\n" +
+	"\fEmptyRequest\"B\n" +
+	"\x13RegisterUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"J\n" +
+	"\x14RegisterUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"K\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06online\x18\x03 \x01(\bR\x06online\".\n" +
+	"\bUserList\x12\"\n" +
+	"\x05users\x18\x01 \x03(\v2\f.crypto.UserR\x05users\"O\n" +
+	"\x16GetKeyChallengeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\"\x82\x01\n" +
+	"\x17GetKeyChallengeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\fR\x05nonce\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"\x82\x01\n" +
+	"\x18RegisterPublicKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12\x19\n" +
+	"\bkey_data\x18\x03 \x01(\fR\akeyData\x12\x14\n" +
+	"\x05proof\x18\x04 \x01(\fR\x05proof\"O\n" +
+	"\x19RegisterPublicKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"L\n" +
+	"\x13GetPublicKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\"e\n" +
+	"\x14GetPublicKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bkey_data\x18\x03 \x01(\fR\akeyData\"\xee\x01\n" +
+	"\x12SendMessageRequest\x12\x1b\n" +
+	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12!\n" +
+	"\frecipient_id\x18\x02 \x01(\tR\vrecipientId\x12+\n" +
+	"\x11encrypted_message\x18\x03 \x01(\fR\x10encryptedMessage\x12\x1c\n" +
+	"\talgorithm\x18\x04 \x01(\tR\talgorithm\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignature\x12/\n" +
+	"\x13signature_algorithm\x18\x06 \x01(\tR\x12signatureAlgorithm\"I\n" +
+	"\x13SendMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xde\x01\n" +
+	"\aMessage\x12\x1b\n" +
+	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12+\n" +
+	"\x11encrypted_message\x18\x02 \x01(\fR\x10encryptedMessage\x12\x1c\n" +
+	"\talgorithm\x18\x03 \x01(\tR\talgorithm\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignature\x12/\n" +
+	"\x13signature_algorithm\x18\x06 \x01(\tR\x12signatureAlgorithm\"-\n" +
+	"\x12GetMessagesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
+	"\x13GetMessagesResponse\x12+\n" +
+	"\bmessages\x18\x01 \x03(\v2\x0f.crypto.MessageR\bmessages\"\x85\x01\n" +
+	"\x11CreatePollRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x1a\n" +
+	"\bquestion\x18\x02 \x01(\tR\bquestion\x12\x1c\n" +
+	"\talgorithm\x18\x03 \x01(\tR\talgorithm\x12\x1b\n" +
+	"\tmin_votes\x18\x04 \x01(\x05R\bminVotes\"a\n" +
+	"\x12CreatePollResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\apoll_id\x18\x03 \x01(\tR\x06pollId\"\xde\x01\n" +
+	"\x04Poll\x12\x17\n" +
+	"\apoll_id\x18\x01 \x01(\tR\x06pollId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x1a\n" +
+	"\bquestion\x18\x03 \x01(\tR\bquestion\x12\x1c\n" +
+	"\talgorithm\x18\x04 \x01(\tR\talgorithm\x12\x14\n" +
+	"\x05votes\x18\x05 \x01(\x05R\x05votes\x12\x16\n" +
+	"\x06closed\x18\x06 \x01(\bR\x06closed\x12\x1b\n" +
+	"\tmin_votes\x18\a \x01(\x05R\bminVotes\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\b \x01(\fR\tpublicKey\".\n" +
+	"\bPollList\x12\"\n" +
+	"\x05polls\x18\x01 \x03(\v2\f.crypto.PollR\x05polls\"n\n" +
+	"\x11SubmitVoteRequest\x12\x17\n" +
+	"\apoll_id\x18\x01 \x01(\tR\x06pollId\x12\x19\n" +
+	"\bvoter_id\x18\x02 \x01(\tR\avoterId\x12%\n" +
+	"\x0eencrypted_vote\x18\x03 \x01(\fR\rencryptedVote\"H\n" +
+	"\x12SubmitVoteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"F\n" +
+	"\x10ClosePollRequest\x12\x17\n" +
+	"\apoll_id\x18\x01 \x01(\tR\x06pollId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\"G\n" +
+	"\x11ClosePollResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"F\n" +
+	"\x10TallyPollRequest\x12\x17\n" +
+	"\apoll_id\x18\x01 \x01(\tR\x06pollId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\"\x86\x01\n" +
+	"\x11TallyPollResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
+	"\x0fencrypted_total\x18\x03 \x01(\fR\x0eencryptedTotal\x12\x14\n" +
+	"\x05votes\x18\x04 \x01(\x05R\x05votes\"\x88\x01\n" +
+	"\x14CreateMailboxRequest\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x01 \x01(\tR\tcreatorId\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x02 \x03(\tR\tmemberIds\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\x05R\tthreshold\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\"j\n" +
+	"\x15CreateMailboxResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x03 \x01(\tR\tmailboxId\"\x97\x02\n" +
+	"\aMailbox\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x02 \x03(\tR\tmemberIds\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\x05R\tthreshold\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\x12\x1d\n" +
+	"\n" +
+	"dealer_ids\x18\x05 \x03(\tR\tdealerIds\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x06 \x01(\fR\tpublicKey\x12)\n" +
+	"\x10acknowledged_ids\x18\a \x03(\tR\x0facknowledgedIds\x121\n" +
+	"\n" +
+	"complaints\x18\b \x03(\v2\x11.crypto.ComplaintR\n" +
+	"complaints\"E\n" +
+	"\tComplaint\x12\x1b\n" +
+	"\tdealer_id\x18\x01 \x01(\tR\bdealerId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\"<\n" +
+	"\vMailboxList\x12-\n" +
+	"\tmailboxes\x18\x01 \x03(\v2\x0f.crypto.MailboxR\tmailboxes\"\xb7\x02\n" +
+	"\x14SubmitDealingRequest\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1b\n" +
+	"\tdealer_id\x18\x02 \x01(\tR\bdealerId\x12 \n" +
+	"\vcommitments\x18\x03 \x03(\fR\vcommitments\x12\\\n" +
+	"\x10encrypted_shares\x18\x04 \x03(\v21.crypto.SubmitDealingRequest.EncryptedSharesEntryR\x0fencryptedShares\x12\x1f\n" +
+	"\vdealer_copy\x18\x05 \x01(\fR\n" +
+	"dealerCopy\x1aB\n" +
+	"\x14EncryptedSharesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"K\n" +
+	"\x15SubmitDealingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"P\n" +
+	"\x12GetDealingsRequest\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\"\xd5\x01\n" +
+	"\aDealing\x12\x1b\n" +
+	"\tdealer_id\x18\x01 \x01(\tR\bdealerId\x12 \n" +
+	"\vcommitments\x18\x02 \x03(\fR\vcommitments\x12'\n" +
+	"\x0fencrypted_share\x18\x03 \x01(\fR\x0eencryptedShare\x12%\n" +
+	"\x0erevealed_share\x18\x04 \x01(\fR\rrevealedShare\x12\x1a\n" +
+	"\bdisputed\x18\x05 \x01(\bR\bdisputed\x12\x1f\n" +
+	"\vdealer_copy\x18\x06 \x01(\fR\n" +
+	"dealerCopy\"v\n" +
+	"\x13GetDealingsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\bdealings\x18\x03 \x03(\v2\x0f.crypto.DealingR\bdealings\"r\n" +
+	"\x14CheckDealingsRequest\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\x12\x1e\n" +
+	"\n" +
+	"complaints\x18\x03 \x03(\tR\n" +
+	"complaints\"K\n" +
+	"\x15CheckDealingsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x87\x01\n" +
+	"\x16AnswerComplaintRequest\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1b\n" +
+	"\tdealer_id\x18\x02 \x01(\tR\bdealerId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\tR\bmemberId\x12\x14\n" +
+	"\x05share\x18\x04 \x01(\fR\x05share\"M\n" +
+	"\x17AnswerComplaintResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x84\x01\n" +
+	"\x19SendMailboxMessageRequest\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12+\n" +
+	"\x11encrypted_message\x18\x03 \x01(\fR\x10encryptedMessage\"P\n" +
+	"\x1aSendMailboxMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"`\n" +
+	"\x11PartialDecryption\x12\x1b\n" +
+	"\tmember_id\x18\x01 \x01(\tR\bmemberId\x12\x18\n" +
+	"\apartial\x18\x02 \x01(\fR\apartial\x12\x14\n" +
+	"\x05proof\x18\x03 \x01(\fR\x05proof\"\xce\x01\n" +
+	"\x0eMailboxMessage\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x05R\tmessageId\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12+\n" +
+	"\x11encrypted_message\x18\x03 \x01(\fR\x10encryptedMessage\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x125\n" +
+	"\bpartials\x18\x05 \x03(\v2\x19.crypto.PartialDecryptionR\bpartials\"W\n" +
+	"\x19GetMailboxMessagesRequest\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\"\x84\x01\n" +
+	"\x1aGetMailboxMessagesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\bmessages\x18\x03 \x03(\v2\x16.crypto.MailboxMessageR\bmessages\"\xab\x01\n" +
+	"\x1eSubmitPartialDecryptionRequest\x12\x1d\n" +
+	"\n" +
+	"mailbox_id\x18\x01 \x01(\tR\tmailboxId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x05R\tmessageId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\tR\bmemberId\x12\x18\n" +
+	"\apartial\x18\x04 \x01(\fR\apartial\x12\x14\n" +
+	"\x05proof\x18\x05 \x01(\fR\x05proof\"U\n" +
+	"\x1fSubmitPartialDecryptionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xc0\f\n" +
+	"\rCryptoService\x12I\n" +
+	"\fRegisterUser\x12\x1b.crypto.RegisterUserRequest\x1a\x1c.crypto.RegisterUserResponse\x122\n" +
+	"\bGetUsers\x12\x14.crypto.EmptyRequest\x1a\x10.crypto.UserList\x12R\n" +
+	"\x0fGetKeyChallenge\x12\x1e.crypto.GetKeyChallengeRequest\x1a\x1f.crypto.GetKeyChallengeResponse\x12X\n" +
+	"\x11RegisterPublicKey\x12 .crypto.RegisterPublicKeyRequest\x1a!.crypto.RegisterPublicKeyResponse\x12I\n" +
+	"\fGetPublicKey\x12\x1b.crypto.GetPublicKeyRequest\x1a\x1c.crypto.GetPublicKeyResponse\x12F\n" +
+	"\vSendMessage\x12\x1a.crypto.SendMessageRequest\x1a\x1b.crypto.SendMessageResponse\x12F\n" +
+	"\vGetMessages\x12\x1a.crypto.GetMessagesRequest\x1a\x1b.crypto.GetMessagesResponse\x12C\n" +
+	"\n" +
+	"CreatePoll\x12\x19.crypto.CreatePollRequest\x1a\x1a.crypto.CreatePollResponse\x122\n" +
+	"\bGetPolls\x12\x14.crypto.EmptyRequest\x1a\x10.crypto.PollList\x12C\n" +
+	"\n" +
+	"SubmitVote\x12\x19.crypto.SubmitVoteRequest\x1a\x1a.crypto.SubmitVoteResponse\x12@\n" +
+	"\tClosePoll\x12\x18.crypto.ClosePollRequest\x1a\x19.crypto.ClosePollResponse\x12@\n" +
+	"\tTallyPoll\x12\x18.crypto.TallyPollRequest\x1a\x19.crypto.TallyPollResponse\x12L\n" +
+	"\rCreateMailbox\x12\x1c.crypto.CreateMailboxRequest\x1a\x1d.crypto.CreateMailboxResponse\x129\n" +
+	"\fGetMailboxes\x12\x14.crypto.EmptyRequest\x1a\x13.crypto.MailboxList\x12L\n" +
+	"\rSubmitDealing\x12\x1c.crypto.SubmitDealingRequest\x1a\x1d.crypto.SubmitDealingResponse\x12F\n" +
+	"\vGetDealings\x12\x1a.crypto.GetDealingsRequest\x1a\x1b.crypto.GetDealingsResponse\x12L\n" +
+	"\rCheckDealings\x12\x1c.crypto.CheckDealingsRequest\x1a\x1d.crypto.CheckDealingsResponse\x12R\n" +
+	"\x0fAnswerComplaint\x12\x1e.crypto.AnswerComplaintRequest\x1a\x1f.crypto.AnswerComplaintResponse\x12[\n" +
+	"\x12SendMailboxMessage\x12!.crypto.SendMailboxMessageRequest\x1a\".crypto.SendMailboxMessageResponse\x12[\n" +
+	"\x12GetMailboxMessages\x12!.crypto.GetMailboxMessagesRequest\x1a\".crypto.GetMailboxMessagesResponse\x12j\n" +
+	"\x17SubmitPartialDecryption\x12&.crypto.SubmitPartialDecryptionRequest\x1a'.crypto.SubmitPartialDecryptionResponseB0Z.github.com/luizgbraga/crypto-go/pkg/cryptogrpcb\x06proto3"
 
 var (
 	file_proto_crypto_service_proto_rawDescOnce sync.Once
@@ -834,43 +3162,115 @@ func file_proto_crypto_service_proto_rawDescGZIP() []byte {
 	return file_proto_crypto_service_proto_rawDescData
 }
 
-var file_proto_crypto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_crypto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_proto_crypto_service_proto_goTypes = []any{
-	(*EmptyRequest)(nil),              // 0: crypto.EmptyRequest
-	(*RegisterUserRequest)(nil),       // 1: crypto.RegisterUserRequest
-	(*RegisterUserResponse)(nil),      // 2: crypto.RegisterUserResponse
-	(*User)(nil),                      // 3: crypto.User
-	(*UserList)(nil),                  // 4: crypto.UserList
-	(*RegisterPublicKeyRequest)(nil),  // 5: crypto.RegisterPublicKeyRequest
-	(*RegisterPublicKeyResponse)(nil), // 6: crypto.RegisterPublicKeyResponse
-	(*GetPublicKeyRequest)(nil),       // 7: crypto.GetPublicKeyRequest
-	(*GetPublicKeyResponse)(nil),      // 8: crypto.GetPublicKeyResponse
-	(*SendMessageRequest)(nil),        // 9: crypto.SendMessageRequest
-	(*SendMessageResponse)(nil),       // 10: crypto.SendMessageResponse
-	(*Message)(nil),                   // 11: crypto.Message
-	(*GetMessagesRequest)(nil),        // 12: crypto.GetMessagesRequest
-	(*GetMessagesResponse)(nil),       // 13: crypto.GetMessagesResponse
+	(*EmptyRequest)(nil),                    // 0: crypto.EmptyRequest
+	(*RegisterUserRequest)(nil),             // 1: crypto.RegisterUserRequest
+	(*RegisterUserResponse)(nil),            // 2: crypto.RegisterUserResponse
+	(*User)(nil),                            // 3: crypto.User
+	(*UserList)(nil),                        // 4: crypto.UserList
+	(*GetKeyChallengeRequest)(nil),          // 5: crypto.GetKeyChallengeRequest
+	(*GetKeyChallengeResponse)(nil),         // 6: crypto.GetKeyChallengeResponse
+	(*RegisterPublicKeyRequest)(nil),        // 7: crypto.RegisterPublicKeyRequest
+	(*RegisterPublicKeyResponse)(nil),       // 8: crypto.RegisterPublicKeyResponse
+	(*GetPublicKeyRequest)(nil),             // 9: crypto.GetPublicKeyRequest
+	(*GetPublicKeyResponse)(nil),            // 10: crypto.GetPublicKeyResponse
+	(*SendMessageRequest)(nil),              // 11: crypto.SendMessageRequest
+	(*SendMessageResponse)(nil),             // 12: crypto.SendMessageResponse
+	(*Message)(nil),                         // 13: crypto.Message
+	(*GetMessagesRequest)(nil),              // 14: crypto.GetMessagesRequest
+	(*GetMessagesResponse)(nil),             // 15: crypto.GetMessagesResponse
+	(*CreatePollRequest)(nil),               // 16: crypto.CreatePollRequest
+	(*CreatePollResponse)(nil),              // 17: crypto.CreatePollResponse
+	(*Poll)(nil),                            // 18: crypto.Poll
+	(*PollList)(nil),                        // 19: crypto.PollList
+	(*SubmitVoteRequest)(nil),               // 20: crypto.SubmitVoteRequest
+	(*SubmitVoteResponse)(nil),              // 21: crypto.SubmitVoteResponse
+	(*ClosePollRequest)(nil),                // 22: crypto.ClosePollRequest
+	(*ClosePollResponse)(nil),               // 23: crypto.ClosePollResponse
+	(*TallyPollRequest)(nil),                // 24: crypto.TallyPollRequest
+	(*TallyPollResponse)(nil),               // 25: crypto.TallyPollResponse
+	(*CreateMailboxRequest)(nil),            // 26: crypto.CreateMailboxRequest
+	(*CreateMailboxResponse)(nil),           // 27: crypto.CreateMailboxResponse
+	(*Mailbox)(nil),                         // 28: crypto.Mailbox
+	(*Complaint)(nil),                       // 29: crypto.Complaint
+	(*MailboxList)(nil),                     // 30: crypto.MailboxList
+	(*SubmitDealingRequest)(nil),            // 31: crypto.SubmitDealingRequest
+	(*SubmitDealingResponse)(nil),           // 32: crypto.SubmitDealingResponse
+	(*GetDealingsRequest)(nil),              // 33: crypto.GetDealingsRequest
+	(*Dealing)(nil),                         // 34: crypto.Dealing
+	(*GetDealingsResponse)(nil),             // 35: crypto.GetDealingsResponse
+	(*CheckDealingsRequest)(nil),            // 36: crypto.CheckDealingsRequest
+	(*CheckDealingsResponse)(nil),           // 37: crypto.CheckDealingsResponse
+	(*AnswerComplaintRequest)(nil),          // 38: crypto.AnswerComplaintRequest
+	(*AnswerComplaintResponse)(nil),         // 39: crypto.AnswerComplaintResponse
+	(*SendMailboxMessageRequest)(nil),       // 40: crypto.SendMailboxMessageRequest
+	(*SendMailboxMessageResponse)(nil),      // 41: crypto.SendMailboxMessageResponse
+	(*PartialDecryption)(nil),               // 42: crypto.PartialDecryption
+	(*MailboxMessage)(nil),                  // 43: crypto.MailboxMessage
+	(*GetMailboxMessagesRequest)(nil),       // 44: crypto.GetMailboxMessagesRequest
+	(*GetMailboxMessagesResponse)(nil),      // 45: crypto.GetMailboxMessagesResponse
+	(*SubmitPartialDecryptionRequest)(nil),  // 46: crypto.SubmitPartialDecryptionRequest
+	(*SubmitPartialDecryptionResponse)(nil), // 47: crypto.SubmitPartialDecryptionResponse
+	nil,                                     // 48: crypto.SubmitDealingRequest.EncryptedSharesEntry
 }
 var file_proto_crypto_service_proto_depIdxs = []int32{
 	3,  // 0: crypto.UserList.users:type_name -> crypto.User
-	11, // 1: crypto.GetMessagesResponse.messages:type_name -> crypto.Message
-	1,  // 2: crypto.CryptoService.RegisterUser:input_type -> crypto.RegisterUserRequest
-	0,  // 3: crypto.CryptoService.GetUsers:input_type -> crypto.EmptyRequest
-	5,  // 4: crypto.CryptoService.RegisterPublicKey:input_type -> crypto.RegisterPublicKeyRequest
-	7,  // 5: crypto.CryptoService.GetPublicKey:input_type -> crypto.GetPublicKeyRequest
-	9,  // 6: crypto.CryptoService.SendMessage:input_type -> crypto.SendMessageRequest
-	12, // 7: crypto.CryptoService.GetMessages:input_type -> crypto.GetMessagesRequest
-	2,  // 8: crypto.CryptoService.RegisterUser:output_type -> crypto.RegisterUserResponse
-	4,  // 9: crypto.CryptoService.GetUsers:output_type -> crypto.UserList
-	6,  // 10: crypto.CryptoService.RegisterPublicKey:output_type -> crypto.RegisterPublicKeyResponse
-	8,  // 11: crypto.CryptoService.GetPublicKey:output_type -> crypto.GetPublicKeyResponse
-	10, // 12: crypto.CryptoService.SendMessage:output_type -> crypto.SendMessageResponse
-	13, // 13: crypto.CryptoService.GetMessages:output_type -> crypto.GetMessagesResponse
-	8,  // [8:14] is the sub-list for method output_type
-	2,  // [2:8] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	13, // 1: crypto.GetMessagesResponse.messages:type_name -> crypto.Message
+	18, // 2: crypto.PollList.polls:type_name -> crypto.Poll
+	29, // 3: crypto.Mailbox.complaints:type_name -> crypto.Complaint
+	28, // 4: crypto.MailboxList.mailboxes:type_name -> crypto.Mailbox
+	48, // 5: crypto.SubmitDealingRequest.encrypted_shares:type_name -> crypto.SubmitDealingRequest.EncryptedSharesEntry
+	34, // 6: crypto.GetDealingsResponse.dealings:type_name -> crypto.Dealing
+	42, // 7: crypto.MailboxMessage.partials:type_name -> crypto.PartialDecryption
+	43, // 8: crypto.GetMailboxMessagesResponse.messages:type_name -> crypto.MailboxMessage
+	1,  // 9: crypto.CryptoService.RegisterUser:input_type -> crypto.RegisterUserRequest
+	0,  // 10: crypto.CryptoService.GetUsers:input_type -> crypto.EmptyRequest
+	5,  // 11: crypto.CryptoService.GetKeyChallenge:input_type -> crypto.GetKeyChallengeRequest
+	7,  // 12: crypto.CryptoService.RegisterPublicKey:input_type -> crypto.RegisterPublicKeyRequest
+	9,  // 13: crypto.CryptoService.GetPublicKey:input_type -> crypto.GetPublicKeyRequest
+	11, // 14: crypto.CryptoService.SendMessage:input_type -> crypto.SendMessageRequest
+	14, // 15: crypto.CryptoService.GetMessages:input_type -> crypto.GetMessagesRequest
+	16, // 16: crypto.CryptoService.CreatePoll:input_type -> crypto.CreatePollRequest
+	0,  // 17: crypto.CryptoService.GetPolls:input_type -> crypto.EmptyRequest
+	20, // 18: crypto.CryptoService.SubmitVote:input_type -> crypto.SubmitVoteRequest
+	22, // 19: crypto.CryptoService.ClosePoll:input_type -> crypto.ClosePollRequest
+	24, // 20: crypto.CryptoService.TallyPoll:input_type -> crypto.TallyPollRequest
+	26, // 21: crypto.CryptoService.CreateMailbox:input_type -> crypto.CreateMailboxRequest
+	0,  // 22: crypto.CryptoService.GetMailboxes:input_type -> crypto.EmptyRequest
+	31, // 23: crypto.CryptoService.SubmitDealing:input_type -> crypto.SubmitDealingRequest
+	33, // 24: crypto.CryptoService.GetDealings:input_type -> crypto.GetDealingsRequest
+	36, // 25: crypto.CryptoService.CheckDealings:input_type -> crypto.CheckDealingsRequest
+	38, // 26: crypto.CryptoService.AnswerComplaint:input_type -> crypto.AnswerComplaintRequest
+	40, // 27: crypto.CryptoService.SendMailboxMessage:input_type -> crypto.SendMailboxMessageRequest
+	44, // 28: crypto.CryptoService.GetMailboxMessages:input_type -> crypto.GetMailboxMessagesRequest
+	46, // 29: crypto.CryptoService.SubmitPartialDecryption:input_type -> crypto.SubmitPartialDecryptionRequest
+	2,  // 30: crypto.CryptoService.RegisterUser:output_type -> crypto.RegisterUserResponse
+	4,  // 31: crypto.CryptoService.GetUsers:output_type -> crypto.UserList
+	6,  // 32: crypto.CryptoService.GetKeyChallenge:output_type -> crypto.GetKeyChallengeResponse
+	8,  // 33: crypto.CryptoService.RegisterPublicKey:output_type -> crypto.RegisterPublicKeyResponse
+	10, // 34: crypto.CryptoService.GetPublicKey:output_type -> crypto.GetPublicKeyResponse
+	12, // 35: crypto.CryptoService.SendMessage:output_type -> crypto.SendMessageResponse
+	15, // 36: crypto.CryptoService.GetMessages:output_type -> crypto.GetMessagesResponse
+	17, // 37: crypto.CryptoService.CreatePoll:output_type -> crypto.CreatePollResponse
+	19, // 38: crypto.CryptoService.GetPolls:output_type -> crypto.PollList
+	21, // 39: crypto.CryptoService.SubmitVote:output_type -> crypto.SubmitVoteResponse
+	23, // 40: crypto.CryptoService.ClosePoll:output_type -> crypto.ClosePollResponse
+	25, // 41: crypto.CryptoService.TallyPoll:output_type -> crypto.TallyPollResponse
+	27, // 42: crypto.CryptoService.CreateMailbox:output_type -> crypto.CreateMailboxResponse
+	30, // 43: crypto.CryptoService.GetMailboxes:output_type -> crypto.MailboxList
+	32, // 44: crypto.CryptoService.SubmitDealing:output_type -> crypto.SubmitDealingResponse
+	35, // 45: crypto.CryptoService.GetDealings:output_type -> crypto.GetDealingsResponse
+	37, // 46: crypto.CryptoService.CheckDealings:output_type -> crypto.CheckDealingsResponse
+	39, // 47: crypto.CryptoService.AnswerComplaint:output_type -> crypto.AnswerComplaintResponse
+	41, // 48: crypto.CryptoService.SendMailboxMessage:output_type -> crypto.SendMailboxMessageResponse
+	45, // 49: crypto.CryptoService.GetMailboxMessages:output_type -> crypto.GetMailboxMessagesResponse
+	47, // 50: crypto.CryptoService.SubmitPartialDecryption:output_type -> crypto.SubmitPartialDecryptionResponse
+	30, // [30:51] is the sub-list for method output_type
+	9,  // [9:30] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_crypto_service_proto_init() }
@@ -884,7 +3284,7 @@ func file_proto_crypto_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_crypto_service_proto_rawDesc), len(file_proto_crypto_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CryptoService_RegisterUser_FullMethodName      = "/crypto.CryptoService/RegisterUser"
-	CryptoService_GetUsers_FullMethodName          = "/crypto.CryptoService/GetUsers"
-	CryptoService_RegisterPublicKey_FullMethodName = "/crypto.CryptoService/RegisterPublicKey"
-	CryptoService_GetPublicKey_FullMethodName      = "/crypto.CryptoService/GetPublicKey"
-	CryptoService_SendMessage_FullMethodName       = "/crypto.CryptoService/SendMessage"
-	CryptoService_GetMessages_FullMethodName       = "/crypto.CryptoService/GetMessages"
+	CryptoService_RegisterUser_FullMethodName            = "/crypto.CryptoService/RegisterUser"
+	CryptoService_GetUsers_FullMethodName                = "/crypto.CryptoService/GetUsers"
+	CryptoService_GetKeyChallenge_FullMethodName         = "/crypto.CryptoService/GetKeyChallenge"
+	CryptoService_RegisterPublicKey_FullMethodName       = "/crypto.CryptoService/RegisterPublicKey"
+	CryptoService_GetPublicKey_FullMethodName            = "/crypto.CryptoService/GetPublicKey"
+	CryptoService_SendMessage_FullMethodName             = "/crypto.CryptoService/SendMessage"
+	CryptoService_GetMessages_FullMethodName             = "/crypto.CryptoService/GetMessages"
+	CryptoService_CreatePoll_FullMethodName              = "/crypto.CryptoService/CreatePoll"
+	CryptoService_GetPolls_FullMethodName                = "/crypto.CryptoService/GetPolls"
+	CryptoService_SubmitVote_FullMethodName              = "/crypto.CryptoService/SubmitVote"
+	CryptoService_ClosePoll_FullMethodName               = "/crypto.CryptoService/ClosePoll"
+	CryptoService_TallyPoll_FullMethodName               = "/crypto.CryptoService/TallyPoll"
+	CryptoService_CreateMailbox_FullMethodName           = "/crypto.CryptoService/CreateMailbox"
+	CryptoService_GetMailboxes_FullMethodName            = "/crypto.CryptoService/GetMailboxes"
+	CryptoService_SubmitDealing_FullMethodName           = "/crypto.CryptoService/SubmitDealing"
+	CryptoService_GetDealings_FullMethodName             = "/crypto.CryptoService/GetDealings"
+	CryptoService_CheckDealings_FullMethodName           = "/crypto.CryptoService/CheckDealings"
+	CryptoService_AnswerComplaint_FullMethodName         = "/crypto.CryptoService/AnswerComplaint"
+	CryptoService_SendMailboxMessage_FullMethodName      = "/crypto.CryptoService/SendMailboxMessage"
+	CryptoService_GetMailboxMessages_FullMethodName      = "/crypto.CryptoService/GetMailboxMessages"
+	CryptoService_SubmitPartialDecryption_FullMethodName = "/crypto.CryptoService/SubmitPartialDecryption"
 )
 
 // CryptoServiceClient is the client API for CryptoService service.
@@ -33,10 +48,25 @@ const (
 type CryptoServiceClient interface {
 	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*RegisterUserResponse, error)
 	GetUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*UserList, error)
+	GetKeyChallenge(ctx context.Context, in *GetKeyChallengeRequest, opts ...grpc.CallOption) (*GetKeyChallengeResponse, error)
 	RegisterPublicKey(ctx context.Context, in *RegisterPublicKeyRequest, opts ...grpc.CallOption) (*RegisterPublicKeyResponse, error)
 	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
 	GetMessages(ctx context.Context, in *GetMessagesRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error)
+	CreatePoll(ctx context.Context, in *CreatePollRequest, opts ...grpc.CallOption) (*CreatePollResponse, error)
+	GetPolls(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*PollList, error)
+	SubmitVote(ctx context.Context, in *SubmitVoteRequest, opts ...grpc.CallOption) (*SubmitVoteResponse, error)
+	ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*ClosePollResponse, error)
+	TallyPoll(ctx context.Context, in *TallyPollRequest, opts ...grpc.CallOption) (*TallyPollResponse, error)
+	CreateMailbox(ctx context.Context, in *CreateMailboxRequest, opts ...grpc.CallOption) (*CreateMailboxResponse, error)
+	GetMailboxes(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*MailboxList, error)
+	SubmitDealing(ctx context.Context, in *SubmitDealingRequest, opts ...grpc.CallOption) (*SubmitDealingResponse, error)
+	GetDealings(ctx context.Context, in *GetDealingsRequest, opts ...grpc.CallOption) (*GetDealingsResponse, error)
+	CheckDealings(ctx context.Context, in *CheckDealingsRequest, opts ...grpc.CallOption) (*CheckDealingsResponse, error)
+	AnswerComplaint(ctx context.Context, in *AnswerComplaintRequest, opts ...grpc.CallOption) (*AnswerComplaintResponse, error)
+	SendMailboxMessage(ctx context.Context, in *SendMailboxMessageRequest, opts ...grpc.CallOption) (*SendMailboxMessageResponse, error)
+	GetMailboxMessages(ctx context.Context, in *GetMailboxMessagesRequest, opts ...grpc.CallOption) (*GetMailboxMessagesResponse, error)
+	SubmitPartialDecryption(ctx context.Context, in *SubmitPartialDecryptionRequest, opts ...grpc.CallOption) (*SubmitPartialDecryptionResponse, error)
 }
 
 type cryptoServiceClient struct {
@@ -67,6 +97,16 @@ func (c *cryptoServiceClient) GetUsers(ctx context.Context, in *EmptyRequest, op
 	return out, nil
 }
 
+func (c *cryptoServiceClient) GetKeyChallenge(ctx context.Context, in *GetKeyChallengeRequest, opts ...grpc.CallOption) (*GetKeyChallengeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyChallengeResponse)
+	err := c.cc.Invoke(ctx, CryptoService_GetKeyChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cryptoServiceClient) RegisterPublicKey(ctx context.Context, in *RegisterPublicKeyRequest, opts ...grpc.CallOption) (*RegisterPublicKeyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RegisterPublicKeyResponse)
@@ -107,16 +147,171 @@ func (c *cryptoServiceClient) GetMessages(ctx context.Context, in *GetMessagesRe
 	return out, nil
 }
 
+func (c *cryptoServiceClient) CreatePoll(ctx context.Context, in *CreatePollRequest, opts ...grpc.CallOption) (*CreatePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePollResponse)
+	err := c.cc.Invoke(ctx, CryptoService_CreatePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) GetPolls(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*PollList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollList)
+	err := c.cc.Invoke(ctx, CryptoService_GetPolls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) SubmitVote(ctx context.Context, in *SubmitVoteRequest, opts ...grpc.CallOption) (*SubmitVoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitVoteResponse)
+	err := c.cc.Invoke(ctx, CryptoService_SubmitVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*ClosePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClosePollResponse)
+	err := c.cc.Invoke(ctx, CryptoService_ClosePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) TallyPoll(ctx context.Context, in *TallyPollRequest, opts ...grpc.CallOption) (*TallyPollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TallyPollResponse)
+	err := c.cc.Invoke(ctx, CryptoService_TallyPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) CreateMailbox(ctx context.Context, in *CreateMailboxRequest, opts ...grpc.CallOption) (*CreateMailboxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateMailboxResponse)
+	err := c.cc.Invoke(ctx, CryptoService_CreateMailbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) GetMailboxes(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*MailboxList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MailboxList)
+	err := c.cc.Invoke(ctx, CryptoService_GetMailboxes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) SubmitDealing(ctx context.Context, in *SubmitDealingRequest, opts ...grpc.CallOption) (*SubmitDealingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitDealingResponse)
+	err := c.cc.Invoke(ctx, CryptoService_SubmitDealing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) GetDealings(ctx context.Context, in *GetDealingsRequest, opts ...grpc.CallOption) (*GetDealingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDealingsResponse)
+	err := c.cc.Invoke(ctx, CryptoService_GetDealings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) CheckDealings(ctx context.Context, in *CheckDealingsRequest, opts ...grpc.CallOption) (*CheckDealingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDealingsResponse)
+	err := c.cc.Invoke(ctx, CryptoService_CheckDealings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) AnswerComplaint(ctx context.Context, in *AnswerComplaintRequest, opts ...grpc.CallOption) (*AnswerComplaintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnswerComplaintResponse)
+	err := c.cc.Invoke(ctx, CryptoService_AnswerComplaint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) SendMailboxMessage(ctx context.Context, in *SendMailboxMessageRequest, opts ...grpc.CallOption) (*SendMailboxMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMailboxMessageResponse)
+	err := c.cc.Invoke(ctx, CryptoService_SendMailboxMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) GetMailboxMessages(ctx context.Context, in *GetMailboxMessagesRequest, opts ...grpc.CallOption) (*GetMailboxMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMailboxMessagesResponse)
+	err := c.cc.Invoke(ctx, CryptoService_GetMailboxMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) SubmitPartialDecryption(ctx context.Context, in *SubmitPartialDecryptionRequest, opts ...grpc.CallOption) (*SubmitPartialDecryptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitPartialDecryptionResponse)
+	err := c.cc.Invoke(ctx, CryptoService_SubmitPartialDecryption_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CryptoServiceServer is the server API for CryptoService service.
 // All implementations must embed UnimplementedCryptoServiceServer
 // for forward compatibility.
 type CryptoServiceServer interface {
 	RegisterUser(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error)
 	GetUsers(context.Context, *EmptyRequest) (*UserList, error)
+	GetKeyChallenge(context.Context, *GetKeyChallengeRequest) (*GetKeyChallengeResponse, error)
 	RegisterPublicKey(context.Context, *RegisterPublicKeyRequest) (*RegisterPublicKeyResponse, error)
 	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
 	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
 	GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error)
+	CreatePoll(context.Context, *CreatePollRequest) (*CreatePollResponse, error)
+	GetPolls(context.Context, *EmptyRequest) (*PollList, error)
+	SubmitVote(context.Context, *SubmitVoteRequest) (*SubmitVoteResponse, error)
+	ClosePoll(context.Context, *ClosePollRequest) (*ClosePollResponse, error)
+	TallyPoll(context.Context, *TallyPollRequest) (*TallyPollResponse, error)
+	CreateMailbox(context.Context, *CreateMailboxRequest) (*CreateMailboxResponse, error)
+	GetMailboxes(context.Context, *EmptyRequest) (*MailboxList, error)
+	SubmitDealing(context.Context, *SubmitDealingRequest) (*SubmitDealingResponse, error)
+	GetDealings(context.Context, *GetDealingsRequest) (*GetDealingsResponse, error)
+	CheckDealings(context.Context, *CheckDealingsRequest) (*CheckDealingsResponse, error)
+	AnswerComplaint(context.Context, *AnswerComplaintRequest) (*AnswerComplaintResponse, error)
+	SendMailboxMessage(context.Context, *SendMailboxMessageRequest) (*SendMailboxMessageResponse, error)
+	GetMailboxMessages(context.Context, *GetMailboxMessagesRequest) (*GetMailboxMessagesResponse, error)
+	SubmitPartialDecryption(context.Context, *SubmitPartialDecryptionRequest) (*SubmitPartialDecryptionResponse, error)
 	mustEmbedUnimplementedCryptoServiceServer()
 }
 
@@ -133,6 +328,9 @@ func (UnimplementedCryptoServiceServer) RegisterUser(context.Context, *RegisterU
 func (UnimplementedCryptoServiceServer) GetUsers(context.Context, *EmptyRequest) (*UserList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUsers not implemented")
 }
+func (UnimplementedCryptoServiceServer) GetKeyChallenge(context.Context, *GetKeyChallengeRequest) (*GetKeyChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyChallenge not implemented")
+}
 func (UnimplementedCryptoServiceServer) RegisterPublicKey(context.Context, *RegisterPublicKeyRequest) (*RegisterPublicKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterPublicKey not implemented")
 }
@@ -145,6 +343,48 @@ func (UnimplementedCryptoServiceServer) SendMessage(context.Context, *SendMessag
 func (UnimplementedCryptoServiceServer) GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMessages not implemented")
 }
+func (UnimplementedCryptoServiceServer) CreatePoll(context.Context, *CreatePollRequest) (*CreatePollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePoll not implemented")
+}
+func (UnimplementedCryptoServiceServer) GetPolls(context.Context, *EmptyRequest) (*PollList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolls not implemented")
+}
+func (UnimplementedCryptoServiceServer) SubmitVote(context.Context, *SubmitVoteRequest) (*SubmitVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitVote not implemented")
+}
+func (UnimplementedCryptoServiceServer) ClosePoll(context.Context, *ClosePollRequest) (*ClosePollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClosePoll not implemented")
+}
+func (UnimplementedCryptoServiceServer) TallyPoll(context.Context, *TallyPollRequest) (*TallyPollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TallyPoll not implemented")
+}
+func (UnimplementedCryptoServiceServer) CreateMailbox(context.Context, *CreateMailboxRequest) (*CreateMailboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMailbox not implemented")
+}
+func (UnimplementedCryptoServiceServer) GetMailboxes(context.Context, *EmptyRequest) (*MailboxList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMailboxes not implemented")
+}
+func (UnimplementedCryptoServiceServer) SubmitDealing(context.Context, *SubmitDealingRequest) (*SubmitDealingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitDealing not implemented")
+}
+func (UnimplementedCryptoServiceServer) GetDealings(context.Context, *GetDealingsRequest) (*GetDealingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDealings not implemented")
+}
+func (UnimplementedCryptoServiceServer) CheckDealings(context.Context, *CheckDealingsRequest) (*CheckDealingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDealings not implemented")
+}
+func (UnimplementedCryptoServiceServer) AnswerComplaint(context.Context, *AnswerComplaintRequest) (*AnswerComplaintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerComplaint not implemented")
+}
+func (UnimplementedCryptoServiceServer) SendMailboxMessage(context.Context, *SendMailboxMessageRequest) (*SendMailboxMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMailboxMessage not implemented")
+}
+func (UnimplementedCryptoServiceServer) GetMailboxMessages(context.Context, *GetMailboxMessagesRequest) (*GetMailboxMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMailboxMessages not implemented")
+}
+func (UnimplementedCryptoServiceServer) SubmitPartialDecryption(context.Context, *SubmitPartialDecryptionRequest) (*SubmitPartialDecryptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPartialDecryption not implemented")
+}
 func (UnimplementedCryptoServiceServer) mustEmbedUnimplementedCryptoServiceServer() {}
 func (UnimplementedCryptoServiceServer) testEmbeddedByValue()                       {}
 
@@ -202,6 +442,24 @@ func _CryptoService_GetUsers_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CryptoService_GetKeyChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).GetKeyChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_GetKeyChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).GetKeyChallenge(ctx, req.(*GetKeyChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CryptoService_RegisterPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RegisterPublicKeyRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +532,258 @@ func _CryptoService_GetMessages_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CryptoService_CreatePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).CreatePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_CreatePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).CreatePoll(ctx, req.(*CreatePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_GetPolls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).GetPolls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_GetPolls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).GetPolls(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_SubmitVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).SubmitVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_SubmitVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).SubmitVote(ctx, req.(*SubmitVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_ClosePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).ClosePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_ClosePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).ClosePoll(ctx, req.(*ClosePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_TallyPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TallyPollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).TallyPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_TallyPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).TallyPoll(ctx, req.(*TallyPollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_CreateMailbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMailboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).CreateMailbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_CreateMailbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).CreateMailbox(ctx, req.(*CreateMailboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_GetMailboxes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).GetMailboxes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_GetMailboxes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).GetMailboxes(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_SubmitDealing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitDealingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).SubmitDealing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_SubmitDealing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).SubmitDealing(ctx, req.(*SubmitDealingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_GetDealings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDealingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).GetDealings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_GetDealings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).GetDealings(ctx, req.(*GetDealingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_CheckDealings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDealingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).CheckDealings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_CheckDealings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).CheckDealings(ctx, req.(*CheckDealingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_AnswerComplaint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerComplaintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).AnswerComplaint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_AnswerComplaint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).AnswerComplaint(ctx, req.(*AnswerComplaintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_SendMailboxMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMailboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).SendMailboxMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_SendMailboxMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).SendMailboxMessage(ctx, req.(*SendMailboxMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_GetMailboxMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMailboxMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).GetMailboxMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_GetMailboxMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).GetMailboxMessages(ctx, req.(*GetMailboxMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_SubmitPartialDecryption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPartialDecryptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).SubmitPartialDecryption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_SubmitPartialDecryption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).SubmitPartialDecryption(ctx, req.(*SubmitPartialDecryptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CryptoService_ServiceDesc is the grpc.ServiceDesc for CryptoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +799,10 @@ var CryptoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUsers",
 			Handler:    _CryptoService_GetUsers_Handler,
 		},
+		{
+			MethodName: "GetKeyChallenge",
+			Handler:    _CryptoService_GetKeyChallenge_Handler,
+		},
 		{
 			MethodName: "RegisterPublicKey",
 			Handler:    _CryptoService_RegisterPublicKey_Handler,
@@ -305,6 +819,62 @@ var CryptoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMessages",
 			Handler:    _CryptoService_GetMessages_Handler,
 		},
+		{
+			MethodName: "CreatePoll",
+			Handler:    _CryptoService_CreatePoll_Handler,
+		},
+		{
+			MethodName: "GetPolls",
+			Handler:    _CryptoService_GetPolls_Handler,
+		},
+		{
+			MethodName: "SubmitVote",
+			Handler:    _CryptoService_SubmitVote_Handler,
+		},
+		{
+			MethodName: "ClosePoll",
+			Handler:    _CryptoService_ClosePoll_Handler,
+		},
+		{
+			MethodName: "TallyPoll",
+			Handler:    _CryptoService_TallyPoll_Handler,
+		},
+		{
+			MethodName: "CreateMailbox",
+			Handler:    _CryptoService_CreateMailbox_Handler,
+		},
+		{
+			MethodName: "GetMailboxes",
+			Handler:    _CryptoService_GetMailboxes_Handler,
+		},
+		{
+			MethodName: "SubmitDealing",
+			Handler:    _CryptoService_SubmitDealing_Handler,
+		},
+		{
+			MethodName: "GetDealings",
+			Handler:    _CryptoService_GetDealings_Handler,
+		},
+		{
+			MethodName: "CheckDealings",
+			Handler:    _CryptoService_CheckDealings_Handler,
+		},
+		{
+			MethodName: "AnswerComplaint",
+			Handler:    _CryptoService_AnswerComplaint_Handler,
+		},
+		{
+			MethodName: "SendMailboxMessage",
+			Handler:    _CryptoService_SendMailboxMessage_Handler,
+		},
+		{
+			MethodName: "GetMailboxMessages",
+			Handler:    _CryptoService_GetMailboxMessages_Handler,
+		},
+		{
+			MethodName: "SubmitPartialDecryption",
+			Handler:    _CryptoService_SubmitPartialDecryption_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/crypto_service.proto",
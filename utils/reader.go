@@ -8,21 +8,51 @@ import (
 	"strings"
 )
 
+// stdin is shared by all reads, so lines pasted in one go and buffered by
+// one read are not lost to the next.
+var stdin = bufio.NewReader(os.Stdin)
+
 func Read(message ...string) string {
 	if len(message) > 0 {
 		fmt.Print(message[0])
 	}
-	reader := bufio.NewReader(os.Stdin)
-	text, _ := reader.ReadString('\n')
+	text, _ := stdin.ReadString('\n')
 	return strings.TrimSpace(text)
 }
 
+// ReadLines reads lines up to the first empty one and returns them joined
+// by newlines, for pasting multi-line text. Empty lines before the text are
+// skipped, so callers that need a way out should accept a word such as
+// "cancel". It returns "" only at the end of input.
+func ReadLines(message ...string) string {
+	if len(message) > 0 {
+		fmt.Println(message[0])
+	}
+
+	var lines []string
+	for {
+		line, err := stdin.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if err != nil || len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, line)
+		if err != nil {
+			break
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func ReadBytes(message ...string) ([]byte, error) {
 	if len(message) > 0 {
 		fmt.Print(message[0])
 	}
-	reader := bufio.NewReader(os.Stdin)
-	text, err := reader.ReadBytes('\n')
+	text, err := stdin.ReadBytes('\n')
 	if err != nil {
 		return nil, err
 	}